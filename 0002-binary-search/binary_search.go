@@ -1,13 +1,37 @@
 package binary_search
 
+import (
+	"cmp"
+	"math"
+)
+
 func Run() any {
 	arr := []int{11, 12, 22, 25, 34, 64, 90}
 	target := 25
 	index := SearchInt(arr, target)
+
+	less := func(a, b int) bool { return a < b }
+	dups := []int{1, 3, 3, 3, 5, 8, 13}
+	lo, hi := EqualRange(dups, 3, less)
+	stream := func(i int) (int, bool) { return i * i, true }
+	square, found := ExponentialSearch(stream, 1369, less)
+	peak := TernarySearch(0, 100, func(x int) int { return -(x - 37) * (x - 37) })
+	root := TernarySearchFloat(0, math.Pi, math.Sin, 1e-9)
+
 	return map[string]any{
-		"array":  arr,
-		"target": target,
-		"index":  index,
+		"array":               arr,
+		"target":              target,
+		"index":               index,
+		"duplicates":          dups,
+		"lower_bound_3":       LowerBound(dups, 3, less),
+		"upper_bound_3":       UpperBound(dups, 3, less),
+		"equal_range_3":       []int{lo, hi},
+		"insertion_point_4":   LowerBound(dups, 4, less),
+		"exponential_sqrt":    square,
+		"exponential_found":   found,
+		"interpolation_index": InterpolationSearch(arr, 64),
+		"ternary_peak":        peak,
+		"ternary_sin_argmax":  root,
 	}
 }
 
@@ -94,3 +118,147 @@ func SearchGeneric[T comparable](arr []T, target T, less func(T, T) bool) int {
 
 	return -1
 }
+
+func LowerBound[T any](arr []T, target T, less func(T, T) bool) int {
+	left, right := 0, len(arr)
+
+	for left < right {
+		mid := int(uint(left+right) >> 1)
+		if less(arr[mid], target) {
+			left = mid + 1
+		} else {
+			right = mid
+		}
+	}
+
+	return left
+}
+
+func UpperBound[T any](arr []T, target T, less func(T, T) bool) int {
+	left, right := 0, len(arr)
+
+	for left < right {
+		mid := int(uint(left+right) >> 1)
+		if less(target, arr[mid]) {
+			right = mid
+		} else {
+			left = mid + 1
+		}
+	}
+
+	return left
+}
+
+func EqualRange[T any](arr []T, target T, less func(T, T) bool) (int, int) {
+	lower := LowerBound(arr, target, less)
+	return lower, lower + UpperBound(arr[lower:], target, less)
+}
+
+func ExponentialSearch[T any](at func(i int) (T, bool), target T, less func(T, T) bool) (int, bool) {
+	below := func(i int) bool {
+		value, ok := at(i)
+		return ok && less(value, target)
+	}
+
+	if !below(0) {
+		value, ok := at(0)
+		return 0, ok && !less(target, value)
+	}
+
+	left, right := 0, 1
+	for below(right) {
+		left = right
+		if right > math.MaxInt/2 {
+			right = math.MaxInt
+			break
+		}
+		right *= 2
+	}
+
+	for left+1 < right {
+		mid := left + (right-left)/2
+		if below(mid) {
+			left = mid
+		} else {
+			right = mid
+		}
+	}
+
+	value, ok := at(right)
+	return right, ok && !less(target, value)
+}
+
+const maxTernaryIterations = 200
+
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+func InterpolationSearch[T Number](arr []T, target T) int {
+	left, right := 0, len(arr)-1
+
+	for left <= right && target >= arr[left] && target <= arr[right] {
+		if arr[left] == arr[right] {
+			if arr[left] == target {
+				return left
+			}
+			return -1
+		}
+
+		fraction := (float64(target) - float64(arr[left])) / (float64(arr[right]) - float64(arr[left]))
+		pos := left + int(fraction*float64(right-left))
+		pos = min(max(pos, left), right)
+
+		switch {
+		case arr[pos] == target:
+			for pos > left && arr[pos-1] == target {
+				pos--
+			}
+			return pos
+		case arr[pos] < target:
+			left = pos + 1
+		default:
+			right = pos - 1
+		}
+	}
+
+	return -1
+}
+
+func TernarySearch[T cmp.Ordered](lo, hi int, f func(int) T) int {
+	for hi-lo > 2 {
+		m1 := lo + (hi-lo)/3
+		m2 := hi - (hi-lo)/3
+		if f(m1) < f(m2) {
+			lo = m1 + 1
+		} else {
+			hi = m2
+		}
+	}
+
+	best := lo
+	for x := lo + 1; x <= hi; x++ {
+		if f(x) > f(best) {
+			best = x
+		}
+	}
+	return best
+}
+
+func TernarySearchFloat(lo, hi float64, f func(float64) float64, eps float64) float64 {
+	for range maxTernaryIterations {
+		if hi-lo <= eps {
+			break
+		}
+		m1 := lo + (hi-lo)/3
+		m2 := hi - (hi-lo)/3
+		if f(m1) < f(m2) {
+			lo = m1
+		} else {
+			hi = m2
+		}
+	}
+	return (lo + hi) / 2
+}
@@ -1,6 +1,10 @@
 package binary_search
 
 import (
+	"math"
+	"math/rand"
+	"slices"
+	"sort"
 	"testing"
 )
 
@@ -89,3 +93,144 @@ func TestSearchInt(t *testing.T) {
 		})
 	}
 }
+
+func intLess(a, b int) bool { return a < b }
+
+func TestBounds(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for n := range 40 {
+		arr := make([]int, n)
+		for i := range arr {
+			arr[i] = rng.Intn(10)
+		}
+		slices.Sort(arr)
+
+		for target := -1; target <= 10; target++ {
+			wantLower := sort.Search(n, func(i int) bool { return arr[i] >= target })
+			wantUpper := sort.Search(n, func(i int) bool { return arr[i] > target })
+
+			if got := LowerBound(arr, target, intLess); got != wantLower {
+				t.Errorf("LowerBound(%v, %d) = %d, expected %d", arr, target, got, wantLower)
+			}
+			if got := UpperBound(arr, target, intLess); got != wantUpper {
+				t.Errorf("UpperBound(%v, %d) = %d, expected %d", arr, target, got, wantUpper)
+			}
+			if lo, hi := EqualRange(arr, target, intLess); lo != wantLower || hi != wantUpper {
+				t.Errorf("EqualRange(%v, %d) = [%d, %d), expected [%d, %d)", arr, target, lo, hi, wantLower, wantUpper)
+			}
+		}
+	}
+}
+
+func TestExponentialSearch(t *testing.T) {
+	arr := []int{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31}
+	at := func(i int) (int, bool) {
+		if i < 0 || i >= len(arr) {
+			return 0, false
+		}
+		return arr[i], true
+	}
+
+	for target := 0; target <= 35; target++ {
+		want := LowerBound(arr, target, intLess)
+		got, found := ExponentialSearch(at, target, intLess)
+		if got != want {
+			t.Errorf("ExponentialSearch(%d) = %d, expected %d", target, got, want)
+		}
+		if found != (want < len(arr) && arr[want] == target) {
+			t.Errorf("ExponentialSearch(%d) found = %v", target, found)
+		}
+	}
+
+	probes := 0
+	squares := func(i int) (int, bool) {
+		probes++
+		return i * i, true
+	}
+	index, found := ExponentialSearch(squares, 1_000_000, intLess)
+	if index != 1000 || !found {
+		t.Errorf("expected 1000² at index 1000, got %d (found=%v)", index, found)
+	}
+	if probes > 3*int(math.Log2(1000))+3 {
+		t.Errorf("expected O(log i) probes on an unbounded source, got %d", probes)
+	}
+}
+
+func TestInterpolationSearch(t *testing.T) {
+	uniform := make([]int, 1000)
+	for i := range uniform {
+		uniform[i] = i * 7
+	}
+	for _, target := range []int{0, 7, 3500, 6993, 5, -1, 7000} {
+		want := -1
+		if target%7 == 0 && target >= 0 && target < 7000 {
+			want = target / 7
+		}
+		if got := InterpolationSearch(uniform, target); got != want {
+			t.Errorf("InterpolationSearch(uniform, %d) = %d, expected %d", target, got, want)
+		}
+	}
+
+	dups := []int{1, 2, 2, 2, 2, 2, 9}
+	if got := InterpolationSearch(dups, 2); got != 1 {
+		t.Errorf("expected the first duplicate at 1, got %d", got)
+	}
+	if got := InterpolationSearch([]int{4, 4, 4}, 4); got != 0 {
+		t.Errorf("expected 0 for a constant array, got %d", got)
+	}
+	if got := InterpolationSearch([]int{}, 4); got != -1 {
+		t.Errorf("expected -1 for an empty array, got %d", got)
+	}
+
+	floats := []float64{0.5, 1.25, 2, 3.75, 1e6}
+	if got := InterpolationSearch(floats, 3.75); got != 3 {
+		t.Errorf("expected 3.75 at index 3, got %d", got)
+	}
+
+	extremes := []int64{math.MinInt64, -1, 0, math.MaxInt64}
+	for i, v := range extremes {
+		if got := InterpolationSearch(extremes, v); got != i {
+			t.Errorf("InterpolationSearch(extremes, %d) = %d, expected %d", v, got, i)
+		}
+	}
+}
+
+func TestTernarySearch(t *testing.T) {
+	for peak := 0; peak <= 50; peak++ {
+		f := func(x int) int { return -(x - peak) * (x - peak) }
+		if got := TernarySearch(0, 50, f); got != peak {
+			t.Errorf("expected peak %d, got %d", peak, got)
+		}
+	}
+
+	x := TernarySearchFloat(0, math.Pi, math.Sin, 1e-9)
+	if math.Abs(x-math.Pi/2) > 1e-6 {
+		t.Errorf("expected sin to peak at π/2, got %v", x)
+	}
+
+	x = TernarySearchFloat(-10, 10, func(x float64) float64 { return -math.Abs(x - 3) }, 0)
+	if math.Abs(x-3) > 1e-9 {
+		t.Errorf("expected the search to stop with eps 0 and land on 3, got %v", x)
+	}
+}
+
+func BenchmarkLowerBound(b *testing.B) {
+	arr := make([]int, 1_000_000)
+	for i := range arr {
+		arr[i] = i * 2
+	}
+	for b.Loop() {
+		LowerBound(arr, 777_777, intLess)
+	}
+}
+
+func BenchmarkInterpolationSearch(b *testing.B) {
+	arr := make([]int, 1_000_000)
+	for i := range arr {
+		arr[i] = i * 2
+	}
+	for b.Loop() {
+		InterpolationSearch(arr, 777_776)
+	}
+}
@@ -1,6 +1,9 @@
 package two_crystal_ball_problem
 
-import "math"
+import (
+	"fmt"
+	"math"
+)
 
 func TwoCrystalBalls(breaks []bool) int {
 	if len(breaks) == 0 {
@@ -30,13 +33,93 @@ func TwoCrystalBalls(breaks []bool) int {
 	return -1
 }
 
+func FloorsCovered(drops, balls, limit int) int {
+	total, term := 0, 1
+	for i := 1; i <= balls && i <= drops; i++ {
+		term = term * (drops - i + 1) / i
+		total += term
+		if term >= limit || total >= limit {
+			return limit
+		}
+	}
+	return total
+}
+
+func MinDrops(floors, balls int) int {
+	if floors <= 0 || balls <= 0 {
+		return 0
+	}
+
+	lo, hi := 0, floors
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		if FloorsCovered(mid, balls, floors) >= floors {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return lo
+}
+
+func FirstTrue(n, balls int, pred func(i int) bool) (int, int) {
+	if n <= 0 || balls <= 0 {
+		return -1, 0
+	}
+
+	remaining := MinDrops(n, balls)
+	lo, hi := 0, n
+	result, drops := -1, 0
+
+	for lo < hi {
+		x := min(lo+FloorsCovered(remaining-1, balls-1, hi-lo), hi-1)
+		remaining--
+		drops++
+
+		if pred(x) {
+			result, hi = x, x
+			balls--
+		} else {
+			lo = x + 1
+		}
+	}
+
+	return result, drops
+}
+
+func DropSchedule(floors, balls int) []int {
+	var schedule []int
+	FirstTrue(floors, balls, func(i int) bool {
+		schedule = append(schedule, i)
+		return false
+	})
+	return schedule
+}
+
+func KCrystalBalls(breaks []bool, balls int) int {
+	index, _ := FirstTrue(len(breaks), balls, func(i int) bool { return breaks[i] })
+	return index
+}
+
 func Run() any {
 	breaks := []bool{false, false, false, false, false, false, false, true, true, true}
 	result := TwoCrystalBalls(breaks)
 
+	worstCase := make(map[string]int)
+	for _, balls := range []int{1, 2, 3, 7} {
+		worstCase[fmt.Sprintf("%d_balls", balls)] = MinDrops(100, balls)
+	}
+
+	firstBroken, drops := FirstTrue(100, 2, func(floor int) bool { return floor >= 73 })
+
 	return map[string]any{
-		"breaks_array":   breaks,
-		"breaking_floor": result,
-		"description":    "First floor where crystal ball breaks",
+		"breaks_array":          breaks,
+		"breaking_floor":        result,
+		"three_ball_floor":      KCrystalBalls(breaks, 3),
+		"description":           "First floor where crystal ball breaks",
+		"worst_case_drops_100":  worstCase,
+		"two_ball_schedule_100": DropSchedule(100, 2),
+		"first_true_73":         firstBroken,
+		"first_true_73_drops":   drops,
 	}
 }
@@ -1,6 +1,9 @@
 package two_crystal_ball_problem
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+)
 
 func TestTwoCrystalBalls(t *testing.T) {
 	tests := []struct {
@@ -93,6 +96,86 @@ func make100FloorsBreakAt(breakFloor int) []bool {
 	return floors
 }
 
+func TestFirstTrueExhaustive(t *testing.T) {
+	for n := 0; n <= 60; n++ {
+		for balls := 1; balls <= 4; balls++ {
+			worst := MinDrops(n, balls)
+			for answer := 0; answer <= n; answer++ {
+				broken := 0
+				got, drops := FirstTrue(n, balls, func(i int) bool {
+					if i < 0 || i >= n {
+						t.Fatalf("n=%d: probed out-of-range index %d", n, i)
+					}
+					if i >= answer {
+						broken++
+						return true
+					}
+					return false
+				})
+
+				want := answer
+				if answer == n {
+					want = -1
+				}
+				if got != want {
+					t.Errorf("n=%d balls=%d answer=%d: got %d", n, balls, answer, got)
+				}
+				if drops > worst {
+					t.Errorf("n=%d balls=%d answer=%d: %d drops exceeds the %d-drop bound", n, balls, answer, drops, worst)
+				}
+				if broken > balls {
+					t.Errorf("n=%d balls=%d answer=%d: broke %d balls", n, balls, answer, broken)
+				}
+			}
+		}
+	}
+}
+
+func TestMinDrops(t *testing.T) {
+	tests := []struct {
+		floors, balls, expected int
+	}{
+		{0, 2, 0},
+		{1, 1, 1},
+		{100, 1, 100},
+		{100, 2, 14},
+		{100, 3, 9},
+		{100, 7, 7},
+		{100, 50, 7},
+		{1_000_000, 2, 1414},
+		{1 << 40, 64, 41},
+	}
+
+	for _, tt := range tests {
+		if got := MinDrops(tt.floors, tt.balls); got != tt.expected {
+			t.Errorf("MinDrops(%d, %d) = %d, expected %d", tt.floors, tt.balls, got, tt.expected)
+		}
+	}
+}
+
+func TestDropSchedule(t *testing.T) {
+	expected := []int{13, 26, 38, 49, 59, 68, 76, 83, 89, 94, 98, 99}
+	if got := DropSchedule(100, 2); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected schedule %v, got %v", expected, got)
+	}
+}
+
+func TestKCrystalBallsMatchesTwoCrystalBalls(t *testing.T) {
+	for n := 0; n <= 40; n++ {
+		for answer := 0; answer <= n; answer++ {
+			breaks := make([]bool, n)
+			for i := answer; i < n; i++ {
+				breaks[i] = true
+			}
+			for _, balls := range []int{1, 2, 5} {
+				if got, want := KCrystalBalls(breaks, balls), TwoCrystalBalls(breaks); got != want {
+					t.Errorf("n=%d answer=%d balls=%d: KCrystalBalls %d, TwoCrystalBalls %d", n, answer, balls, got, want)
+				}
+			}
+		}
+	}
+}
+
 func TestRun(t *testing.T) {
 	result := Run()
 	if result == nil {
@@ -146,3 +229,10 @@ func BenchmarkTwoCrystalBalls10000(b *testing.B) {
 		TwoCrystalBalls(breaks)
 	}
 }
+
+func BenchmarkFirstTrue(b *testing.B) {
+	breaks := make100FloorsBreakAt(73)
+	for b.Loop() {
+		FirstTrue(len(breaks), 2, func(i int) bool { return breaks[i] })
+	}
+}
@@ -93,6 +93,36 @@ func BubbleSortFloat64(arr []float64) []float64 {
 	return result
 }
 
+type Tracer interface {
+	Compare(i, j int)
+	Swap(i, j int)
+	Write(i, value int)
+	Copy(dst, src int)
+}
+
+func BubbleSortTraced(arr []int, t Tracer) {
+	n := len(arr)
+
+	for i := range n - 1 {
+		swapped := false
+		for j := range n - i - 1 {
+			if t != nil {
+				t.Compare(j, j+1)
+			}
+			if arr[j] > arr[j+1] {
+				arr[j], arr[j+1] = arr[j+1], arr[j]
+				if t != nil {
+					t.Swap(j, j+1)
+				}
+				swapped = true
+			}
+		}
+		if !swapped {
+			break
+		}
+	}
+}
+
 func BubbleSortWithSteps(arr []int) ([]int, [][]int) {
 	n := len(arr)
 	result := make([]int, n)
@@ -1,6 +1,7 @@
 package bubble_sort
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -70,3 +71,49 @@ func TestBubbleSortInt(t *testing.T) {
 		})
 	}
 }
+
+type countingTracer struct {
+	compares, swaps, writes int
+}
+
+func (c *countingTracer) Compare(i, j int)   { c.compares++ }
+func (c *countingTracer) Swap(i, j int)      { c.swaps++ }
+func (c *countingTracer) Write(i, value int) { c.writes++ }
+func (c *countingTracer) Copy(dst, src int)  { c.writes++ }
+
+func TestBubbleSortTraced(t *testing.T) {
+	arr := []int{3, 1, 2}
+	var counts countingTracer
+	BubbleSortTraced(arr, &counts)
+
+	if !reflect.DeepEqual(arr, []int{1, 2, 3}) {
+		t.Errorf("expected [1 2 3], got %v", arr)
+	}
+	if want := (countingTracer{compares: 3, swaps: 2}); counts != want {
+		t.Errorf("expected counts %+v, got %+v", want, counts)
+	}
+
+	counts = countingTracer{}
+	BubbleSortTraced([]int{1, 2, 3, 4}, &counts)
+	if counts.compares != 3 || counts.swaps != 0 {
+		t.Errorf("sorted input should stop after one pass, got %+v", counts)
+	}
+
+	arr = []int{2, 1}
+	BubbleSortTraced(arr, nil)
+	if !reflect.DeepEqual(arr, []int{1, 2}) {
+		t.Errorf("expected a nil tracer to sort untraced, got %v", arr)
+	}
+}
+
+func TestBubbleSortWithSteps(t *testing.T) {
+	result, steps := BubbleSortWithSteps([]int{3, 1, 2})
+	want := [][]int{{3, 1, 2}, {1, 3, 2}, {1, 2, 3}}
+
+	if !reflect.DeepEqual(result, []int{1, 2, 3}) {
+		t.Errorf("expected [1 2 3], got %v", result)
+	}
+	if !reflect.DeepEqual(steps, want) {
+		t.Errorf("expected steps %v, got %v", want, steps)
+	}
+}
@@ -1,6 +1,61 @@
 package ring_buffers
 
-import "errors"
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var ErrClosed = errors.New("ring buffer is closed")
+
+type OverflowPolicy int
+
+const (
+	BlockWhenFull OverflowPolicy = iota
+	OverwriteOldest
+)
+
+type cacheLinePad [64]byte
+
+const (
+	backoffSpins = 16
+	minBackoff   = time.Microsecond
+	maxBackoff   = time.Millisecond
+)
+
+type backoff struct {
+	spins int
+	delay time.Duration
+	timer *time.Timer
+}
+
+func (b *backoff) pause(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if b.spins < backoffSpins {
+		b.spins++
+		runtime.Gosched()
+		return nil
+	}
+
+	b.delay = min(max(2*b.delay, minBackoff), maxBackoff)
+	if b.timer == nil {
+		b.timer = time.NewTimer(b.delay)
+	} else {
+		b.timer.Reset(b.delay)
+	}
+	select {
+	case <-ctx.Done():
+		b.timer.Stop()
+		return ctx.Err()
+	case <-b.timer.C:
+		return nil
+	}
+}
 
 type RingBuffer[T any] struct {
 	buffer []T
@@ -47,6 +102,17 @@ func (rb *RingBuffer[T]) Dequeue() (T, error) {
 	return item, nil
 }
 
+func (rb *RingBuffer[T]) EnqueueOverwrite(item T) (T, bool) {
+	var evicted T
+	overwritten := rb.IsFull()
+	if overwritten {
+		evicted, _ = rb.Dequeue()
+	}
+
+	rb.Enqueue(item)
+	return evicted, overwritten
+}
+
 func (rb *RingBuffer[T]) Peek() (T, error) {
 	var zero T
 	if rb.IsEmpty() {
@@ -93,6 +159,308 @@ func (rb *RingBuffer[T]) ToSlice() []T {
 	return result
 }
 
+func nextPowerOfTwo(n int) int {
+	capacity := 1
+	for capacity < n {
+		capacity <<= 1
+	}
+	return capacity
+}
+
+type SPSCRing[T any] struct {
+	buffer []T
+	mask   uint64
+	_      cacheLinePad
+	head   atomic.Uint64
+	_      cacheLinePad
+	tail   atomic.Uint64
+	_      cacheLinePad
+}
+
+func NewSPSCRing[T any](capacity int) *SPSCRing[T] {
+	capacity = nextPowerOfTwo(max(capacity, 1))
+	return &SPSCRing[T]{
+		buffer: make([]T, capacity),
+		mask:   uint64(capacity - 1),
+	}
+}
+
+func (r *SPSCRing[T]) TryEnqueue(item T) bool {
+	tail := r.tail.Load()
+	if tail-r.head.Load() == uint64(len(r.buffer)) {
+		return false
+	}
+
+	r.buffer[tail&r.mask] = item
+	r.tail.Store(tail + 1)
+	return true
+}
+
+func (r *SPSCRing[T]) TryDequeue() (T, bool) {
+	var zero T
+	head := r.head.Load()
+	if head == r.tail.Load() {
+		return zero, false
+	}
+
+	item := r.buffer[head&r.mask]
+	r.buffer[head&r.mask] = zero
+	r.head.Store(head + 1)
+	return item, true
+}
+
+func (r *SPSCRing[T]) Enqueue(ctx context.Context, item T) error {
+	var wait backoff
+	for !r.TryEnqueue(item) {
+		if err := wait.pause(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *SPSCRing[T]) Dequeue(ctx context.Context) (T, error) {
+	var wait backoff
+	for {
+		if item, ok := r.TryDequeue(); ok {
+			return item, nil
+		}
+		if err := wait.pause(ctx); err != nil {
+			var zero T
+			return zero, err
+		}
+	}
+}
+
+func (r *SPSCRing[T]) Size() int {
+	return int(r.tail.Load() - r.head.Load())
+}
+
+func (r *SPSCRing[T]) Capacity() int {
+	return len(r.buffer)
+}
+
+type mpmcCell[T any] struct {
+	sequence atomic.Uint64
+	value    T
+}
+
+type MPMCQueue[T any] struct {
+	buffer     []mpmcCell[T]
+	mask       uint64
+	_          cacheLinePad
+	enqueuePos atomic.Uint64
+	_          cacheLinePad
+	dequeuePos atomic.Uint64
+	_          cacheLinePad
+}
+
+func NewMPMCQueue[T any](capacity int) *MPMCQueue[T] {
+	capacity = nextPowerOfTwo(max(capacity, 2))
+	q := &MPMCQueue[T]{
+		buffer: make([]mpmcCell[T], capacity),
+		mask:   uint64(capacity - 1),
+	}
+	for i := range q.buffer {
+		q.buffer[i].sequence.Store(uint64(i))
+	}
+	return q
+}
+
+func (q *MPMCQueue[T]) TryEnqueue(item T) bool {
+	pos := q.enqueuePos.Load()
+	for {
+		cell := &q.buffer[pos&q.mask]
+		diff := int64(cell.sequence.Load()) - int64(pos)
+
+		if diff == 0 {
+			if q.enqueuePos.CompareAndSwap(pos, pos+1) {
+				cell.value = item
+				cell.sequence.Store(pos + 1)
+				return true
+			}
+		} else if diff < 0 {
+			return false
+		}
+
+		pos = q.enqueuePos.Load()
+	}
+}
+
+func (q *MPMCQueue[T]) TryDequeue() (T, bool) {
+	var zero T
+	pos := q.dequeuePos.Load()
+	for {
+		cell := &q.buffer[pos&q.mask]
+		diff := int64(cell.sequence.Load()) - int64(pos+1)
+
+		if diff == 0 {
+			if q.dequeuePos.CompareAndSwap(pos, pos+1) {
+				item := cell.value
+				cell.value = zero
+				cell.sequence.Store(pos + q.mask + 1)
+				return item, true
+			}
+		} else if diff < 0 {
+			return zero, false
+		}
+
+		pos = q.dequeuePos.Load()
+	}
+}
+
+func (q *MPMCQueue[T]) Enqueue(ctx context.Context, item T) error {
+	var wait backoff
+	for !q.TryEnqueue(item) {
+		if err := wait.pause(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (q *MPMCQueue[T]) Dequeue(ctx context.Context) (T, error) {
+	var wait backoff
+	for {
+		if item, ok := q.TryDequeue(); ok {
+			return item, nil
+		}
+		if err := wait.pause(ctx); err != nil {
+			var zero T
+			return zero, err
+		}
+	}
+}
+
+func (q *MPMCQueue[T]) Capacity() int {
+	return len(q.buffer)
+}
+
+type BlockingRingBuffer[T any] struct {
+	mu      sync.Mutex
+	ring    *RingBuffer[T]
+	policy  OverflowPolicy
+	changed chan struct{}
+	closed  bool
+	dropped int
+}
+
+func NewBlockingRingBuffer[T any](capacity int, policy OverflowPolicy) *BlockingRingBuffer[T] {
+	return &BlockingRingBuffer[T]{
+		ring:    NewRingBuffer[T](capacity),
+		policy:  policy,
+		changed: make(chan struct{}),
+	}
+}
+
+func (b *BlockingRingBuffer[T]) broadcast() {
+	close(b.changed)
+	b.changed = make(chan struct{})
+}
+
+func (b *BlockingRingBuffer[T]) Put(ctx context.Context, item T) error {
+	for {
+		b.mu.Lock()
+		if b.closed {
+			b.mu.Unlock()
+			return ErrClosed
+		}
+
+		if b.policy == OverwriteOldest {
+			if _, overwritten := b.ring.EnqueueOverwrite(item); overwritten {
+				b.dropped++
+			}
+			b.broadcast()
+			b.mu.Unlock()
+			return nil
+		}
+
+		if !b.ring.IsFull() {
+			b.ring.Enqueue(item)
+			b.broadcast()
+			b.mu.Unlock()
+			return nil
+		}
+
+		wait := b.changed
+		b.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-wait:
+		}
+	}
+}
+
+func (b *BlockingRingBuffer[T]) Take(ctx context.Context) (T, error) {
+	var zero T
+	for {
+		b.mu.Lock()
+		if !b.ring.IsEmpty() {
+			item, _ := b.ring.Dequeue()
+			b.broadcast()
+			b.mu.Unlock()
+			return item, nil
+		}
+
+		if b.closed {
+			b.mu.Unlock()
+			return zero, ErrClosed
+		}
+
+		wait := b.changed
+		b.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return zero, ctx.Err()
+		case <-wait:
+		}
+	}
+}
+
+func (b *BlockingRingBuffer[T]) TryPut(item T) bool {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return b.Put(ctx, item) == nil
+}
+
+func (b *BlockingRingBuffer[T]) TryTake() (T, bool) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	item, err := b.Take(ctx)
+	return item, err == nil
+}
+
+func (b *BlockingRingBuffer[T]) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.closed {
+		b.closed = true
+		b.broadcast()
+	}
+}
+
+func (b *BlockingRingBuffer[T]) Size() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.ring.Size()
+}
+
+func (b *BlockingRingBuffer[T]) Dropped() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.dropped
+}
+
+func (b *BlockingRingBuffer[T]) Snapshot() []T {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.ring.ToSlice()
+}
+
 func Run() any {
 	rb := NewRingBuffer[int](3)
 
@@ -103,10 +471,17 @@ func Run() any {
 	first, _ := rb.Dequeue()
 	rb.Enqueue(4)
 
+	telemetry := NewBlockingRingBuffer[int](3, OverwriteOldest)
+	for i := range 5 {
+		telemetry.Put(context.Background(), i)
+	}
+
 	return map[string]any{
-		"first_dequeued": first,
-		"remaining":      rb.ToSlice(),
-		"is_full":        rb.IsFull(),
-		"size":           rb.Size(),
+		"first_dequeued":    first,
+		"remaining":         rb.ToSlice(),
+		"is_full":           rb.IsFull(),
+		"size":              rb.Size(),
+		"telemetry_latest":  telemetry.Snapshot(),
+		"telemetry_dropped": telemetry.Dropped(),
 	}
 }
@@ -1,8 +1,12 @@
 package ring_buffers
 
 import (
+	"context"
+	"errors"
 	"reflect"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestNewRingBuffer(t *testing.T) {
@@ -221,6 +225,306 @@ func TestRun(t *testing.T) {
 	}
 }
 
+func TestEnqueueOverwrite(t *testing.T) {
+	rb := NewRingBuffer[int](3)
+
+	for i := range 3 {
+		if _, overwritten := rb.EnqueueOverwrite(i); overwritten {
+			t.Errorf("Unexpected overwrite while inserting %d", i)
+		}
+	}
+
+	evicted, overwritten := rb.EnqueueOverwrite(3)
+	if !overwritten || evicted != 0 {
+		t.Errorf("Expected to evict 0, got %d (overwritten=%v)", evicted, overwritten)
+	}
+
+	if !reflect.DeepEqual(rb.ToSlice(), []int{1, 2, 3}) {
+		t.Errorf("Expected [1 2 3], got %v", rb.ToSlice())
+	}
+}
+
+func TestSPSCRing(t *testing.T) {
+	r := NewSPSCRing[int](3)
+	if r.Capacity() != 4 {
+		t.Errorf("Expected capacity rounded up to 4, got %d", r.Capacity())
+	}
+
+	for i := range 4 {
+		if !r.TryEnqueue(i) {
+			t.Fatalf("Unexpected full ring at %d", i)
+		}
+	}
+	if r.TryEnqueue(4) {
+		t.Error("Expected enqueue on full ring to fail")
+	}
+
+	for i := range 4 {
+		value, ok := r.TryDequeue()
+		if !ok || value != i {
+			t.Errorf("Expected %d, got %d (ok=%v)", i, value, ok)
+		}
+	}
+	if _, ok := r.TryDequeue(); ok {
+		t.Error("Expected dequeue on empty ring to fail")
+	}
+}
+
+func TestSPSCRingConcurrent(t *testing.T) {
+	r := NewSPSCRing[int](64)
+	const total = 20000
+
+	ctx := context.Background()
+
+	go func() {
+		for i := range total {
+			r.Enqueue(ctx, i)
+		}
+	}()
+
+	for expected := range total {
+		value, _ := r.Dequeue(ctx)
+		if value != expected {
+			t.Fatalf("Expected %d, got %d", expected, value)
+		}
+	}
+}
+
+func TestMPMCQueue(t *testing.T) {
+	q := NewMPMCQueue[string](2)
+
+	if !q.TryEnqueue("a") || !q.TryEnqueue("b") {
+		t.Fatal("Expected two enqueues to succeed")
+	}
+	if q.TryEnqueue("c") {
+		t.Error("Expected enqueue on full queue to fail")
+	}
+
+	first, _ := q.TryDequeue()
+	second, _ := q.TryDequeue()
+	if first != "a" || second != "b" {
+		t.Errorf("Expected a, b; got %s, %s", first, second)
+	}
+	if _, ok := q.TryDequeue(); ok {
+		t.Error("Expected dequeue on empty queue to fail")
+	}
+}
+
+func TestMPMCQueueConcurrent(t *testing.T) {
+	q := NewMPMCQueue[int](128)
+	const producers, consumers, perProducer = 4, 4, 10000
+
+	var wg sync.WaitGroup
+	for p := range producers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range perProducer {
+				q.Enqueue(context.Background(), p*perProducer+i+1)
+			}
+		}()
+	}
+
+	sums := make([]int, consumers)
+	var consumed sync.WaitGroup
+	for c := range consumers {
+		consumed.Add(1)
+		go func() {
+			defer consumed.Done()
+			for range producers * perProducer / consumers {
+				value, _ := q.Dequeue(context.Background())
+				sums[c] += value
+			}
+		}()
+	}
+
+	wg.Wait()
+	consumed.Wait()
+
+	total := 0
+	for _, sum := range sums {
+		total += sum
+	}
+
+	n := producers * perProducer
+	if expected := n * (n + 1) / 2; total != expected {
+		t.Errorf("Expected sum %d, got %d", expected, total)
+	}
+}
+
+func TestMPMCQueueContextCancellation(t *testing.T) {
+	q := NewMPMCQueue[int](2)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := q.Dequeue(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestBackoffIsCappedAndCancellable(t *testing.T) {
+	var wait backoff
+	for range backoffSpins + 12 {
+		if err := wait.pause(context.Background()); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if wait.delay != maxBackoff {
+		t.Errorf("Expected the delay to be capped at %v, got %v", maxBackoff, wait.delay)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := wait.pause(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestSPSCRingContextCancellation(t *testing.T) {
+	r := NewSPSCRing[int](1)
+	r.TryEnqueue(1)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if err := r.Enqueue(ctx, 2); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Millisecond+50*time.Millisecond {
+		t.Errorf("Expected Enqueue to return soon after the deadline, took %v", elapsed)
+	}
+}
+
+func TestBlockingRingBufferPutTake(t *testing.T) {
+	b := NewBlockingRingBuffer[int](2, BlockWhenFull)
+	ctx := context.Background()
+
+	b.Put(ctx, 1)
+	b.Put(ctx, 2)
+	if b.TryPut(3) {
+		t.Error("Expected TryPut on full buffer to fail")
+	}
+
+	done := make(chan error)
+	go func() {
+		done <- b.Put(ctx, 3)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("Expected Put to block while buffer is full")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	if value, _ := b.Take(ctx); value != 1 {
+		t.Errorf("Expected 1, got %d", value)
+	}
+	if err := <-done; err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(b.Snapshot(), []int{2, 3}) {
+		t.Errorf("Expected [2 3], got %v", b.Snapshot())
+	}
+}
+
+func TestBlockingRingBufferCancellation(t *testing.T) {
+	b := NewBlockingRingBuffer[int](1, BlockWhenFull)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		cancel()
+	}()
+
+	if _, err := b.Take(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+
+	b.Put(context.Background(), 1)
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	if err := b.Put(ctx, 2); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestBlockingRingBufferClose(t *testing.T) {
+	b := NewBlockingRingBuffer[int](2, BlockWhenFull)
+	b.Put(context.Background(), 1)
+
+	waiting := make(chan error)
+	empty := NewBlockingRingBuffer[int](1, BlockWhenFull)
+	go func() {
+		_, err := empty.Take(context.Background())
+		waiting <- err
+	}()
+	empty.Close()
+	if err := <-waiting; !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected ErrClosed for blocked Take, got %v", err)
+	}
+
+	b.Close()
+	if err := b.Put(context.Background(), 2); !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected ErrClosed, got %v", err)
+	}
+	if value, err := b.Take(context.Background()); err != nil || value != 1 {
+		t.Errorf("Expected to drain 1 after close, got %d (%v)", value, err)
+	}
+	if _, err := b.Take(context.Background()); !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected ErrClosed once drained, got %v", err)
+	}
+}
+
+func TestBlockingRingBufferOverwriteOldest(t *testing.T) {
+	b := NewBlockingRingBuffer[int](3, OverwriteOldest)
+	for i := range 10 {
+		if err := b.Put(context.Background(), i); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	if !reflect.DeepEqual(b.Snapshot(), []int{7, 8, 9}) {
+		t.Errorf("Expected [7 8 9], got %v", b.Snapshot())
+	}
+	if b.Dropped() != 7 {
+		t.Errorf("Expected 7 dropped, got %d", b.Dropped())
+	}
+}
+
+func TestBlockingRingBufferConcurrent(t *testing.T) {
+	b := NewBlockingRingBuffer[int](8, BlockWhenFull)
+	const producers, perProducer = 4, 2000
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for range producers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range perProducer {
+				b.Put(ctx, i)
+			}
+		}()
+	}
+
+	counts := make(map[int]int)
+	for range producers * perProducer {
+		value, err := b.Take(ctx)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		counts[value]++
+	}
+	wg.Wait()
+
+	for i := range perProducer {
+		if counts[i] != producers {
+			t.Fatalf("Expected value %d %d times, got %d", i, producers, counts[i])
+		}
+	}
+}
+
 func BenchmarkEnqueue(b *testing.B) {
 	rb := NewRingBuffer[int](1000)
 
@@ -261,3 +565,58 @@ func BenchmarkMixedOperations(b *testing.B) {
 		}
 	}
 }
+
+func BenchmarkThroughputSPSCRing(b *testing.B) {
+	r := NewSPSCRing[int](1024)
+	ctx := context.Background()
+	done := make(chan struct{})
+	n := b.N
+
+	go func() {
+		for range n {
+			r.Dequeue(ctx)
+		}
+		close(done)
+	}()
+
+	b.ResetTimer()
+	for i := range n {
+		r.Enqueue(ctx, i)
+	}
+	<-done
+}
+
+func BenchmarkThroughputMPMCQueue(b *testing.B) {
+	q := NewMPMCQueue[int](1024)
+	ctx := context.Background()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			q.Enqueue(ctx, 1)
+			q.Dequeue(ctx)
+		}
+	})
+}
+
+func BenchmarkThroughputBlockingRingBuffer(b *testing.B) {
+	rb := NewBlockingRingBuffer[int](1024, BlockWhenFull)
+	ctx := context.Background()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			rb.Put(ctx, 1)
+			rb.Take(ctx)
+		}
+	})
+}
+
+func BenchmarkThroughputChannel(b *testing.B) {
+	ch := make(chan int, 1024)
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			ch <- 1
+			<-ch
+		}
+	})
+}
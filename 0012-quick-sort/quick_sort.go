@@ -1,10 +1,22 @@
 package quick_sort
 
 import (
+	"cmp"
+	"context"
 	"fmt"
+	"math/bits"
 	"math/rand"
+	"runtime"
+	"sync"
 )
 
+type Tracer interface {
+	Compare(i, j int)
+	Swap(i, j int)
+	Write(i, value int)
+	Copy(dst, src int)
+}
+
 func QuickSort(arr []int) []int {
 	if len(arr) <= 1 {
 		return arr
@@ -18,24 +30,52 @@ func QuickSort(arr []int) []int {
 
 func quickSortHelper(arr []int, low, high int) {
 	if low < high {
-		pivotIndex := partition(arr, low, high)
-		quickSortHelper(arr, low, pivotIndex-1)
-		quickSortHelper(arr, pivotIndex+1, high)
+		QuickSortFunc(arr[low:high+1], cmp.Compare[int])
+	}
+}
+
+func QuickSortFunc[T any](s []T, compare func(a, b T) int) {
+	quickSortFunc(s, 0, compare, nil)
+}
+
+func quickSortFunc[T any](s []T, offset int, compare func(a, b T) int, t Tracer) {
+	if len(s) > 1 {
+		pivotIndex := PartitionFuncTraced(s, offset, compare, t)
+		quickSortFunc(s[:pivotIndex], offset, compare, t)
+		quickSortFunc(s[pivotIndex+1:], offset+pivotIndex+1, compare, t)
 	}
 }
 
 func partition(arr []int, low, high int) int {
-	pivot := arr[high]
-	i := low - 1
+	return low + PartitionFunc(arr[low:high+1], cmp.Compare[int])
+}
+
+func PartitionFunc[T any](s []T, compare func(a, b T) int) int {
+	return PartitionFuncTraced(s, 0, compare, nil)
+}
 
-	for j := low; j < high; j++ {
-		if arr[j] <= pivot {
+func PartitionFuncTraced[T any](s []T, offset int, compare func(a, b T) int, t Tracer) int {
+	high := len(s) - 1
+	pivot := s[high]
+	i := -1
+
+	for j := 0; j < high; j++ {
+		if t != nil {
+			t.Compare(offset+j, offset+high)
+		}
+		if compare(s[j], pivot) <= 0 {
 			i++
-			arr[i], arr[j] = arr[j], arr[i]
+			s[i], s[j] = s[j], s[i]
+			if t != nil {
+				t.Swap(offset+i, offset+j)
+			}
 		}
 	}
 
-	arr[i+1], arr[high] = arr[high], arr[i+1]
+	s[i+1], s[high] = s[high], s[i+1]
+	if t != nil {
+		t.Swap(offset+i+1, offset+high)
+	}
 	return i + 1
 }
 
@@ -74,30 +114,50 @@ func QuickSortMedianOfThree(arr []int) []int {
 
 func quickSortMedianHelper(arr []int, low, high int) {
 	if low < high {
-		medianIndex := medianOfThree(arr, low, high)
-		arr[medianIndex], arr[high] = arr[high], arr[medianIndex]
+		QuickSortMedianOfThreeFunc(arr[low:high+1], cmp.Compare[int])
+	}
+}
 
-		pivotIndex := partition(arr, low, high)
-		quickSortMedianHelper(arr, low, pivotIndex-1)
-		quickSortMedianHelper(arr, pivotIndex+1, high)
+func QuickSortMedianOfThreeFunc[T any](s []T, compare func(a, b T) int) {
+	if len(s) > 1 {
+		medianIndex := MedianOfThreeFunc(s, compare)
+		s[medianIndex], s[len(s)-1] = s[len(s)-1], s[medianIndex]
+
+		pivotIndex := PartitionFunc(s, compare)
+		QuickSortMedianOfThreeFunc(s[:pivotIndex], compare)
+		QuickSortMedianOfThreeFunc(s[pivotIndex+1:], compare)
 	}
 }
 
 func medianOfThree(arr []int, low, high int) int {
-	mid := (low + high) / 2
+	return low + MedianOfThreeFunc(arr[low:high+1], cmp.Compare[int])
+}
+
+func MedianOfThreeFunc[T any](s []T, compare func(a, b T) int) int {
+	return MedianOfThreeFuncTraced(s, 0, compare, nil)
+}
 
-	if arr[low] > arr[mid] {
-		if arr[mid] > arr[high] {
+func MedianOfThreeFuncTraced[T any](s []T, offset int, compare func(a, b T) int, t Tracer) int {
+	low, mid, high := 0, (len(s)-1)/2, len(s)-1
+	greater := func(i, j int) bool {
+		if t != nil {
+			t.Compare(offset+i, offset+j)
+		}
+		return compare(s[i], s[j]) > 0
+	}
+
+	if greater(low, mid) {
+		if greater(mid, high) {
 			return mid
-		} else if arr[low] > arr[high] {
+		} else if greater(low, high) {
 			return high
 		} else {
 			return low
 		}
 	} else {
-		if arr[low] > arr[high] {
+		if greater(low, high) {
 			return low
-		} else if arr[mid] > arr[high] {
+		} else if greater(mid, high) {
 			return high
 		} else {
 			return mid
@@ -112,6 +172,96 @@ func QuickSortInPlace(arr []int) {
 	quickSortHelper(arr, 0, len(arr)-1)
 }
 
+func QuickSortTraced(arr []int, t Tracer) {
+	quickSortFunc(arr, 0, cmp.Compare[int], t)
+}
+
+const parallelThreshold = 1 << 13
+
+type parallelSorter struct {
+	ctx      context.Context
+	sem      chan struct{}
+	maxDepth int
+}
+
+func ParallelQuickSort(ctx context.Context, arr []int, workers int) ([]int, error) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	result := make([]int, len(arr))
+	copy(result, arr)
+
+	p := &parallelSorter{
+		ctx:      ctx,
+		sem:      make(chan struct{}, workers-1),
+		maxDepth: bits.Len(uint(workers)) + 2,
+	}
+	p.sort(result, 0)
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (p *parallelSorter) sort(arr []int, depth int) {
+	for len(arr) > 1 {
+		if p.ctx.Err() != nil {
+			return
+		}
+
+		lt, gt := partitionThreeWay(arr)
+		left, right := arr[:lt], arr[gt:]
+
+		if depth < p.maxDepth && len(arr) > parallelThreshold {
+			select {
+			case p.sem <- struct{}{}:
+				var wg sync.WaitGroup
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					defer func() { <-p.sem }()
+					p.sort(left, depth+1)
+				}()
+				p.sort(right, depth+1)
+				wg.Wait()
+				return
+			default:
+			}
+		}
+
+		if len(left) < len(right) {
+			p.sort(left, depth+1)
+			arr = right
+		} else {
+			p.sort(right, depth+1)
+			arr = left
+		}
+		depth++
+	}
+}
+
+func partitionThreeWay(arr []int) (int, int) {
+	pivot := arr[MedianOfThreeFunc(arr, cmp.Compare[int])]
+	lt, i, gt := 0, 0, len(arr)
+
+	for i < gt {
+		switch {
+		case arr[i] < pivot:
+			arr[lt], arr[i] = arr[i], arr[lt]
+			lt++
+			i++
+		case arr[i] > pivot:
+			gt--
+			arr[i], arr[gt] = arr[gt], arr[i]
+		default:
+			i++
+		}
+	}
+	return lt, gt
+}
+
 func IsSorted(arr []int) bool {
 	for i := 1; i < len(arr); i++ {
 		if arr[i] < arr[i-1] {
@@ -142,21 +292,24 @@ func Run() any {
 		basicSorted := QuickSort(testCase)
 		randomSorted := QuickSortRandomPivot(testCase)
 		medianSorted := QuickSortMedianOfThree(testCase)
+		parallelSorted, _ := ParallelQuickSort(context.Background(), testCase, 4)
 
 		inPlaceTest := make([]int, len(testCase))
 		copy(inPlaceTest, testCase)
 		QuickSortInPlace(inPlaceTest)
 
 		results[fmt.Sprintf("test_case_%d", i+1)] = map[string]any{
-			"original":          original,
-			"basic_quick_sort":  basicSorted,
-			"random_pivot":      randomSorted,
-			"median_of_three":   medianSorted,
-			"in_place_sort":     inPlaceTest,
-			"is_sorted_basic":   IsSorted(basicSorted),
-			"is_sorted_random":  IsSorted(randomSorted),
-			"is_sorted_median":  IsSorted(medianSorted),
-			"is_sorted_inplace": IsSorted(inPlaceTest),
+			"original":           original,
+			"basic_quick_sort":   basicSorted,
+			"random_pivot":       randomSorted,
+			"median_of_three":    medianSorted,
+			"parallel_sort":      parallelSorted,
+			"in_place_sort":      inPlaceTest,
+			"is_sorted_basic":    IsSorted(basicSorted),
+			"is_sorted_random":   IsSorted(randomSorted),
+			"is_sorted_median":   IsSorted(medianSorted),
+			"is_sorted_inplace":  IsSorted(inPlaceTest),
+			"is_sorted_parallel": IsSorted(parallelSorted),
 		}
 	}
 
@@ -171,6 +324,7 @@ func Run() any {
 			"Random pivot",
 			"Median-of-three pivot",
 			"In-place sorting",
+			"Parallel three-way partitioning",
 		},
 	}
 
@@ -1,7 +1,14 @@
 package quick_sort
 
 import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
 	"reflect"
+	"slices"
+	"strings"
 	"testing"
 )
 
@@ -204,6 +211,172 @@ func TestNegativeNumbers(t *testing.T) {
 	}
 }
 
+func TestParallelQuickSort(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for _, n := range []int{0, 1, 2, 100, parallelThreshold + 1, 200_000} {
+		for _, workers := range []int{0, 1, 2, 3, 8} {
+			arr := make([]int, n)
+			for i := range arr {
+				arr[i] = rng.Intn(n/4 + 1)
+			}
+			original := slices.Clone(arr)
+
+			got, err := ParallelQuickSort(context.Background(), arr, workers)
+			if err != nil {
+				t.Fatalf("n=%d workers=%d: unexpected error %v", n, workers, err)
+			}
+
+			want := slices.Clone(arr)
+			slices.Sort(want)
+			if !slices.Equal(got, want) {
+				t.Errorf("n=%d workers=%d: result not sorted", n, workers)
+			}
+			if !slices.Equal(arr, original) {
+				t.Errorf("n=%d workers=%d: input was modified", n, workers)
+			}
+		}
+	}
+}
+
+func TestParallelQuickSortDuplicates(t *testing.T) {
+	arr := make([]int, 500_000)
+	for i := range arr {
+		arr[i] = i % 3
+	}
+
+	got, err := ParallelQuickSort(context.Background(), arr, 4)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !IsSorted(got) {
+		t.Error("result with heavy duplicates is not sorted")
+	}
+}
+
+func TestPartitionThreeWay(t *testing.T) {
+	arr := []int{5, 1, 5, 9, 3, 5, 7, 5, 2}
+	lt, gt := partitionThreeWay(arr)
+	pivot := arr[lt]
+
+	for i, v := range arr {
+		switch {
+		case i < lt && v >= pivot:
+			t.Errorf("index %d: %d should be less than pivot %d", i, v, pivot)
+		case i >= lt && i < gt && v != pivot:
+			t.Errorf("index %d: %d should equal pivot %d", i, v, pivot)
+		case i >= gt && v <= pivot:
+			t.Errorf("index %d: %d should be greater than pivot %d", i, v, pivot)
+		}
+	}
+}
+
+func TestParallelQuickSortCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	arr := make([]int, 100_000)
+	for i := range arr {
+		arr[i] = len(arr) - i
+	}
+
+	got, err := ParallelQuickSort(ctx, arr, 4)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if got != nil {
+		t.Errorf("expected nil result on cancellation, got %d elements", len(got))
+	}
+}
+
+type replayTracer struct {
+	state    []int
+	compared [][2]int
+	swaps    int
+	writes   int
+}
+
+func newReplayTracer(arr []int) *replayTracer {
+	return &replayTracer{state: slices.Clone(arr)}
+}
+
+func (r *replayTracer) at(i int) int {
+	if i >= len(r.state) {
+		r.state = append(r.state, make([]int, i+1-len(r.state))...)
+	}
+	return r.state[i]
+}
+
+func (r *replayTracer) Compare(i, j int) {
+	r.compared = append(r.compared, [2]int{r.at(i), r.at(j)})
+}
+
+func (r *replayTracer) Swap(i, j int) {
+	a, b := r.at(i), r.at(j)
+	r.state[i], r.state[j] = b, a
+	r.swaps++
+}
+
+func (r *replayTracer) Write(i, value int) {
+	r.at(i)
+	r.state[i] = value
+	r.writes++
+}
+
+func (r *replayTracer) Copy(dst, src int) {
+	r.Write(dst, r.at(src))
+}
+
+func TestQuickSortTraced(t *testing.T) {
+	rng := rand.New(rand.NewSource(5))
+
+	for _, n := range []int{0, 1, 2, 3, 17, 200} {
+		arr := make([]int, n)
+		for i := range arr {
+			arr[i] = rng.Intn(n/2 + 1)
+		}
+
+		sorted := slices.Clone(arr)
+		trace := newReplayTracer(arr)
+		QuickSortTraced(sorted, trace)
+		if !IsSorted(sorted) {
+			t.Errorf("n=%d: result not sorted %v", n, sorted)
+		}
+		if !slices.Equal(trace.state, sorted) {
+			t.Errorf("n=%d: replaying the trace gives %v, want %v", n, trace.state, sorted)
+		}
+		if trace.writes != 0 {
+			t.Errorf("n=%d: quick sort only swaps, got %d writes", n, trace.writes)
+		}
+
+		var compared [][2]int
+		trace = newReplayTracer(arr)
+		quickSortFunc(slices.Clone(arr), 0, func(a, b int) int {
+			compared = append(compared, [2]int{a, b})
+			return cmp.Compare(a, b)
+		}, trace)
+		if !slices.Equal(trace.compared, compared) {
+			t.Errorf("n=%d: traced comparisons %v do not match the values QuickSortFunc compared %v", n, trace.compared, compared)
+		}
+	}
+}
+
+func TestQuickSortFuncGeneric(t *testing.T) {
+	words := []string{"pear", "fig", "apple", "kiwi", "banana", "fig"}
+	want := slices.Sorted(slices.Values(words))
+
+	for name, sort := range map[string]func([]string, func(a, b string) int){
+		"last_element":    QuickSortFunc[string],
+		"median_of_three": QuickSortMedianOfThreeFunc[string],
+	} {
+		got := slices.Clone(words)
+		sort(got, strings.Compare)
+		if !slices.Equal(got, want) {
+			t.Errorf("%s: expected %v, got %v", name, want, got)
+		}
+	}
+}
+
 func TestRun(t *testing.T) {
 	result := Run()
 	if result == nil {
@@ -287,3 +460,25 @@ func BenchmarkQuickSortInPlace(b *testing.B) {
 		QuickSortInPlace(arr)
 	}
 }
+
+func BenchmarkParallelQuickSort(b *testing.B) {
+	rng := rand.New(rand.NewSource(2))
+	arr := make([]int, 1<<21)
+	for i := range arr {
+		arr[i] = rng.Int()
+	}
+
+	b.Run("sequential", func(b *testing.B) {
+		for b.Loop() {
+			QuickSort(arr)
+		}
+	})
+
+	for _, workers := range []int{1, 2, 4, 8, 32} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			for b.Loop() {
+				ParallelQuickSort(context.Background(), arr, workers)
+			}
+		})
+	}
+}
@@ -1,9 +1,24 @@
 package merge_sort
 
 import (
+	"cmp"
+	"context"
 	"fmt"
+	"math/bits"
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/celj/dsa/0018-heap"
 )
 
+type Tracer interface {
+	Compare(i, j int)
+	Swap(i, j int)
+	Write(i, value int)
+	Copy(dst, src int)
+}
+
 func MergeSort(arr []int) []int {
 	if len(arr) <= 1 {
 		return arr
@@ -112,39 +127,116 @@ func mergeSortStableHelper(arr []int, left, right int) {
 }
 
 func mergeStable(arr []int, left, mid, right int) {
-	leftSize := mid - left + 1
-	rightSize := right - mid
+	leftArr := make([]int, mid-left+1)
+	copy(leftArr, arr[left:mid+1])
+	MergeFunc(arr[left:right+1], leftArr, arr[mid+1:right+1], cmp.Compare[int])
+}
 
-	leftArr := make([]int, leftSize)
-	rightArr := make([]int, rightSize)
+const minGallop = 7
 
-	copy(leftArr, arr[left:mid+1])
-	copy(rightArr, arr[mid+1:right+1])
+func gallop(n int, stop func(int) bool) int {
+	high := 1
+	for high <= n && !stop(high-1) {
+		high *= 2
+	}
+	low := high / 2
+	high = min(high, n)
+	return low + sort.Search(high-low, func(i int) bool { return stop(low + i) })
+}
 
-	i, j, k := 0, 0, left
+type MergeTrace struct {
+	Trace            Tracer
+	Dst, Left, Right int
+}
 
-	for i < leftSize && j < rightSize {
-		if leftArr[i] <= rightArr[j] {
-			arr[k] = leftArr[i]
-			i++
-		} else {
-			arr[k] = rightArr[j]
+func (m MergeTrace) compare(right, left int) {
+	if m.Trace != nil {
+		m.Trace.Compare(m.Right+right, m.Left+left)
+	}
+}
+
+func (m MergeTrace) copy(dst, src, n int) {
+	if m.Trace == nil {
+		return
+	}
+	for k := range n {
+		m.Trace.Copy(dst+k, src+k)
+	}
+}
+
+func MergeFunc[T any](dst, left, right []T, compare func(a, b T) int) {
+	MergeFuncTraced(dst, left, right, compare, MergeTrace{})
+}
+
+func MergeFuncTraced[T any](dst, left, right []T, compare func(a, b T) int, m MergeTrace) {
+	i, j, k := 0, 0, 0
+	leftWins, rightWins := 0, 0
+
+	for i < len(left) && j < len(right) {
+		m.compare(j, i)
+		if compare(right[j], left[i]) < 0 {
+			dst[k] = right[j]
+			m.copy(m.Dst+k, m.Right+j, 1)
 			j++
+			rightWins, leftWins = rightWins+1, 0
+		} else {
+			dst[k] = left[i]
+			m.copy(m.Dst+k, m.Left+i, 1)
+			i++
+			leftWins, rightWins = leftWins+1, 0
 		}
 		k++
+
+		if leftWins >= minGallop && j < len(right) {
+			n := gallop(len(left)-i, func(x int) bool {
+				m.compare(j, i+x)
+				return compare(right[j], left[i+x]) < 0
+			})
+			m.copy(m.Dst+k, m.Left+i, n)
+			k += copy(dst[k:], left[i:i+n])
+			i += n
+			leftWins = 0
+		} else if rightWins >= minGallop && i < len(left) {
+			n := gallop(len(right)-j, func(y int) bool {
+				m.compare(j+y, i)
+				return compare(right[j+y], left[i]) >= 0
+			})
+			m.copy(m.Dst+k, m.Right+j, n)
+			k += copy(dst[k:], right[j:j+n])
+			j += n
+			rightWins = 0
+		}
 	}
 
-	for i < leftSize {
-		arr[k] = leftArr[i]
-		i++
-		k++
+	m.copy(m.Dst+k, m.Left+i, len(left)-i)
+	k += copy(dst[k:], left[i:])
+	m.copy(m.Dst+k, m.Right+j, len(right)-j)
+	copy(dst[k:], right[j:])
+}
+
+func MergeSortFunc[T any](s []T, compare func(a, b T) int) {
+	mergeSortFunc(s, make([]T, len(s)/2), 0, 0, compare, nil)
+}
+
+func mergeSortFunc[T any](s, buf []T, offset, scratch int, compare func(a, b T) int, t Tracer) {
+	if len(s) <= 1 {
+		return
 	}
 
-	for j < rightSize {
-		arr[k] = rightArr[j]
-		j++
-		k++
+	mid := len(s) / 2
+	mergeSortFunc(s[:mid], buf, offset, scratch, compare, t)
+	mergeSortFunc(s[mid:], buf, offset+mid, scratch, compare, t)
+
+	if t != nil {
+		t.Compare(offset+mid-1, offset+mid)
+	}
+	if compare(s[mid-1], s[mid]) <= 0 {
+		return
 	}
+	m := MergeTrace{Trace: t, Dst: offset, Left: scratch, Right: offset + mid}
+	m.copy(m.Left, offset, mid)
+	copy(buf, s[:mid])
+	MergeFuncTraced(s, buf[:mid], s[mid:], compare, m)
 }
 
 func MergeSortOptimized(arr []int) []int {
@@ -214,6 +306,24 @@ func insertionSort(arr []int, left, right int) {
 	}
 }
 
+func MergeRuns(runs [][]int) []int {
+	return heap.MergeSlices(func(a, b int) bool { return a < b }, runs...)
+}
+
+func MergeSortRuns(arr []int, runSize int) []int {
+	if runSize < 1 {
+		runSize = 1
+	}
+
+	runs := make([][]int, 0, (len(arr)+runSize-1)/runSize)
+	for start := 0; start < len(arr); start += runSize {
+		end := min(start+runSize, len(arr))
+		runs = append(runs, MergeSort(arr[start:end:end]))
+	}
+
+	return MergeRuns(runs)
+}
+
 func min(a, b int) int {
 	if a < b {
 		return a
@@ -230,6 +340,130 @@ func IsSorted(arr []int) bool {
 	return true
 }
 
+func MergeSortTraced(arr []int, t Tracer) {
+	mergeSortFunc(arr, make([]int, len(arr)/2), 0, len(arr), cmp.Compare[int], t)
+}
+
+const parallelThreshold = 1 << 13
+
+type parallelMerger struct {
+	ctx      context.Context
+	sem      chan struct{}
+	maxDepth int
+}
+
+func ParallelMergeSort(ctx context.Context, arr []int, workers int) ([]int, error) {
+	result := make([]int, len(arr))
+	copy(result, arr)
+	if len(result) <= 1 {
+		return result, ctx.Err()
+	}
+
+	p := newParallelMerger(ctx, workers)
+	p.sort(result, make([]int, len(result)), false, 0)
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func newParallelMerger(ctx context.Context, workers int) *parallelMerger {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	return &parallelMerger{
+		ctx:      ctx,
+		sem:      make(chan struct{}, workers-1),
+		maxDepth: bits.Len(uint(workers)) + 2,
+	}
+}
+
+func (p *parallelMerger) fork(depth int, left, right func()) {
+	if depth < p.maxDepth {
+		select {
+		case p.sem <- struct{}{}:
+			var wg sync.WaitGroup
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() { <-p.sem }()
+				left()
+			}()
+			right()
+			wg.Wait()
+			return
+		default:
+		}
+	}
+	left()
+	right()
+}
+
+func (p *parallelMerger) sort(arr, buf []int, toBuf bool, depth int) {
+	if p.ctx.Err() != nil {
+		return
+	}
+	if len(arr) <= parallelThreshold || depth >= p.maxDepth {
+		mergeSortOptimizedHelper(arr, buf, 0, len(arr)-1)
+		if toBuf {
+			copy(buf, arr)
+		}
+		return
+	}
+
+	mid := len(arr) / 2
+	p.fork(depth,
+		func() { p.sort(arr[:mid], buf[:mid], !toBuf, depth+1) },
+		func() { p.sort(arr[mid:], buf[mid:], !toBuf, depth+1) },
+	)
+	if p.ctx.Err() != nil {
+		return
+	}
+
+	src, dst := buf, arr
+	if toBuf {
+		src, dst = arr, buf
+	}
+	p.merge(dst, src[:mid], src[mid:], depth)
+}
+
+func (p *parallelMerger) merge(dst, left, right []int, depth int) {
+	if p.ctx.Err() != nil {
+		return
+	}
+	if len(left)+len(right) <= parallelThreshold || depth >= p.maxDepth {
+		MergeFunc(dst, left, right, cmp.Compare[int])
+		return
+	}
+
+	var i, j int
+	if len(left) >= len(right) {
+		i = len(left) / 2
+		j = sort.SearchInts(right, left[i])
+	} else {
+		j = len(right) / 2
+		i = sort.Search(len(left), func(k int) bool { return left[k] > right[j] })
+	}
+
+	p.fork(depth,
+		func() { p.merge(dst[:i+j], left[:i], right[:j], depth+1) },
+		func() { p.merge(dst[i+j:], left[i:], right[j:], depth+1) },
+	)
+}
+
+func ParallelMerge(ctx context.Context, left, right []int, workers int) ([]int, error) {
+	p := newParallelMerger(ctx, workers)
+	result := make([]int, len(left)+len(right))
+	p.merge(result, left, right, 0)
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func Run() any {
 	testCases := [][]int{
 		{64, 34, 25, 12, 22, 11, 90},
@@ -253,6 +487,8 @@ func Run() any {
 		bottomUpSorted := MergeSortBottomUp(testCase)
 		stableSorted := MergeSortStable(testCase)
 		optimizedSorted := MergeSortOptimized(testCase)
+		runsSorted := MergeSortRuns(testCase, 3)
+		parallelSorted, _ := ParallelMergeSort(context.Background(), testCase, 4)
 
 		inPlaceTest := make([]int, len(testCase))
 		copy(inPlaceTest, testCase)
@@ -265,11 +501,15 @@ func Run() any {
 			"stable_sort":         stableSorted,
 			"optimized_sort":      optimizedSorted,
 			"in_place_sort":       inPlaceTest,
+			"k_way_runs_sort":     runsSorted,
+			"parallel_sort":       parallelSorted,
 			"is_sorted_topdown":   IsSorted(topDownSorted),
 			"is_sorted_bottomup":  IsSorted(bottomUpSorted),
 			"is_sorted_stable":    IsSorted(stableSorted),
 			"is_sorted_optimized": IsSorted(optimizedSorted),
 			"is_sorted_inplace":   IsSorted(inPlaceTest),
+			"is_sorted_runs":      IsSorted(runsSorted),
+			"is_sorted_parallel":  IsSorted(parallelSorted),
 		}
 	}
 
@@ -285,6 +525,8 @@ func Run() any {
 			"Stable merge sort",
 			"Optimized (with insertion sort for small arrays)",
 			"In-place sorting",
+			"K-way merge of sorted runs",
+			"Parallel fork-join with parallel merge",
 		},
 		"advantages": []string{
 			"Guaranteed O(n log n) time complexity",
@@ -1,7 +1,14 @@
 package merge_sort
 
 import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math/bits"
+	"math/rand"
 	"reflect"
+	"slices"
 	"testing"
 )
 
@@ -280,6 +287,282 @@ func TestAllVariantsConsistency(t *testing.T) {
 	}
 }
 
+func TestMergeRuns(t *testing.T) {
+	runs := [][]int{{1, 4, 9}, {}, {2, 3, 10, 11}, {0, 4}}
+	expected := []int{0, 1, 2, 3, 4, 4, 9, 10, 11}
+
+	result := MergeRuns(runs)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("MergeRuns(%v) = %v, expected %v", runs, result, expected)
+	}
+}
+
+func TestMergeSortRuns(t *testing.T) {
+	input := []int{38, 27, 43, 3, 9, 82, 10, 3, -5, 0, 27}
+	expected := MergeSort(input)
+
+	for _, runSize := range []int{0, 1, 2, 3, 4, len(input), len(input) + 5} {
+		original := make([]int, len(input))
+		copy(original, input)
+
+		result := MergeSortRuns(input, runSize)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("MergeSortRuns(runSize=%d) = %v, expected %v", runSize, result, expected)
+		}
+		if !reflect.DeepEqual(input, original) {
+			t.Errorf("MergeSortRuns(runSize=%d) modified its input", runSize)
+		}
+	}
+
+	if result := MergeSortRuns(nil, 4); len(result) != 0 {
+		t.Errorf("MergeSortRuns(nil) = %v, expected empty", result)
+	}
+}
+
+func TestMergeFunc(t *testing.T) {
+	type item struct {
+		key, source int
+	}
+	byKey := func(a, b item) int { return a.key - b.key }
+
+	rng := rand.New(rand.NewSource(1))
+	for range 200 {
+		left := make([]item, rng.Intn(40))
+		right := make([]item, rng.Intn(40))
+		for i := range left {
+			left[i] = item{key: rng.Intn(10), source: 0}
+		}
+		for i := range right {
+			right[i] = item{key: rng.Intn(10), source: 1}
+		}
+		slices.SortStableFunc(left, byKey)
+		slices.SortStableFunc(right, byKey)
+
+		expected := slices.Concat(left, right)
+		slices.SortStableFunc(expected, byKey)
+
+		merged := make([]item, len(left)+len(right))
+		MergeFunc(merged, left, right, byKey)
+		if !reflect.DeepEqual(merged, expected) {
+			t.Fatalf("MergeFunc(%v, %v) = %v, expected %v", left, right, merged, expected)
+		}
+
+		inPlace := slices.Concat(left, right)
+		MergeFunc(inPlace, slices.Clone(left), inPlace[len(left):], byKey)
+		if !reflect.DeepEqual(inPlace, expected) {
+			t.Fatalf("in-place MergeFunc(%v, %v) = %v, expected %v", left, right, inPlace, expected)
+		}
+	}
+}
+
+func TestMergeFuncGallops(t *testing.T) {
+	left := make([]int, 1000)
+	right := make([]int, 1000)
+	for i := range left {
+		left[i] = i
+		right[i] = 1000 + i
+	}
+
+	comparisons := 0
+	merged := make([]int, 2000)
+	MergeFunc(merged, left, right, func(a, b int) int {
+		comparisons++
+		return a - b
+	})
+
+	if !IsSorted(merged) {
+		t.Fatalf("MergeFunc produced an unsorted result")
+	}
+	if comparisons > 100 {
+		t.Errorf("MergeFunc used %d comparisons on disjoint runs, expected galloping to need far fewer than 2000", comparisons)
+	}
+}
+
+func TestParallelMergeSort(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+
+	for _, n := range []int{0, 1, 2, 100, parallelThreshold + 1, 200_000} {
+		for _, workers := range []int{0, 1, 2, 3, 8} {
+			arr := make([]int, n)
+			for i := range arr {
+				arr[i] = rng.Intn(n/4 + 1)
+			}
+			original := slices.Clone(arr)
+
+			got, err := ParallelMergeSort(context.Background(), arr, workers)
+			if err != nil {
+				t.Fatalf("n=%d workers=%d: unexpected error %v", n, workers, err)
+			}
+
+			want := slices.Clone(arr)
+			slices.Sort(want)
+			if !slices.Equal(got, want) {
+				t.Errorf("n=%d workers=%d: result not sorted", n, workers)
+			}
+			if !slices.Equal(arr, original) {
+				t.Errorf("n=%d workers=%d: input was modified", n, workers)
+			}
+		}
+	}
+}
+
+func TestParallelMerge(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+
+	for _, sizes := range [][2]int{{0, 0}, {0, 50_000}, {50_000, 0}, {1, 60_000}, {70_000, 30_000}} {
+		left := make([]int, sizes[0])
+		right := make([]int, sizes[1])
+		for i := range left {
+			left[i] = rng.Intn(1000)
+		}
+		for i := range right {
+			right[i] = rng.Intn(1000)
+		}
+		slices.Sort(left)
+		slices.Sort(right)
+
+		got, err := ParallelMerge(context.Background(), left, right, 4)
+		if err != nil {
+			t.Fatalf("sizes %v: unexpected error %v", sizes, err)
+		}
+
+		want := append(slices.Clone(left), right...)
+		slices.Sort(want)
+		if !slices.Equal(got, want) {
+			t.Errorf("sizes %v: merge mismatch", sizes)
+		}
+	}
+}
+
+func TestParallelMergeSortCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	arr := make([]int, 100_000)
+	for i := range arr {
+		arr[i] = len(arr) - i
+	}
+
+	got, err := ParallelMergeSort(ctx, arr, 4)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if got != nil {
+		t.Errorf("expected nil result on cancellation, got %d elements", len(got))
+	}
+}
+
+type replayTracer struct {
+	state    []int
+	compared [][2]int
+	swaps    int
+	writes   int
+}
+
+func newReplayTracer(arr []int) *replayTracer {
+	return &replayTracer{state: slices.Clone(arr)}
+}
+
+func (r *replayTracer) at(i int) int {
+	if i >= len(r.state) {
+		r.state = append(r.state, make([]int, i+1-len(r.state))...)
+	}
+	return r.state[i]
+}
+
+func (r *replayTracer) Compare(i, j int) {
+	r.compared = append(r.compared, [2]int{r.at(i), r.at(j)})
+}
+
+func (r *replayTracer) Swap(i, j int) {
+	a, b := r.at(i), r.at(j)
+	r.state[i], r.state[j] = b, a
+	r.swaps++
+}
+
+func (r *replayTracer) Write(i, value int) {
+	r.at(i)
+	r.state[i] = value
+	r.writes++
+}
+
+func (r *replayTracer) Copy(dst, src int) {
+	r.Write(dst, r.at(src))
+}
+
+func TestMergeSortTraced(t *testing.T) {
+	rng := rand.New(rand.NewSource(5))
+
+	var inputs [][]int
+	for _, n := range []int{0, 1, 2, 3, 17, 200} {
+		arr := make([]int, n)
+		for i := range arr {
+			arr[i] = rng.Intn(n/2 + 1)
+		}
+		inputs = append(inputs, arr)
+	}
+	inputs = append(inputs, append(slices.Repeat([]int{9}, 40), slices.Repeat([]int{1}, 40)...))
+
+	for _, arr := range inputs {
+		n := len(arr)
+		sorted := slices.Clone(arr)
+		trace := newReplayTracer(arr)
+		MergeSortTraced(sorted, trace)
+		if !IsSorted(sorted) {
+			t.Errorf("n=%d: result not sorted %v", n, sorted)
+		}
+		if !slices.Equal(trace.state[:n], sorted) {
+			t.Errorf("n=%d: replaying the trace gives %v, want %v", n, trace.state[:n], sorted)
+		}
+		levels := bits.Len(uint(max(n-1, 0)))
+		if trace.swaps != 0 || trace.writes > 3*n*levels/2 || len(trace.compared) > n*levels {
+			t.Errorf("n=%d: unexpected counts: %d comparisons, %d swaps, %d writes", n, len(trace.compared), trace.swaps, trace.writes)
+		}
+		if scratch := len(trace.state) - n; scratch > n/2 {
+			t.Errorf("n=%d: expected at most %d scratch cells for the left run, got %d", n, n/2, scratch)
+		}
+
+		var compared [][2]int
+		trace = newReplayTracer(arr)
+		mergeSortFunc(slices.Clone(arr), make([]int, n/2), 0, n, func(a, b int) int {
+			compared = append(compared, [2]int{a, b})
+			return cmp.Compare(a, b)
+		}, trace)
+		if !slices.Equal(trace.compared, compared) {
+			t.Errorf("n=%d: traced comparisons %v do not match the values MergeSortFunc compared %v", n, trace.compared, compared)
+		}
+	}
+}
+
+func TestMergeSortFunc(t *testing.T) {
+	type pair struct{ key, seq int }
+	rng := rand.New(rand.NewSource(6))
+
+	for _, n := range []int{0, 1, 2, 7, 100, 1000} {
+		s := make([]pair, n)
+		for i := range s {
+			s[i] = pair{rng.Intn(10), i}
+		}
+		want := slices.Clone(s)
+		slices.SortStableFunc(want, func(a, b pair) int { return a.key - b.key })
+
+		MergeSortFunc(s, func(a, b pair) int { return a.key - b.key })
+		if !slices.Equal(s, want) {
+			t.Errorf("n=%d: MergeSortFunc is not a stable sort", n)
+		}
+	}
+
+	comparisons := 0
+	sorted := make([]int, 64)
+	for i := range sorted {
+		sorted[i] = i
+	}
+	MergeSortFunc(sorted, func(a, b int) int { comparisons++; return a - b })
+	if comparisons != 63 {
+		t.Errorf("expected 63 comparisons on sorted input, got %d", comparisons)
+	}
+}
+
 func TestRun(t *testing.T) {
 	result := Run()
 	if result == nil {
@@ -363,3 +646,42 @@ func BenchmarkMergeSortInPlace(b *testing.B) {
 		MergeSortInPlace(arr)
 	}
 }
+
+func BenchmarkMergeSortRuns(b *testing.B) {
+	arr := make([]int, 1000)
+	for i := range 1000 {
+		arr[i] = 1000 - i
+	}
+
+	for b.Loop() {
+		MergeSortRuns(arr, 64)
+	}
+}
+
+func BenchmarkParallelMergeSort(b *testing.B) {
+	rng := rand.New(rand.NewSource(4))
+	arr := make([]int, 1<<21)
+	for i := range arr {
+		arr[i] = rng.Int()
+	}
+
+	b.Run("sequential", func(b *testing.B) {
+		for b.Loop() {
+			MergeSortOptimized(arr)
+		}
+	})
+
+	b.Run("bottom_up", func(b *testing.B) {
+		for b.Loop() {
+			MergeSortBottomUp(arr)
+		}
+	})
+
+	for _, workers := range []int{1, 2, 4, 8, 32} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			for b.Loop() {
+				ParallelMergeSort(context.Background(), arr, workers)
+			}
+		})
+	}
+}
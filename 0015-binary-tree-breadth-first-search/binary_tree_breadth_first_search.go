@@ -1,5 +1,7 @@
 package binary_tree_breadth_first_search
 
+import "github.com/celj/dsa/0045-deque"
+
 type TreeNode struct {
 	Value int
 	Left  *TreeNode
@@ -10,55 +12,17 @@ func NewTreeNode(value int) *TreeNode {
 	return &TreeNode{Value: value}
 }
 
-type Queue[T any] struct {
-	items []T
-}
-
-func NewQueue[T any]() *Queue[T] {
-	return &Queue[T]{items: make([]T, 0)}
-}
-
-func (q *Queue[T]) Enqueue(item T) {
-	q.items = append(q.items, item)
-}
-
-func (q *Queue[T]) Dequeue() T {
-	if len(q.items) == 0 {
-		var zero T
-		return zero
-	}
-	item := q.items[0]
-	q.items = q.items[1:]
-	return item
-}
-
-func (q *Queue[T]) IsEmpty() bool {
-	return len(q.items) == 0
-}
-
-func (q *Queue[T]) Size() int {
-	return len(q.items)
-}
-
-func (q *Queue[T]) Peek(index int) T {
-	if index < 0 || index >= len(q.items) {
-		var zero T
-		return zero
-	}
-	return q.items[index]
-}
-
 func BFS(root *TreeNode) []int {
 	if root == nil {
 		return []int{}
 	}
 
 	result := []int{}
-	queue := NewQueue[*TreeNode]()
+	queue := deque.NewQueue[*TreeNode]()
 	queue.Enqueue(root)
 
 	for !queue.IsEmpty() {
-		current := queue.Dequeue()
+		current, _ := queue.Dequeue()
 		result = append(result, current.Value)
 
 		if current.Left != nil {
@@ -78,7 +42,7 @@ func BFSLevels(root *TreeNode) [][]int {
 	}
 
 	result := [][]int{}
-	queue := NewQueue[*TreeNode]()
+	queue := deque.NewQueue[*TreeNode]()
 	queue.Enqueue(root)
 
 	for !queue.IsEmpty() {
@@ -86,7 +50,7 @@ func BFSLevels(root *TreeNode) [][]int {
 		currentLevel := []int{}
 
 		for range levelSize {
-			current := queue.Dequeue()
+			current, _ := queue.Dequeue()
 			currentLevel = append(currentLevel, current.Value)
 
 			if current.Left != nil {
@@ -109,14 +73,14 @@ func BFSRightSideView(root *TreeNode) []int {
 	}
 
 	result := []int{}
-	queue := NewQueue[*TreeNode]()
+	queue := deque.NewQueue[*TreeNode]()
 	queue.Enqueue(root)
 
 	for !queue.IsEmpty() {
 		levelSize := queue.Size()
 
 		for i := range levelSize {
-			current := queue.Dequeue()
+			current, _ := queue.Dequeue()
 
 			if i == levelSize-1 {
 				result = append(result, current.Value)
@@ -140,14 +104,14 @@ func BFSLeftSideView(root *TreeNode) []int {
 	}
 
 	result := []int{}
-	queue := NewQueue[*TreeNode]()
+	queue := deque.NewQueue[*TreeNode]()
 	queue.Enqueue(root)
 
 	for !queue.IsEmpty() {
 		levelSize := queue.Size()
 
 		for i := range levelSize {
-			current := queue.Dequeue()
+			current, _ := queue.Dequeue()
 
 			if i == 0 {
 				result = append(result, current.Value)
@@ -171,7 +135,7 @@ func BFSZigzag(root *TreeNode) [][]int {
 	}
 
 	result := [][]int{}
-	queue := NewQueue[*TreeNode]()
+	queue := deque.NewQueue[*TreeNode]()
 	queue.Enqueue(root)
 	leftToRight := true
 
@@ -180,7 +144,7 @@ func BFSZigzag(root *TreeNode) [][]int {
 		currentLevel := make([]int, levelSize)
 
 		for i := range levelSize {
-			current := queue.Dequeue()
+			current, _ := queue.Dequeue()
 
 			index := i
 			if !leftToRight {
@@ -208,7 +172,7 @@ func MaxDepth(root *TreeNode) int {
 		return 0
 	}
 
-	queue := NewQueue[*TreeNode]()
+	queue := deque.NewQueue[*TreeNode]()
 	queue.Enqueue(root)
 	depth := 0
 
@@ -217,7 +181,7 @@ func MaxDepth(root *TreeNode) int {
 		depth++
 
 		for range levelSize {
-			current := queue.Dequeue()
+			current, _ := queue.Dequeue()
 
 			if current.Left != nil {
 				queue.Enqueue(current.Left)
@@ -236,7 +200,7 @@ func MinDepth(root *TreeNode) int {
 		return 0
 	}
 
-	queue := NewQueue[*TreeNode]()
+	queue := deque.NewQueue[*TreeNode]()
 	queue.Enqueue(root)
 	depth := 1
 
@@ -244,7 +208,7 @@ func MinDepth(root *TreeNode) int {
 		levelSize := queue.Size()
 
 		for range levelSize {
-			current := queue.Dequeue()
+			current, _ := queue.Dequeue()
 
 			if current.Left == nil && current.Right == nil {
 				return depth
@@ -269,7 +233,7 @@ func LevelSum(root *TreeNode, level int) int {
 		return 0
 	}
 
-	queue := NewQueue[*TreeNode]()
+	queue := deque.NewQueue[*TreeNode]()
 	queue.Enqueue(root)
 	currentLevel := 0
 
@@ -278,15 +242,14 @@ func LevelSum(root *TreeNode, level int) int {
 
 		if currentLevel == level {
 			sum := 0
-			for i := range levelSize {
-				current := queue.Peek(i)
+			for current := range queue.All() {
 				sum += current.Value
 			}
 			return sum
 		}
 
 		for range levelSize {
-			current := queue.Dequeue()
+			current, _ := queue.Dequeue()
 
 			if current.Left != nil {
 				queue.Enqueue(current.Left)
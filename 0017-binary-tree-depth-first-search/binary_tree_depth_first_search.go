@@ -1,5 +1,7 @@
 package binary_tree_depth_first_search
 
+import "github.com/celj/dsa/0045-deque"
+
 type TreeNode struct {
 	Value int
 	Left  *TreeNode
@@ -95,44 +97,71 @@ func (bst *BST) findNode(node *TreeNode, value int) *TreeNode {
 
 func (bst *BST) InOrderTraversal() []int {
 	var result []int
-	bst.inOrder(bst.Root, &result)
-	return result
-}
+	stack := deque.NewStack[*TreeNode]()
+	current := bst.Root
 
-func (bst *BST) inOrder(node *TreeNode, result *[]int) {
-	if node != nil {
-		bst.inOrder(node.Left, result)
-		*result = append(*result, node.Value)
-		bst.inOrder(node.Right, result)
+	for current != nil || !stack.IsEmpty() {
+		for current != nil {
+			stack.Push(current)
+			current = current.Left
+		}
+
+		current, _ = stack.Pop()
+		result = append(result, current.Value)
+		current = current.Right
 	}
+
+	return result
 }
 
 func (bst *BST) PreOrderTraversal() []int {
 	var result []int
-	bst.preOrder(bst.Root, &result)
-	return result
-}
+	if bst.Root == nil {
+		return result
+	}
+
+	stack := deque.NewStack[*TreeNode]()
+	stack.Push(bst.Root)
+
+	for !stack.IsEmpty() {
+		current, _ := stack.Pop()
+		result = append(result, current.Value)
 
-func (bst *BST) preOrder(node *TreeNode, result *[]int) {
-	if node != nil {
-		*result = append(*result, node.Value)
-		bst.preOrder(node.Left, result)
-		bst.preOrder(node.Right, result)
+		if current.Right != nil {
+			stack.Push(current.Right)
+		}
+		if current.Left != nil {
+			stack.Push(current.Left)
+		}
 	}
+
+	return result
 }
 
 func (bst *BST) PostOrderTraversal() []int {
 	var result []int
-	bst.postOrder(bst.Root, &result)
-	return result
-}
+	stack := deque.NewStack[*TreeNode]()
+	var lastVisited *TreeNode
+	current := bst.Root
+
+	for current != nil || !stack.IsEmpty() {
+		for current != nil {
+			stack.Push(current)
+			current = current.Left
+		}
+
+		top, _ := stack.Peek()
+		if top.Right != nil && top.Right != lastVisited {
+			current = top.Right
+			continue
+		}
 
-func (bst *BST) postOrder(node *TreeNode, result *[]int) {
-	if node != nil {
-		bst.postOrder(node.Left, result)
-		bst.postOrder(node.Right, result)
-		*result = append(*result, node.Value)
+		stack.Pop()
+		result = append(result, top.Value)
+		lastVisited = top
 	}
+
+	return result
 }
 
 func (bst *BST) Height() int {
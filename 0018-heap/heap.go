@@ -2,209 +2,1247 @@ package heap
 
 import (
 	"container/heap"
+	"context"
+	"errors"
 	"fmt"
+	"iter"
+	"slices"
 )
 
-type MinHeap struct {
-	data []int
-}
-
-func NewMinHeap() *MinHeap {
-	return &MinHeap{data: make([]int, 0)}
+type Heap[T any] struct {
+	data   []T
+	less   func(a, b T) bool
+	arity  int
+	onMove func(item T, index int)
 }
 
-func (h *MinHeap) parent(i int) int {
-	return (i - 1) / 2
+func NewHeap[T any](less func(a, b T) bool) *Heap[T] {
+	return &Heap[T]{data: make([]T, 0), less: less, arity: 2}
 }
 
-func (h *MinHeap) leftChild(i int) int {
-	return 2*i + 1
+func Heapify[T any](items []T, less func(a, b T) bool) *Heap[T] {
+	h := &Heap[T]{data: slices.Clone(items), less: less, arity: 2}
+	h.build()
+	return h
 }
 
-func (h *MinHeap) rightChild(i int) int {
-	return 2*i + 2
+func (h *Heap[T]) build() {
+	for i := (len(h.data) - 2) / h.arity; i >= 0; i-- {
+		h.down(i)
+	}
+	if h.onMove != nil {
+		for i, item := range h.data {
+			h.onMove(item, i)
+		}
+	}
 }
 
-func (h *MinHeap) swap(i, j int) {
+func (h *Heap[T]) swap(i, j int) {
 	h.data[i], h.data[j] = h.data[j], h.data[i]
+	if h.onMove != nil {
+		h.onMove(h.data[i], i)
+		h.onMove(h.data[j], j)
+	}
 }
 
-func (h *MinHeap) Insert(value int) {
-	h.data = append(h.data, value)
-	h.heapifyUp(len(h.data) - 1)
+func (h *Heap[T]) up(index int) {
+	for index > 0 {
+		parent := (index - 1) / h.arity
+		if !h.less(h.data[index], h.data[parent]) {
+			break
+		}
+		h.swap(index, parent)
+		index = parent
+	}
 }
 
-func (h *MinHeap) heapifyUp(index int) {
-	for index > 0 {
-		parentIndex := h.parent(index)
-		if h.data[index] >= h.data[parentIndex] {
+func (h *Heap[T]) down(index int) bool {
+	start := index
+	for {
+		best := index
+		first := h.arity*index + 1
+		last := min(first+h.arity, len(h.data))
+
+		for child := first; child < last; child++ {
+			if h.less(h.data[child], h.data[best]) {
+				best = child
+			}
+		}
+		if best == index {
 			break
 		}
-		h.swap(index, parentIndex)
-		index = parentIndex
+
+		h.swap(index, best)
+		index = best
 	}
+	return index > start
 }
 
-func (h *MinHeap) ExtractMin() (int, bool) {
+func (h *Heap[T]) fix(index int) {
+	if !h.down(index) {
+		h.up(index)
+	}
+}
+
+func (h *Heap[T]) removeAt(index int) T {
+	last := len(h.data) - 1
+	if index != last {
+		h.swap(index, last)
+	}
+
+	item := h.data[last]
+	var zero T
+	h.data[last] = zero
+	h.data = h.data[:last]
+
+	if index < len(h.data) {
+		h.fix(index)
+	}
+	return item
+}
+
+func (h *Heap[T]) Push(item T) {
+	h.data = append(h.data, item)
+	if h.onMove != nil {
+		h.onMove(item, len(h.data)-1)
+	}
+	h.up(len(h.data) - 1)
+}
+
+func (h *Heap[T]) Pop() (T, bool) {
 	if len(h.data) == 0 {
-		return 0, false
+		var zero T
+		return zero, false
 	}
+	return h.removeAt(0), true
+}
 
-	min := h.data[0]
-	lastIndex := len(h.data) - 1
-	h.data[0] = h.data[lastIndex]
-	h.data = h.data[:lastIndex]
+func (h *Heap[T]) Peek() (T, bool) {
+	if len(h.data) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.data[0], true
+}
 
-	if len(h.data) > 0 {
-		h.heapifyDown(0)
+func (h *Heap[T]) Merge(other *Heap[T]) {
+	h.data = append(h.data, other.data...)
+	h.build()
+}
+
+func (h *Heap[T]) Size() int {
+	return len(h.data)
+}
+
+func (h *Heap[T]) IsEmpty() bool {
+	return len(h.data) == 0
+}
+
+func (h *Heap[T]) ToSlice() []T {
+	return slices.Clone(h.data)
+}
+
+type Entry[T any] struct {
+	Value T
+	index int
+}
+
+type IndexedHeap[T any] struct {
+	heap *Heap[*Entry[T]]
+}
+
+func NewIndexedHeap[T any](less func(a, b T) bool) *IndexedHeap[T] {
+	return newIndexedHeap(2, less)
+}
+
+func newIndexedHeap[T any](arity int, less func(a, b T) bool) *IndexedHeap[T] {
+	h := NewHeap(func(a, b *Entry[T]) bool { return less(a.Value, b.Value) })
+	h.arity = arity
+	h.onMove = func(entry *Entry[T], index int) { entry.index = index }
+	return &IndexedHeap[T]{heap: h}
+}
+
+func (e *Entry[T]) Item() T {
+	return e.Value
+}
+
+func (ih *IndexedHeap[T]) Push(value T) *Entry[T] {
+	entry := &Entry[T]{Value: value}
+	ih.heap.Push(entry)
+	return entry
+}
+
+func (ih *IndexedHeap[T]) Pop() (T, bool) {
+	entry, ok := ih.heap.Pop()
+	if !ok {
+		var zero T
+		return zero, false
 	}
+	entry.index = -1
+	return entry.Value, true
+}
 
-	return min, true
+func (ih *IndexedHeap[T]) Peek() (T, bool) {
+	entry, ok := ih.heap.Peek()
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	return entry.Value, true
 }
 
-func (h *MinHeap) heapifyDown(index int) {
-	for {
-		smallest := index
-		left := h.leftChild(index)
-		right := h.rightChild(index)
+func (ih *IndexedHeap[T]) Contains(entry *Entry[T]) bool {
+	return entry != nil && entry.index >= 0 && entry.index < ih.heap.Size() &&
+		ih.heap.data[entry.index] == entry
+}
+
+func (ih *IndexedHeap[T]) Update(entry *Entry[T], value T) bool {
+	if !ih.Contains(entry) {
+		return false
+	}
+	entry.Value = value
+	ih.heap.fix(entry.index)
+	return true
+}
+
+func (ih *IndexedHeap[T]) Remove(entry *Entry[T]) bool {
+	if !ih.Contains(entry) {
+		return false
+	}
+	ih.heap.removeAt(entry.index)
+	entry.index = -1
+	return true
+}
+
+func (ih *IndexedHeap[T]) Merge(other *IndexedHeap[T]) {
+	ih.heap.Merge(other.heap)
+	other.heap.data = other.heap.data[:0]
+}
+
+func (ih *IndexedHeap[T]) Size() int {
+	return ih.heap.Size()
+}
+
+func (ih *IndexedHeap[T]) IsEmpty() bool {
+	return ih.heap.IsEmpty()
+}
 
-		if left < len(h.data) && h.data[left] < h.data[smallest] {
-			smallest = left
+var (
+	ErrInvalidHandle = errors.New("handle is not in the heap")
+	ErrKeyIncreased  = errors.New("new key is greater than the current key")
+	ErrHeapMismatch  = errors.New("cannot meld heaps of different types")
+)
+
+type Handle[T any] interface {
+	Item() T
+}
+
+type PriorityQueue[T any] interface {
+	Push(value T) Handle[T]
+	Pop() (T, bool)
+	Peek() (T, bool)
+	DecreaseKey(handle Handle[T], value T) error
+	Delete(handle Handle[T]) error
+	Meld(other PriorityQueue[T]) error
+	Size() int
+	IsEmpty() bool
+}
+
+type DaryHeap[T any] struct {
+	indexed *IndexedHeap[T]
+	less    func(a, b T) bool
+}
+
+func NewDaryHeap[T any](arity int, less func(a, b T) bool) *DaryHeap[T] {
+	return &DaryHeap[T]{indexed: newIndexedHeap(max(arity, 2), less), less: less}
+}
+
+func (dh *DaryHeap[T]) Push(value T) Handle[T] {
+	return dh.indexed.Push(value)
+}
+
+func (dh *DaryHeap[T]) Pop() (T, bool) {
+	return dh.indexed.Pop()
+}
+
+func (dh *DaryHeap[T]) Peek() (T, bool) {
+	return dh.indexed.Peek()
+}
+
+func (dh *DaryHeap[T]) DecreaseKey(handle Handle[T], value T) error {
+	entry, ok := handle.(*Entry[T])
+	if !ok || !dh.indexed.Contains(entry) {
+		return ErrInvalidHandle
+	}
+	if dh.less(entry.Value, value) {
+		return ErrKeyIncreased
+	}
+	dh.indexed.Update(entry, value)
+	return nil
+}
+
+func (dh *DaryHeap[T]) Delete(handle Handle[T]) error {
+	entry, ok := handle.(*Entry[T])
+	if !ok || !dh.indexed.Remove(entry) {
+		return ErrInvalidHandle
+	}
+	return nil
+}
+
+func (dh *DaryHeap[T]) Meld(other PriorityQueue[T]) error {
+	source, ok := other.(*DaryHeap[T])
+	if !ok {
+		return ErrHeapMismatch
+	}
+	dh.indexed.Merge(source.indexed)
+	return nil
+}
+
+func (dh *DaryHeap[T]) Size() int {
+	return dh.indexed.Size()
+}
+
+func (dh *DaryHeap[T]) IsEmpty() bool {
+	return dh.indexed.IsEmpty()
+}
+
+type heapOwner struct {
+	merged *heapOwner
+}
+
+func (o *heapOwner) resolve() *heapOwner {
+	root := o
+	for root.merged != nil {
+		root = root.merged
+	}
+	for o != root {
+		next := o.merged
+		o.merged = root
+		o = next
+	}
+	return root
+}
+
+type binomialNode[T any] struct {
+	handle  *binomialHandle[T]
+	parent  *binomialNode[T]
+	child   *binomialNode[T]
+	sibling *binomialNode[T]
+	degree  int
+}
+
+type binomialHandle[T any] struct {
+	value T
+	node  *binomialNode[T]
+	owner *heapOwner
+}
+
+func (bh *binomialHandle[T]) Item() T {
+	return bh.value
+}
+
+type BinomialHeap[T any] struct {
+	head  *binomialNode[T]
+	size  int
+	less  func(a, b T) bool
+	owner *heapOwner
+}
+
+func NewBinomialHeap[T any](less func(a, b T) bool) *BinomialHeap[T] {
+	return &BinomialHeap[T]{less: less, owner: &heapOwner{}}
+}
+
+func (bh *BinomialHeap[T]) Push(value T) Handle[T] {
+	handle := &binomialHandle[T]{value: value, owner: bh.owner}
+	handle.node = &binomialNode[T]{handle: handle}
+	bh.head = bh.union(bh.head, handle.node)
+	bh.size++
+	return handle
+}
+
+func (bh *BinomialHeap[T]) minRoot() (*binomialNode[T], *binomialNode[T]) {
+	var best, bestPrev, prev *binomialNode[T]
+	for node := bh.head; node != nil; node = node.sibling {
+		if best == nil || bh.less(node.handle.value, best.handle.value) {
+			best, bestPrev = node, prev
 		}
+		prev = node
+	}
+	return best, bestPrev
+}
+
+func (bh *BinomialHeap[T]) Peek() (T, bool) {
+	root, _ := bh.minRoot()
+	if root == nil {
+		var zero T
+		return zero, false
+	}
+	return root.handle.value, true
+}
+
+func (bh *BinomialHeap[T]) Pop() (T, bool) {
+	root, prev := bh.minRoot()
+	if root == nil {
+		var zero T
+		return zero, false
+	}
+	bh.removeRoot(root, prev)
+	return root.handle.value, true
+}
+
+func (bh *BinomialHeap[T]) removeRoot(root, prev *binomialNode[T]) {
+	if prev == nil {
+		bh.head = root.sibling
+	} else {
+		prev.sibling = root.sibling
+	}
+
+	var reversed *binomialNode[T]
+	for child := root.child; child != nil; {
+		next := child.sibling
+		child.parent = nil
+		child.sibling = reversed
+		reversed = child
+		child = next
+	}
+
+	bh.head = bh.union(bh.head, reversed)
+	bh.size--
+	root.handle.node = nil
+}
 
-		if right < len(h.data) && h.data[right] < h.data[smallest] {
-			smallest = right
+func (bh *BinomialHeap[T]) mergeRootLists(a, b *binomialNode[T]) *binomialNode[T] {
+	dummy := &binomialNode[T]{}
+	tail := dummy
+	for a != nil && b != nil {
+		if a.degree <= b.degree {
+			tail.sibling, a = a, a.sibling
+		} else {
+			tail.sibling, b = b, b.sibling
 		}
+		tail = tail.sibling
+	}
+	if a != nil {
+		tail.sibling = a
+	} else {
+		tail.sibling = b
+	}
+	return dummy.sibling
+}
 
-		if smallest == index {
-			break
+func (bh *BinomialHeap[T]) union(a, b *binomialNode[T]) *binomialNode[T] {
+	head := bh.mergeRootLists(a, b)
+	if head == nil {
+		return nil
+	}
+
+	var prev *binomialNode[T]
+	current := head
+	next := current.sibling
+
+	for next != nil {
+		if current.degree != next.degree ||
+			(next.sibling != nil && next.sibling.degree == current.degree) {
+			prev, current = current, next
+		} else if !bh.less(next.handle.value, current.handle.value) {
+			current.sibling = next.sibling
+			bh.link(next, current)
+		} else {
+			if prev == nil {
+				head = next
+			} else {
+				prev.sibling = next
+			}
+			bh.link(current, next)
+			current = next
 		}
+		next = current.sibling
+	}
+
+	return head
+}
+
+func (bh *BinomialHeap[T]) link(child, parent *binomialNode[T]) {
+	child.parent = parent
+	child.sibling = parent.child
+	parent.child = child
+	parent.degree++
+}
 
-		h.swap(index, smallest)
-		index = smallest
+func (bh *BinomialHeap[T]) bubbleUp(node *binomialNode[T], force bool) *binomialNode[T] {
+	for node.parent != nil &&
+		(force || bh.less(node.handle.value, node.parent.handle.value)) {
+		parent := node.parent
+		node.handle, parent.handle = parent.handle, node.handle
+		node.handle.node = node
+		parent.handle.node = parent
+		node = parent
 	}
+	return node
 }
 
-func (h *MinHeap) Peek() (int, bool) {
-	if len(h.data) == 0 {
-		return 0, false
+func (bh *BinomialHeap[T]) lookup(handle Handle[T]) (*binomialHandle[T], bool) {
+	h, ok := handle.(*binomialHandle[T])
+	if !ok || h.node == nil || h.owner.resolve() != bh.owner {
+		return nil, false
 	}
-	return h.data[0], true
+	return h, true
 }
 
-func (h *MinHeap) Size() int {
-	return len(h.data)
+func (bh *BinomialHeap[T]) DecreaseKey(handle Handle[T], value T) error {
+	h, ok := bh.lookup(handle)
+	if !ok {
+		return ErrInvalidHandle
+	}
+	if bh.less(h.value, value) {
+		return ErrKeyIncreased
+	}
+	h.value = value
+	bh.bubbleUp(h.node, false)
+	return nil
 }
 
-func (h *MinHeap) IsEmpty() bool {
-	return len(h.data) == 0
+func (bh *BinomialHeap[T]) Delete(handle Handle[T]) error {
+	h, ok := bh.lookup(handle)
+	if !ok {
+		return ErrInvalidHandle
+	}
+
+	root := bh.bubbleUp(h.node, true)
+	var prev *binomialNode[T]
+	for node := bh.head; node != root; node = node.sibling {
+		prev = node
+	}
+	bh.removeRoot(root, prev)
+	return nil
 }
 
-func (h *MinHeap) ToSlice() []int {
-	result := make([]int, len(h.data))
-	copy(result, h.data)
-	return result
+func (bh *BinomialHeap[T]) Meld(other PriorityQueue[T]) error {
+	source, ok := other.(*BinomialHeap[T])
+	if !ok {
+		return ErrHeapMismatch
+	}
+	if source == bh {
+		return nil
+	}
+	bh.head = bh.union(bh.head, source.head)
+	bh.size += source.size
+	source.head = nil
+	source.size = 0
+	source.owner.merged = bh.owner
+	source.owner = &heapOwner{}
+	return nil
 }
 
-type MaxHeap struct {
-	data []int
+func (bh *BinomialHeap[T]) Size() int {
+	return bh.size
 }
 
-func NewMaxHeap() *MaxHeap {
-	return &MaxHeap{data: make([]int, 0)}
+func (bh *BinomialHeap[T]) IsEmpty() bool {
+	return bh.size == 0
 }
 
-func (h *MaxHeap) parent(i int) int {
-	return (i - 1) / 2
+type fibonacciNode[T any] struct {
+	value   T
+	parent  *fibonacciNode[T]
+	child   *fibonacciNode[T]
+	left    *fibonacciNode[T]
+	right   *fibonacciNode[T]
+	degree  int
+	marked  bool
+	removed bool
+	owner   *heapOwner
 }
 
-func (h *MaxHeap) leftChild(i int) int {
-	return 2*i + 1
+func (fn *fibonacciNode[T]) Item() T {
+	return fn.value
 }
 
-func (h *MaxHeap) rightChild(i int) int {
-	return 2*i + 2
+type FibonacciHeap[T any] struct {
+	min   *fibonacciNode[T]
+	size  int
+	less  func(a, b T) bool
+	owner *heapOwner
 }
 
-func (h *MaxHeap) swap(i, j int) {
-	h.data[i], h.data[j] = h.data[j], h.data[i]
+func NewFibonacciHeap[T any](less func(a, b T) bool) *FibonacciHeap[T] {
+	return &FibonacciHeap[T]{less: less, owner: &heapOwner{}}
 }
 
-func (h *MaxHeap) Insert(value int) {
-	h.data = append(h.data, value)
-	h.heapifyUp(len(h.data) - 1)
+func (fh *FibonacciHeap[T]) addRoot(node *fibonacciNode[T]) {
+	node.parent = nil
+	node.marked = false
+	if fh.min == nil {
+		node.left, node.right = node, node
+		fh.min = node
+		return
+	}
+
+	node.left = fh.min
+	node.right = fh.min.right
+	fh.min.right.left = node
+	fh.min.right = node
+	if fh.less(node.value, fh.min.value) {
+		fh.min = node
+	}
 }
 
-func (h *MaxHeap) heapifyUp(index int) {
-	for index > 0 {
-		parentIndex := h.parent(index)
-		if h.data[index] <= h.data[parentIndex] {
-			break
+func (fh *FibonacciHeap[T]) Push(value T) Handle[T] {
+	node := &fibonacciNode[T]{value: value, owner: fh.owner}
+	fh.addRoot(node)
+	fh.size++
+	return node
+}
+
+func (fh *FibonacciHeap[T]) Peek() (T, bool) {
+	if fh.min == nil {
+		var zero T
+		return zero, false
+	}
+	return fh.min.value, true
+}
+
+func (fh *FibonacciHeap[T]) Pop() (T, bool) {
+	root := fh.min
+	if root == nil {
+		var zero T
+		return zero, false
+	}
+
+	children := []*fibonacciNode[T]{}
+	if root.child != nil {
+		child := root.child
+		for {
+			children = append(children, child)
+			child = child.right
+			if child == root.child {
+				break
+			}
 		}
-		h.swap(index, parentIndex)
-		index = parentIndex
 	}
+
+	roots := children
+	for node := root.right; node != root; node = node.right {
+		roots = append(roots, node)
+	}
+
+	fh.min = nil
+	fh.consolidate(roots)
+	fh.size--
+	root.removed = true
+	root.child = nil
+	return root.value, true
 }
 
-func (h *MaxHeap) ExtractMax() (int, bool) {
-	if len(h.data) == 0 {
-		return 0, false
+func (fh *FibonacciHeap[T]) consolidate(roots []*fibonacciNode[T]) {
+	table := []*fibonacciNode[T]{}
+
+	for _, node := range roots {
+		node.parent = nil
+		node.left, node.right = node, node
+
+		for {
+			for len(table) <= node.degree {
+				table = append(table, nil)
+			}
+			other := table[node.degree]
+			if other == nil {
+				break
+			}
+			table[node.degree] = nil
+			if fh.less(other.value, node.value) {
+				node, other = other, node
+			}
+			fh.link(other, node)
+		}
+		table[node.degree] = node
 	}
 
-	max := h.data[0]
-	lastIndex := len(h.data) - 1
-	h.data[0] = h.data[lastIndex]
-	h.data = h.data[:lastIndex]
+	for _, node := range table {
+		if node != nil {
+			fh.addRoot(node)
+		}
+	}
+}
 
-	if len(h.data) > 0 {
-		h.heapifyDown(0)
+func (fh *FibonacciHeap[T]) link(child, parent *fibonacciNode[T]) {
+	child.parent = parent
+	child.marked = false
+	if parent.child == nil {
+		child.left, child.right = child, child
+		parent.child = child
+	} else {
+		child.left = parent.child
+		child.right = parent.child.right
+		parent.child.right.left = child
+		parent.child.right = child
 	}
+	parent.degree++
+}
 
-	return max, true
+func (fh *FibonacciHeap[T]) cut(node, parent *fibonacciNode[T]) {
+	if node.right == node {
+		parent.child = nil
+	} else {
+		node.left.right = node.right
+		node.right.left = node.left
+		if parent.child == node {
+			parent.child = node.right
+		}
+	}
+	parent.degree--
+	fh.addRoot(node)
 }
 
-func (h *MaxHeap) heapifyDown(index int) {
-	for {
-		largest := index
-		left := h.leftChild(index)
-		right := h.rightChild(index)
+func (fh *FibonacciHeap[T]) cascadingCut(node *fibonacciNode[T]) {
+	for parent := node.parent; parent != nil; parent = node.parent {
+		if !node.marked {
+			node.marked = true
+			return
+		}
+		fh.cut(node, parent)
+		node = parent
+	}
+}
+
+func (fh *FibonacciHeap[T]) lookup(handle Handle[T]) (*fibonacciNode[T], bool) {
+	node, ok := handle.(*fibonacciNode[T])
+	if !ok || node.removed || node.owner.resolve() != fh.owner {
+		return nil, false
+	}
+	return node, true
+}
 
-		if left < len(h.data) && h.data[left] > h.data[largest] {
-			largest = left
+func (fh *FibonacciHeap[T]) DecreaseKey(handle Handle[T], value T) error {
+	node, ok := fh.lookup(handle)
+	if !ok {
+		return ErrInvalidHandle
+	}
+	if fh.less(node.value, value) {
+		return ErrKeyIncreased
+	}
+
+	node.value = value
+	if parent := node.parent; parent != nil && fh.less(node.value, parent.value) {
+		fh.cut(node, parent)
+		fh.cascadingCut(parent)
+	}
+	if fh.less(node.value, fh.min.value) {
+		fh.min = node
+	}
+	return nil
+}
+
+func (fh *FibonacciHeap[T]) Delete(handle Handle[T]) error {
+	node, ok := fh.lookup(handle)
+	if !ok {
+		return ErrInvalidHandle
+	}
+
+	if parent := node.parent; parent != nil {
+		fh.cut(node, parent)
+		fh.cascadingCut(parent)
+	}
+	fh.min = node
+	fh.Pop()
+	return nil
+}
+
+func (fh *FibonacciHeap[T]) Meld(other PriorityQueue[T]) error {
+	source, ok := other.(*FibonacciHeap[T])
+	if !ok {
+		return ErrHeapMismatch
+	}
+	if source.min == nil || source == fh {
+		return nil
+	}
+
+	if fh.min == nil {
+		fh.min = source.min
+	} else {
+		right := fh.min.right
+		sourceLeft := source.min.left
+		fh.min.right = source.min
+		source.min.left = fh.min
+		sourceLeft.right = right
+		right.left = sourceLeft
+		if fh.less(source.min.value, fh.min.value) {
+			fh.min = source.min
 		}
+	}
+
+	fh.size += source.size
+	source.min = nil
+	source.size = 0
+	source.owner.merged = fh.owner
+	source.owner = &heapOwner{}
+	return nil
+}
 
-		if right < len(h.data) && h.data[right] > h.data[largest] {
-			largest = right
+func (fh *FibonacciHeap[T]) Size() int {
+	return fh.size
+}
+
+func (fh *FibonacciHeap[T]) IsEmpty() bool {
+	return fh.size == 0
+}
+
+type pairingNode[T any] struct {
+	value   T
+	child   *pairingNode[T]
+	sibling *pairingNode[T]
+	prev    *pairingNode[T]
+	removed bool
+	owner   *heapOwner
+}
+
+func (pn *pairingNode[T]) Item() T {
+	return pn.value
+}
+
+type PairingHeap[T any] struct {
+	root  *pairingNode[T]
+	size  int
+	less  func(a, b T) bool
+	owner *heapOwner
+}
+
+func NewPairingHeap[T any](less func(a, b T) bool) *PairingHeap[T] {
+	return &PairingHeap[T]{less: less, owner: &heapOwner{}}
+}
+
+func (ph *PairingHeap[T]) meld(a, b *pairingNode[T]) *pairingNode[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if ph.less(b.value, a.value) {
+		a, b = b, a
+	}
+
+	b.prev = a
+	b.sibling = a.child
+	if a.child != nil {
+		a.child.prev = b
+	}
+	a.child = b
+	a.sibling = nil
+	a.prev = nil
+	return a
+}
+
+func (ph *PairingHeap[T]) mergePairs(first *pairingNode[T]) *pairingNode[T] {
+	nodes := []*pairingNode[T]{}
+	for node := first; node != nil; {
+		next := node.sibling
+		node.prev = nil
+		node.sibling = nil
+		nodes = append(nodes, node)
+		node = next
+	}
+
+	paired := []*pairingNode[T]{}
+	for i := 0; i < len(nodes); i += 2 {
+		if i+1 < len(nodes) {
+			paired = append(paired, ph.meld(nodes[i], nodes[i+1]))
+		} else {
+			paired = append(paired, nodes[i])
 		}
+	}
 
-		if largest == index {
-			break
+	var result *pairingNode[T]
+	for i := len(paired) - 1; i >= 0; i-- {
+		result = ph.meld(paired[i], result)
+	}
+	return result
+}
+
+func (ph *PairingHeap[T]) detach(node *pairingNode[T]) {
+	if node.prev.child == node {
+		node.prev.child = node.sibling
+	} else {
+		node.prev.sibling = node.sibling
+	}
+	if node.sibling != nil {
+		node.sibling.prev = node.prev
+	}
+	node.prev = nil
+	node.sibling = nil
+}
+
+func (ph *PairingHeap[T]) Push(value T) Handle[T] {
+	node := &pairingNode[T]{value: value, owner: ph.owner}
+	ph.root = ph.meld(ph.root, node)
+	ph.size++
+	return node
+}
+
+func (ph *PairingHeap[T]) Peek() (T, bool) {
+	if ph.root == nil {
+		var zero T
+		return zero, false
+	}
+	return ph.root.value, true
+}
+
+func (ph *PairingHeap[T]) Pop() (T, bool) {
+	root := ph.root
+	if root == nil {
+		var zero T
+		return zero, false
+	}
+
+	ph.root = ph.mergePairs(root.child)
+	root.child = nil
+	root.removed = true
+	ph.size--
+	return root.value, true
+}
+
+func (ph *PairingHeap[T]) lookup(handle Handle[T]) (*pairingNode[T], bool) {
+	node, ok := handle.(*pairingNode[T])
+	if !ok || node.removed || node.owner.resolve() != ph.owner {
+		return nil, false
+	}
+	return node, true
+}
+
+func (ph *PairingHeap[T]) DecreaseKey(handle Handle[T], value T) error {
+	node, ok := ph.lookup(handle)
+	if !ok {
+		return ErrInvalidHandle
+	}
+	if ph.less(node.value, value) {
+		return ErrKeyIncreased
+	}
+
+	node.value = value
+	if node != ph.root {
+		ph.detach(node)
+		ph.root = ph.meld(ph.root, node)
+	}
+	return nil
+}
+
+func (ph *PairingHeap[T]) Delete(handle Handle[T]) error {
+	node, ok := ph.lookup(handle)
+	if !ok {
+		return ErrInvalidHandle
+	}
+
+	if node == ph.root {
+		ph.Pop()
+		return nil
+	}
+
+	ph.detach(node)
+	ph.root = ph.meld(ph.root, ph.mergePairs(node.child))
+	node.child = nil
+	node.removed = true
+	ph.size--
+	return nil
+}
+
+func (ph *PairingHeap[T]) Meld(other PriorityQueue[T]) error {
+	source, ok := other.(*PairingHeap[T])
+	if !ok {
+		return ErrHeapMismatch
+	}
+	if source == ph {
+		return nil
+	}
+	ph.root = ph.meld(ph.root, source.root)
+	ph.size += source.size
+	source.root = nil
+	source.size = 0
+	source.owner.merged = ph.owner
+	source.owner = &heapOwner{}
+	return nil
+}
+
+func (ph *PairingHeap[T]) Size() int {
+	return ph.size
+}
+
+func (ph *PairingHeap[T]) IsEmpty() bool {
+	return ph.size == 0
+}
+
+func TopK[T any](seq iter.Seq[T], k int, less func(a, b T) bool) []T {
+	if k <= 0 {
+		return []T{}
+	}
+
+	h := NewHeap(less)
+	for item := range seq {
+		if h.Size() < k {
+			h.Push(item)
+		} else if top, _ := h.Peek(); less(top, item) {
+			h.data[0] = item
+			h.down(0)
 		}
+	}
 
-		h.swap(index, largest)
-		index = largest
+	result := make([]T, h.Size())
+	for i := len(result) - 1; i >= 0; i-- {
+		result[i], _ = h.Pop()
 	}
+	return result
 }
 
-func (h *MaxHeap) Peek() (int, bool) {
-	if len(h.data) == 0 {
+type RunningMedian struct {
+	low  *Heap[float64]
+	high *Heap[float64]
+}
+
+func NewRunningMedian() *RunningMedian {
+	return &RunningMedian{
+		low:  NewHeap(func(a, b float64) bool { return a > b }),
+		high: NewHeap(func(a, b float64) bool { return a < b }),
+	}
+}
+
+func (rm *RunningMedian) Add(value float64) {
+	if top, ok := rm.low.Peek(); !ok || value <= top {
+		rm.low.Push(value)
+	} else {
+		rm.high.Push(value)
+	}
+
+	if rm.low.Size() > rm.high.Size()+1 {
+		moved, _ := rm.low.Pop()
+		rm.high.Push(moved)
+	} else if rm.high.Size() > rm.low.Size() {
+		moved, _ := rm.high.Pop()
+		rm.low.Push(moved)
+	}
+}
+
+func (rm *RunningMedian) Median() (float64, bool) {
+	low, ok := rm.low.Peek()
+	if !ok {
 		return 0, false
 	}
-	return h.data[0], true
+	if rm.low.Size() > rm.high.Size() {
+		return low, true
+	}
+	high, _ := rm.high.Peek()
+	return (low + high) / 2, true
+}
+
+func (rm *RunningMedian) Size() int {
+	return rm.low.Size() + rm.high.Size()
+}
+
+type windowItem struct {
+	value float64
+	index int
+}
+
+func SlidingWindowMedian(values []float64, window int) []float64 {
+	if window <= 0 || window > len(values) {
+		return []float64{}
+	}
+
+	low := NewIndexedHeap(func(a, b windowItem) bool { return a.value > b.value })
+	high := NewIndexedHeap(func(a, b windowItem) bool { return a.value < b.value })
+	entries := make([]*Entry[windowItem], len(values))
+
+	move := func(from, to *IndexedHeap[windowItem]) {
+		item, _ := from.Pop()
+		entries[item.index] = to.Push(item)
+	}
+
+	rebalance := func() {
+		if low.Size() > high.Size()+1 {
+			move(low, high)
+		} else if high.Size() > low.Size() {
+			move(high, low)
+		}
+	}
+
+	result := make([]float64, 0, len(values)-window+1)
+	for i, value := range values {
+		item := windowItem{value: value, index: i}
+		if top, ok := low.Peek(); !ok || value <= top.value {
+			entries[i] = low.Push(item)
+		} else {
+			entries[i] = high.Push(item)
+		}
+
+		if i >= window {
+			expired := entries[i-window]
+			if !low.Remove(expired) {
+				high.Remove(expired)
+			}
+			entries[i-window] = nil
+		}
+		rebalance()
+
+		if i >= window-1 {
+			lowTop, _ := low.Peek()
+			if low.Size() > high.Size() {
+				result = append(result, lowTop.value)
+			} else {
+				highTop, _ := high.Peek()
+				result = append(result, (lowTop.value+highTop.value)/2)
+			}
+		}
+	}
+
+	return result
+}
+
+type mergeCursor[T any] struct {
+	value  T
+	source int
+}
+
+func MergeSeqs[T any](less func(a, b T) bool, seqs ...iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		nexts := make([]func() (T, bool), len(seqs))
+		h := NewHeap(func(a, b mergeCursor[T]) bool {
+			if less(a.value, b.value) {
+				return true
+			}
+			return !less(b.value, a.value) && a.source < b.source
+		})
+
+		for i, seq := range seqs {
+			next, stop := iter.Pull(seq)
+			defer stop()
+			nexts[i] = next
+			if value, ok := next(); ok {
+				h.Push(mergeCursor[T]{value: value, source: i})
+			}
+		}
+
+		for !h.IsEmpty() {
+			top := h.data[0]
+			if !yield(top.value) {
+				return
+			}
+
+			if value, ok := nexts[top.source](); ok {
+				h.data[0] = mergeCursor[T]{value: value, source: top.source}
+				h.down(0)
+			} else {
+				h.Pop()
+			}
+		}
+	}
+}
+
+func MergeSlices[T any](less func(a, b T) bool, sorted ...[]T) []T {
+	total := 0
+	seqs := make([]iter.Seq[T], len(sorted))
+	for i, s := range sorted {
+		total += len(s)
+		seqs[i] = slices.Values(s)
+	}
+
+	result := make([]T, 0, total)
+	for value := range MergeSeqs(less, seqs...) {
+		result = append(result, value)
+	}
+	return result
+}
+
+func MergeChannels[T any](ctx context.Context, less func(a, b T) bool, channels ...<-chan T) <-chan T {
+	seqs := make([]iter.Seq[T], len(channels))
+	for i, ch := range channels {
+		seqs[i] = func(yield func(T) bool) {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case value, ok := <-ch:
+					if !ok || !yield(value) {
+						return
+					}
+				}
+			}
+		}
+	}
+
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for value := range MergeSeqs(less, seqs...) {
+			select {
+			case <-ctx.Done():
+				return
+			case out <- value:
+			}
+		}
+	}()
+	return out
+}
+
+type MinHeap struct {
+	heap *Heap[int]
+}
+
+func NewMinHeap() *MinHeap {
+	return &MinHeap{heap: NewHeap(func(a, b int) bool { return a < b })}
+}
+
+func (h *MinHeap) Insert(value int) {
+	h.heap.Push(value)
+}
+
+func (h *MinHeap) ExtractMin() (int, bool) {
+	return h.heap.Pop()
+}
+
+func (h *MinHeap) Peek() (int, bool) {
+	return h.heap.Peek()
+}
+
+func (h *MinHeap) Size() int {
+	return h.heap.Size()
+}
+
+func (h *MinHeap) IsEmpty() bool {
+	return h.heap.IsEmpty()
+}
+
+func (h *MinHeap) ToSlice() []int {
+	return h.heap.ToSlice()
+}
+
+type MaxHeap struct {
+	heap *Heap[int]
+}
+
+func NewMaxHeap() *MaxHeap {
+	return &MaxHeap{heap: NewHeap(func(a, b int) bool { return a > b })}
+}
+
+func (h *MaxHeap) Insert(value int) {
+	h.heap.Push(value)
+}
+
+func (h *MaxHeap) ExtractMax() (int, bool) {
+	return h.heap.Pop()
+}
+
+func (h *MaxHeap) Peek() (int, bool) {
+	return h.heap.Peek()
 }
 
 func (h *MaxHeap) Size() int {
-	return len(h.data)
+	return h.heap.Size()
 }
 
 func (h *MaxHeap) IsEmpty() bool {
-	return len(h.data) == 0
+	return h.heap.IsEmpty()
 }
 
 func (h *MaxHeap) ToSlice() []int {
-	result := make([]int, len(h.data))
-	copy(result, h.data)
-	return result
+	return h.heap.ToSlice()
 }
 
 type IntHeap []int
@@ -226,14 +1264,11 @@ func (h *IntHeap) Pop() any {
 }
 
 func HeapSort(arr []int) []int {
-	maxHeap := NewMaxHeap()
-	for _, v := range arr {
-		maxHeap.Insert(v)
-	}
+	maxHeap := Heapify(arr, func(a, b int) bool { return a > b })
 
 	result := make([]int, 0, len(arr))
 	for !maxHeap.IsEmpty() {
-		val, _ := maxHeap.ExtractMax()
+		val, _ := maxHeap.Pop()
 		result = append(result, val)
 	}
 
@@ -2,7 +2,11 @@ package heap
 
 import (
 	"container/heap"
+	"context"
+	"errors"
+	"math/rand"
 	"reflect"
+	"slices"
 	"sort"
 	"testing"
 )
@@ -211,6 +215,597 @@ func TestSingleElement(t *testing.T) {
 	}
 }
 
+type task struct {
+	name     string
+	priority int
+}
+
+func drain[T any](h *Heap[T]) []T {
+	result := []T{}
+	for !h.IsEmpty() {
+		value, _ := h.Pop()
+		result = append(result, value)
+	}
+	return result
+}
+
+func TestGenericHeapWithStructs(t *testing.T) {
+	h := NewHeap(func(a, b task) bool { return a.priority < b.priority })
+	h.Push(task{"deploy", 3})
+	h.Push(task{"build", 1})
+	h.Push(task{"test", 2})
+
+	top, ok := h.Peek()
+	if !ok || top.name != "build" {
+		t.Errorf("Expected build on top, got %v", top)
+	}
+
+	names := []string{}
+	for _, item := range drain(h) {
+		names = append(names, item.name)
+	}
+	if !reflect.DeepEqual(names, []string{"build", "test", "deploy"}) {
+		t.Errorf("Expected [build test deploy], got %v", names)
+	}
+
+	if _, ok := h.Pop(); ok {
+		t.Error("Expected Pop on empty heap to fail")
+	}
+}
+
+func TestHeapify(t *testing.T) {
+	values := []int{9, 4, 7, 1, 8, 2, 6, 3, 5, 0}
+	original := slices.Clone(values)
+
+	h := Heapify(values, func(a, b int) bool { return a < b })
+
+	if !reflect.DeepEqual(values, original) {
+		t.Error("Heapify should not modify the input slice")
+	}
+
+	expected := slices.Clone(values)
+	sort.Ints(expected)
+	if result := drain(h); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+
+	if empty := Heapify([]int{}, func(a, b int) bool { return a < b }); !empty.IsEmpty() {
+		t.Error("Expected empty heap from empty slice")
+	}
+}
+
+func TestHeapMerge(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	left := Heapify([]int{5, 1, 9}, less)
+	right := Heapify([]int{4, 8, 2, 7}, less)
+
+	left.Merge(right)
+
+	if right.Size() != 4 {
+		t.Errorf("Merge should leave the other heap intact, got size %d", right.Size())
+	}
+	if result := drain(left); !reflect.DeepEqual(result, []int{1, 2, 4, 5, 7, 8, 9}) {
+		t.Errorf("Expected merged order, got %v", result)
+	}
+}
+
+func TestIndexedHeapUpdateRemoveContains(t *testing.T) {
+	pq := NewIndexedHeap(func(a, b task) bool { return a.priority < b.priority })
+
+	build := pq.Push(task{"build", 5})
+	test := pq.Push(task{"test", 3})
+	deploy := pq.Push(task{"deploy", 4})
+
+	if !pq.Contains(build) || !pq.Contains(test) || !pq.Contains(deploy) {
+		t.Fatal("Expected all entries to be contained")
+	}
+
+	pq.Update(build, task{"build", 1})
+	if top, _ := pq.Peek(); top.name != "build" {
+		t.Errorf("Expected build after decrease-key, got %s", top.name)
+	}
+
+	pq.Update(build, task{"build", 10})
+	if top, _ := pq.Peek(); top.name != "test" {
+		t.Errorf("Expected test after increase-key, got %s", top.name)
+	}
+
+	if !pq.Remove(test) {
+		t.Error("Expected removal of test to succeed")
+	}
+	if pq.Contains(test) || pq.Remove(test) || pq.Update(test, task{"test", 0}) {
+		t.Error("Removed entry should no longer be usable")
+	}
+
+	first, _ := pq.Pop()
+	if first.name != "deploy" || pq.Contains(deploy) {
+		t.Errorf("Expected to pop deploy and drop its handle, got %s", first.name)
+	}
+
+	other := NewIndexedHeap(func(a, b task) bool { return a.priority < b.priority })
+	if other.Contains(build) {
+		t.Error("Entry should not be contained in a different heap")
+	}
+}
+
+func TestIndexedHeapMatchesSortedModel(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	pq := NewIndexedHeap(func(a, b int) bool { return a < b })
+	entries := []*Entry[int]{}
+
+	for range 2000 {
+		switch rng.Intn(4) {
+		case 0, 1:
+			entries = append(entries, pq.Push(rng.Intn(1000)))
+		case 2:
+			if len(entries) > 0 {
+				entry := entries[rng.Intn(len(entries))]
+				pq.Update(entry, rng.Intn(1000))
+			}
+		case 3:
+			if len(entries) > 0 {
+				i := rng.Intn(len(entries))
+				pq.Remove(entries[i])
+				entries = slices.Delete(entries, i, i+1)
+			}
+		}
+	}
+
+	expected := []int{}
+	for _, entry := range entries {
+		if !pq.Contains(entry) {
+			t.Fatal("Live entry is not contained")
+		}
+		expected = append(expected, entry.Value)
+	}
+	sort.Ints(expected)
+
+	result := []int{}
+	for !pq.IsEmpty() {
+		value, _ := pq.Pop()
+		result = append(result, value)
+	}
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Indexed heap order diverged from sorted model")
+	}
+}
+
+func TestIndexedHeapMerge(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	left := NewIndexedHeap(less)
+	right := NewIndexedHeap(less)
+
+	left.Push(3)
+	moved := right.Push(5)
+	right.Push(1)
+
+	left.Merge(right)
+
+	if !right.IsEmpty() || !left.Contains(moved) {
+		t.Fatal("Merge should move entries and keep their handles valid")
+	}
+
+	left.Update(moved, 0)
+	if top, _ := left.Peek(); top != 0 {
+		t.Errorf("Expected moved entry on top after update, got %d", top)
+	}
+}
+
+func priorityQueues() map[string]func() PriorityQueue[int] {
+	less := func(a, b int) bool { return a < b }
+	return map[string]func() PriorityQueue[int]{
+		"binary":    func() PriorityQueue[int] { return NewDaryHeap(2, less) },
+		"4-ary":     func() PriorityQueue[int] { return NewDaryHeap(4, less) },
+		"binomial":  func() PriorityQueue[int] { return NewBinomialHeap(less) },
+		"fibonacci": func() PriorityQueue[int] { return NewFibonacciHeap(less) },
+		"pairing":   func() PriorityQueue[int] { return NewPairingHeap(less) },
+	}
+}
+
+func drainQueue(pq PriorityQueue[int]) []int {
+	result := []int{}
+	for !pq.IsEmpty() {
+		value, _ := pq.Pop()
+		result = append(result, value)
+	}
+	return result
+}
+
+func TestPriorityQueuesBasic(t *testing.T) {
+	for name, newQueue := range priorityQueues() {
+		t.Run(name, func(t *testing.T) {
+			pq := newQueue()
+			if _, ok := pq.Pop(); ok {
+				t.Error("Expected Pop on empty queue to fail")
+			}
+			if _, ok := pq.Peek(); ok {
+				t.Error("Expected Peek on empty queue to fail")
+			}
+
+			for _, v := range []int{15, 10, 20, 8, 25, 5, 12, 5} {
+				pq.Push(v)
+			}
+
+			if top, _ := pq.Peek(); top != 5 {
+				t.Errorf("Expected 5 on top, got %d", top)
+			}
+			if result := drainQueue(pq); !reflect.DeepEqual(result, []int{5, 5, 8, 10, 12, 15, 20, 25}) {
+				t.Errorf("Unexpected order %v", result)
+			}
+		})
+	}
+}
+
+func TestPriorityQueuesDecreaseKeyAndDelete(t *testing.T) {
+	for name, newQueue := range priorityQueues() {
+		t.Run(name, func(t *testing.T) {
+			pq := newQueue()
+			handles := make([]Handle[int], 10)
+			for i := range handles {
+				handles[i] = pq.Push((i + 1) * 10)
+			}
+			pq.Pop()
+			pq.Push(1000)
+			pq.Pop()
+
+			if err := pq.DecreaseKey(handles[7], 5); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if handles[7].Item() != 5 {
+				t.Errorf("Expected handle to report 5, got %d", handles[7].Item())
+			}
+			if top, _ := pq.Peek(); top != 5 {
+				t.Errorf("Expected 5 after decrease-key, got %d", top)
+			}
+
+			if err := pq.DecreaseKey(handles[3], 500); !errors.Is(err, ErrKeyIncreased) {
+				t.Errorf("Expected ErrKeyIncreased, got %v", err)
+			}
+
+			if err := pq.Delete(handles[5]); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if err := pq.Delete(handles[5]); !errors.Is(err, ErrInvalidHandle) {
+				t.Errorf("Expected ErrInvalidHandle for deleted handle, got %v", err)
+			}
+			if err := pq.DecreaseKey(handles[0], 0); !errors.Is(err, ErrInvalidHandle) {
+				t.Errorf("Expected ErrInvalidHandle for popped handle, got %v", err)
+			}
+
+			expected := []int{5, 30, 40, 50, 70, 90, 100, 1000}
+			if result := drainQueue(pq); !reflect.DeepEqual(result, expected) {
+				t.Errorf("Expected %v, got %v", expected, result)
+			}
+		})
+	}
+}
+
+func TestPriorityQueuesMeld(t *testing.T) {
+	for name, newQueue := range priorityQueues() {
+		t.Run(name, func(t *testing.T) {
+			left := newQueue()
+			right := newQueue()
+			for _, v := range []int{9, 3, 7} {
+				left.Push(v)
+			}
+			handle := right.Push(8)
+			right.Push(1)
+			right.Push(4)
+
+			if err := left.Meld(right); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !right.IsEmpty() || left.Size() != 6 {
+				t.Errorf("Expected sizes 6 and 0, got %d and %d", left.Size(), right.Size())
+			}
+
+			if err := right.DecreaseKey(handle, 2); !errors.Is(err, ErrInvalidHandle) {
+				t.Errorf("Expected the melded-away heap to reject the handle, got %v", err)
+			}
+			if err := left.DecreaseKey(handle, 0); err != nil {
+				t.Errorf("Handle should stay valid after meld: %v", err)
+			}
+			if result := drainQueue(left); !reflect.DeepEqual(result, []int{0, 1, 3, 4, 7, 9}) {
+				t.Errorf("Unexpected order %v", result)
+			}
+		})
+	}
+
+	less := func(a, b int) bool { return a < b }
+	if err := NewPairingHeap(less).Meld(NewFibonacciHeap(less)); !errors.Is(err, ErrHeapMismatch) {
+		t.Errorf("Expected ErrHeapMismatch, got %v", err)
+	}
+}
+
+func TestPriorityQueuesRejectForeignHandles(t *testing.T) {
+	for name, newQueue := range priorityQueues() {
+		t.Run(name, func(t *testing.T) {
+			first, second := newQueue(), newQueue()
+			first.Push(10)
+			foreign := second.Push(20)
+
+			if err := first.DecreaseKey(foreign, 1); !errors.Is(err, ErrInvalidHandle) {
+				t.Errorf("Expected ErrInvalidHandle for another heap's handle, got %v", err)
+			}
+			if err := first.Delete(foreign); !errors.Is(err, ErrInvalidHandle) {
+				t.Errorf("Expected ErrInvalidHandle when deleting another heap's handle, got %v", err)
+			}
+			if first.Size() != 1 || second.Size() != 1 || foreign.Item() != 20 {
+				t.Errorf("Expected both heaps untouched, got sizes %d and %d", first.Size(), second.Size())
+			}
+
+			third := newQueue()
+			if err := third.Meld(second); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			moved := second.Push(30)
+			if err := third.DecreaseKey(moved, 1); !errors.Is(err, ErrInvalidHandle) {
+				t.Errorf("Expected a handle pushed after the meld to stay with its own heap, got %v", err)
+			}
+			if err := third.DecreaseKey(foreign, 5); err != nil {
+				t.Errorf("Expected the melded handle to belong to the new heap: %v", err)
+			}
+			if top, _ := third.Peek(); top != 5 {
+				t.Errorf("Expected 5 on top, got %d", top)
+			}
+		})
+	}
+}
+
+func TestPriorityQueuesMatchSortedModel(t *testing.T) {
+	for name, newQueue := range priorityQueues() {
+		t.Run(name, func(t *testing.T) {
+			rng := rand.New(rand.NewSource(3))
+			pq := newQueue()
+			live := map[Handle[int]]bool{}
+			handles := []Handle[int]{}
+
+			for range 5000 {
+				op := rng.Intn(6)
+				if len(handles) == 0 {
+					op = 0
+				}
+
+				switch op {
+				case 0, 1, 2:
+					handle := pq.Push(rng.Intn(100000))
+					live[handle] = true
+					handles = append(handles, handle)
+				case 3:
+					value, ok := pq.Pop()
+					if ok != (len(live) > 0) {
+						t.Fatal("Pop result disagrees with model size")
+					}
+					if !ok {
+						continue
+					}
+					for handle := range live {
+						if handle.Item() < value {
+							t.Fatalf("Popped %d but %d is still live", value, handle.Item())
+						}
+					}
+					for handle := range live {
+						if handle.Item() == value {
+							delete(live, handle)
+							break
+						}
+					}
+				case 4:
+					handle := handles[rng.Intn(len(handles))]
+					if live[handle] {
+						pq.DecreaseKey(handle, handle.Item()-rng.Intn(1000))
+					}
+				case 5:
+					handle := handles[rng.Intn(len(handles))]
+					if live[handle] {
+						if err := pq.Delete(handle); err != nil {
+							t.Fatalf("Unexpected error: %v", err)
+						}
+						delete(live, handle)
+					}
+				}
+
+				if pq.Size() != len(live) {
+					t.Fatalf("Expected size %d, got %d", len(live), pq.Size())
+				}
+			}
+
+			expected := []int{}
+			for handle := range live {
+				expected = append(expected, handle.Item())
+			}
+			sort.Ints(expected)
+			if result := drainQueue(pq); !reflect.DeepEqual(result, expected) {
+				t.Error("Final drain diverged from sorted model")
+			}
+		})
+	}
+}
+
+func TestTopK(t *testing.T) {
+	values := []int{5, 1, 9, 3, 7, 9, 2, 8}
+
+	result := TopK(slices.Values(values), 3, func(a, b int) bool { return a < b })
+	if !reflect.DeepEqual(result, []int{9, 9, 8}) {
+		t.Errorf("Expected [9 9 8], got %v", result)
+	}
+
+	smallest := TopK(slices.Values(values), 2, func(a, b int) bool { return a > b })
+	if !reflect.DeepEqual(smallest, []int{1, 2}) {
+		t.Errorf("Expected [1 2], got %v", smallest)
+	}
+
+	if all := TopK(slices.Values(values), 100, func(a, b int) bool { return a < b }); len(all) != len(values) {
+		t.Errorf("Expected all %d values, got %d", len(values), len(all))
+	}
+	if none := TopK(slices.Values(values), 0, func(a, b int) bool { return a < b }); len(none) != 0 {
+		t.Errorf("Expected no values, got %v", none)
+	}
+}
+
+func TestRunningMedian(t *testing.T) {
+	rm := NewRunningMedian()
+	if _, ok := rm.Median(); ok {
+		t.Error("Expected no median for empty stream")
+	}
+
+	values := []float64{5, 15, 1, 3, 2, 8, 7, 9, 10, 6, 11, 4}
+	seen := []float64{}
+	for _, v := range values {
+		rm.Add(v)
+		seen = append(seen, v)
+
+		sorted := slices.Clone(seen)
+		slices.Sort(sorted)
+		n := len(sorted)
+		expected := sorted[n/2]
+		if n%2 == 0 {
+			expected = (sorted[n/2-1] + sorted[n/2]) / 2
+		}
+
+		if median, _ := rm.Median(); median != expected {
+			t.Errorf("After %v: expected median %v, got %v", seen, expected, median)
+		}
+	}
+
+	if rm.Size() != len(values) {
+		t.Errorf("Expected size %d, got %d", len(values), rm.Size())
+	}
+}
+
+func TestSlidingWindowMedian(t *testing.T) {
+	values := []float64{1, 3, -1, -3, 5, 3, 6, 7}
+	expected := []float64{1, -1, -1, 3, 5, 6}
+
+	if result := SlidingWindowMedian(values, 3); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+
+	evenExpected := []float64{2, 1, -2, 1, 4, 4.5, 6.5}
+	if result := SlidingWindowMedian(values, 2); !reflect.DeepEqual(result, evenExpected) {
+		t.Errorf("Expected %v, got %v", evenExpected, result)
+	}
+
+	if result := SlidingWindowMedian(values, 0); len(result) != 0 {
+		t.Errorf("Expected empty result for invalid window, got %v", result)
+	}
+
+	rng := rand.New(rand.NewSource(5))
+	random := make([]float64, 300)
+	for i := range random {
+		random[i] = float64(rng.Intn(50))
+	}
+	for _, window := range []int{1, 4, 7, 50} {
+		result := SlidingWindowMedian(random, window)
+		for i := range result {
+			sorted := slices.Clone(random[i : i+window])
+			slices.Sort(sorted)
+			median := sorted[window/2]
+			if window%2 == 0 {
+				median = (sorted[window/2-1] + sorted[window/2]) / 2
+			}
+			if result[i] != median {
+				t.Fatalf("Window %d at %d: expected %v, got %v", window, i, median, result[i])
+			}
+		}
+	}
+}
+
+type record struct {
+	key    int
+	source string
+}
+
+func TestMergeSlicesIsStable(t *testing.T) {
+	less := func(a, b record) bool { return a.key < b.key }
+	a := []record{{1, "a"}, {3, "a"}, {5, "a"}}
+	b := []record{{1, "b"}, {2, "b"}, {5, "b"}}
+	c := []record{{0, "c"}, {5, "c"}}
+
+	expected := []record{{0, "c"}, {1, "a"}, {1, "b"}, {2, "b"}, {3, "a"}, {5, "a"}, {5, "b"}, {5, "c"}}
+	if result := MergeSlices(less, a, b, c); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+
+	if result := MergeSlices(less); len(result) != 0 {
+		t.Errorf("Expected empty merge, got %v", result)
+	}
+}
+
+func TestMergeSeqsStopsEarly(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	evens := func(yield func(int) bool) {
+		for i := 0; ; i += 2 {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	odds := func(yield func(int) bool) {
+		for i := 1; ; i += 2 {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+
+	result := []int{}
+	for value := range MergeSeqs(less, evens, odds) {
+		result = append(result, value)
+		if len(result) == 6 {
+			break
+		}
+	}
+
+	if !reflect.DeepEqual(result, []int{0, 1, 2, 3, 4, 5}) {
+		t.Errorf("Expected [0 1 2 3 4 5], got %v", result)
+	}
+}
+
+func TestMergeChannels(t *testing.T) {
+	produce := func(values ...int) <-chan int {
+		ch := make(chan int)
+		go func() {
+			defer close(ch)
+			for _, v := range values {
+				ch <- v
+			}
+		}()
+		return ch
+	}
+
+	merged := MergeChannels(context.Background(), func(a, b int) bool { return a < b },
+		produce(1, 4, 7), produce(2, 5, 8), produce(3, 6, 9))
+
+	result := []int{}
+	for value := range merged {
+		result = append(result, value)
+	}
+
+	if !reflect.DeepEqual(result, []int{1, 2, 3, 4, 5, 6, 7, 8, 9}) {
+		t.Errorf("Expected 1..9, got %v", result)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	endless := make(chan int)
+	go func() {
+		for i := 0; ; i++ {
+			select {
+			case endless <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	out := MergeChannels(ctx, func(a, b int) bool { return a < b }, endless)
+	<-out
+	cancel()
+	for range out {
+	}
+}
+
 func TestRun(t *testing.T) {
 	result := Run()
 	resultMap, ok := result.(map[string]any)
@@ -304,3 +899,71 @@ func BenchmarkStandardSort(b *testing.B) {
 		sort.Sort(sort.Reverse(sort.IntSlice(sorted)))
 	}
 }
+
+func BenchmarkHeapify(b *testing.B) {
+	values := make([]int, 10000)
+	for i := range values {
+		values[i] = (i * 7919) % 10007
+	}
+
+	for b.Loop() {
+		Heapify(values, func(a, b int) bool { return a < b })
+	}
+}
+
+func BenchmarkIndexedHeapUpdate(b *testing.B) {
+	pq := NewIndexedHeap(func(a, b int) bool { return a < b })
+	entries := make([]*Entry[int], 1000)
+	for i := range entries {
+		entries[i] = pq.Push(i)
+	}
+
+	for i := 0; b.Loop(); i++ {
+		pq.Update(entries[i%len(entries)], (i*7919)%10007)
+	}
+}
+
+func BenchmarkPriorityQueues(b *testing.B) {
+	for name, newQueue := range priorityQueues() {
+		b.Run(name, func(b *testing.B) {
+			for b.Loop() {
+				pq := newQueue()
+				handles := make([]Handle[int], 0, 1000)
+				for i := range 1000 {
+					handles = append(handles, pq.Push((i*7919)%10007+10007))
+				}
+				for i, handle := range handles {
+					pq.DecreaseKey(handle, handle.Item()-i%100)
+				}
+				for !pq.IsEmpty() {
+					pq.Pop()
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkTopK(b *testing.B) {
+	values := make([]int, 100000)
+	for i := range values {
+		values[i] = (i * 7919) % 100003
+	}
+
+	for b.Loop() {
+		TopK(slices.Values(values), 100, func(a, b int) bool { return a < b })
+	}
+}
+
+func BenchmarkMergeSlices(b *testing.B) {
+	runs := make([][]int, 16)
+	for i := range runs {
+		runs[i] = make([]int, 1000)
+		for j := range runs[i] {
+			runs[i][j] = j*16 + i
+		}
+	}
+
+	for b.Loop() {
+		MergeSlices(func(a, b int) bool { return a < b }, runs...)
+	}
+}
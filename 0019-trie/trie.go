@@ -1,14 +1,26 @@
 package trie
 
 import (
+	"bufio"
+	"io"
+	"iter"
+	"maps"
+	"net/netip"
+	"slices"
 	"sort"
 	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/celj/dsa/0018-heap"
+	"github.com/celj/dsa/0045-deque"
 )
 
 type TrieNode struct {
 	children map[rune]*TrieNode
 	isEnd    bool
 	word     string
+	weight   float64
 }
 
 func NewTrieNode() *TrieNode {
@@ -19,27 +31,127 @@ func NewTrieNode() *TrieNode {
 	}
 }
 
+type TrieOptions struct {
+	CaseSensitive  bool
+	FoldDiacritics bool
+}
+
 type Trie struct {
-	root *TrieNode
-	size int
+	root    *TrieNode
+	size    int
+	options TrieOptions
 }
 
 func NewTrie() *Trie {
+	return NewTrieWithOptions(TrieOptions{})
+}
+
+func NewTrieWithOptions(options TrieOptions) *Trie {
 	return &Trie{
-		root: NewTrieNode(),
-		size: 0,
+		root:    NewTrieNode(),
+		size:    0,
+		options: options,
+	}
+}
+
+var diacriticBase = func() map[rune]rune {
+	groups := map[rune]string{
+		'a': "àáâãäåāăą",
+		'c': "çćĉċč",
+		'd': "ďđ",
+		'e': "èéêëēĕėęě",
+		'g': "ĝğġģ",
+		'h': "ĥħ",
+		'i': "ìíîïĩīĭįı",
+		'j': "ĵ",
+		'k': "ķ",
+		'l': "ĺļľŀł",
+		'n': "ñńņňŉ",
+		'o': "òóôõöøōŏő",
+		'r': "ŕŗř",
+		's': "śŝşš",
+		't': "ţťŧ",
+		'u': "ùúûüũūŭůűų",
+		'w': "ŵ",
+		'y': "ýÿŷ",
+		'z': "źżž",
+	}
+
+	bases := make(map[rune]rune)
+	for base, accented := range groups {
+		for _, r := range accented {
+			bases[r] = base
+			if upper := unicode.ToUpper(r); upper != r {
+				bases[upper] = unicode.ToUpper(base)
+			}
+		}
+	}
+	return bases
+}()
+
+func (o TrieOptions) normalizeRune(r rune) (rune, bool) {
+	if o.FoldDiacritics {
+		if unicode.Is(unicode.Mn, r) {
+			return 0, false
+		}
+		if base, ok := diacriticBase[r]; ok {
+			r = base
+		}
+	}
+	if !o.CaseSensitive {
+		r = unicode.ToLower(r)
 	}
+	return r, true
+}
+
+func (t *Trie) normalize(word string) string {
+	var normalized strings.Builder
+	normalized.Grow(len(word))
+	for _, r := range word {
+		if n, keep := t.options.normalizeRune(r); keep {
+			normalized.WriteRune(n)
+		}
+	}
+	return normalized.String()
+}
+
+func (t *Trie) display(word string) string {
+	if t.options.CaseSensitive {
+		return word
+	}
+	return strings.ToLower(word)
+}
+
+func (t *Trie) find(key string) *TrieNode {
+	current := t.root
+
+	for _, char := range key {
+		if current.children[char] == nil {
+			return nil
+		}
+		current = current.children[char]
+	}
+
+	return current
 }
 
 func (t *Trie) Insert(word string) {
-	if word == "" {
+	t.insert(word, 0, false)
+}
+
+func (t *Trie) InsertWithWeight(word string, weight float64) {
+	t.insert(word, weight, true)
+}
+
+func (t *Trie) insert(word string, weight float64, setWeight bool) {
+	key := t.normalize(word)
+	if key == "" {
 		return
 	}
 
-	word = strings.ToLower(word)
 	current := t.root
 
-	for _, char := range word {
+	for _, char := range key {
 		if current.children[char] == nil {
 			current.children[char] = NewTrieNode()
 		}
@@ -48,9 +160,20 @@ func (t *Trie) Insert(word string) {
 
 	if !current.isEnd {
 		current.isEnd = true
-		current.word = word
+		current.word = t.display(word)
 		t.size++
 	}
+	if setWeight {
+		current.weight = weight
+	}
+}
+
+func (t *Trie) Weight(word string) (float64, bool) {
+	node := t.find(t.normalize(word))
+	if node == nil || !node.isEnd {
+		return 0, false
+	}
+	return node.weight, true
 }
 
 func (t *Trie) Search(word string) bool {
@@ -58,17 +181,8 @@ func (t *Trie) Search(word string) bool {
 		return false
 	}
 
-	word = strings.ToLower(word)
-	current := t.root
-
-	for _, char := range word {
-		if current.children[char] == nil {
-			return false
-		}
-		current = current.children[char]
-	}
-
-	return current.isEnd
+	node := t.find(t.normalize(word))
+	return node != nil && node.isEnd
 }
 
 func (t *Trie) StartsWith(prefix string) bool {
@@ -76,28 +190,13 @@ func (t *Trie) StartsWith(prefix string) bool {
 		return true
 	}
 
-	prefix = strings.ToLower(prefix)
-	current := t.root
-
-	for _, char := range prefix {
-		if current.children[char] == nil {
-			return false
-		}
-		current = current.children[char]
-	}
-
-	return true
+	return t.find(t.normalize(prefix)) != nil
 }
 
 func (t *Trie) GetWordsWithPrefix(prefix string) []string {
-	prefix = strings.ToLower(prefix)
-	current := t.root
-
-	for _, char := range prefix {
-		if current.children[char] == nil {
-			return []string{}
-		}
-		current = current.children[char]
+	current := t.find(t.normalize(prefix))
+	if current == nil {
+		return []string{}
 	}
 
 	var words []string
@@ -116,20 +215,159 @@ func (t *Trie) collectWords(node *TrieNode, words *[]string) {
 	}
 }
 
+type Suggestion struct {
+	Word     string
+	Weight   float64
+	Distance int
+	Matched  int
+}
+
+func (s Suggestion) Highlight(open, close string) string {
+	return open + s.Word[:s.Matched] + close + s.Word[s.Matched:]
+}
+
+func byWeight(a, b Suggestion) bool {
+	if a.Weight != b.Weight {
+		return a.Weight < b.Weight
+	}
+	return a.Word > b.Word
+}
+
+func byDistanceThenWeight(a, b Suggestion) bool {
+	if a.Distance != b.Distance {
+		return a.Distance > b.Distance
+	}
+	return byWeight(a, b)
+}
+
+func (t *Trie) highlightLength(word string, keyRunes int) int {
+	count := 0
+	for i, r := range word {
+		if _, keep := t.options.normalizeRune(r); keep {
+			if count == keyRunes {
+				return i
+			}
+			count++
+		}
+	}
+	return len(word)
+}
+
+func (t *Trie) ranked(seq iter.Seq[Suggestion], k int, less func(a, b Suggestion) bool) []Suggestion {
+	suggestions := heap.TopK(seq, k, less)
+	for i := range suggestions {
+		suggestions[i].Matched = t.highlightLength(suggestions[i].Word, suggestions[i].Matched)
+	}
+	return suggestions
+}
+
+func (t *Trie) Suggest(prefix string, k int) []Suggestion {
+	key := t.normalize(prefix)
+	node := t.find(key)
+	if node == nil {
+		return []Suggestion{}
+	}
+
+	matched := len([]rune(key))
+	return t.ranked(func(yield func(Suggestion) bool) {
+		var visit func(node *TrieNode) bool
+		visit = func(node *TrieNode) bool {
+			if node.isEnd && !yield(Suggestion{Word: node.word, Weight: node.weight, Matched: matched}) {
+				return false
+			}
+			for _, child := range node.children {
+				if !visit(child) {
+					return false
+				}
+			}
+			return true
+		}
+		visit(node)
+	}, k, byWeight)
+}
+
 func (t *Trie) AutoComplete(prefix string, maxSuggestions int) []string {
-	words := t.GetWordsWithPrefix(prefix)
-	if len(words) > maxSuggestions {
-		return words[:maxSuggestions]
+	suggestions := t.Suggest(prefix, maxSuggestions)
+	words := make([]string, len(suggestions))
+	for i, suggestion := range suggestions {
+		words[i] = suggestion.Word
 	}
 	return words
 }
 
+func (t *Trie) FuzzySuggest(prefix string, maxDistance, k int) []Suggestion {
+	return t.ranked(t.fuzzy(prefix, maxDistance, true), k, byDistanceThenWeight)
+}
+
+func (t *Trie) FuzzySearch(word string, maxDistance, k int) []Suggestion {
+	return t.ranked(t.fuzzy(word, maxDistance, false), k, byDistanceThenWeight)
+}
+
+func (t *Trie) fuzzy(query string, maxDistance int, prefix bool) iter.Seq[Suggestion] {
+	target := []rune(t.normalize(query))
+
+	return func(yield func(Suggestion) bool) {
+		if maxDistance < 0 {
+			return
+		}
+
+		var visit func(node *TrieNode, row []int, depth, best, bestDepth int) bool
+		visit = func(node *TrieNode, row []int, depth, best, bestDepth int) bool {
+			distance := row[len(target)]
+			if distance <= best {
+				best, bestDepth = distance, depth
+			}
+
+			if node.isEnd {
+				matched, matchedDepth := distance, depth
+				if prefix {
+					matched, matchedDepth = best, bestDepth
+				}
+				if matched <= maxDistance && !yield(Suggestion{
+					Word:     node.word,
+					Weight:   node.weight,
+					Distance: matched,
+					Matched:  matchedDepth,
+				}) {
+					return false
+				}
+			}
+
+			if slices.Min(row) > maxDistance && !(prefix && best <= maxDistance) {
+				return true
+			}
+
+			for char, child := range node.children {
+				next := make([]int, len(row))
+				next[0] = row[0] + 1
+				for i := 1; i < len(row); i++ {
+					cost := 1
+					if target[i-1] == char {
+						cost = 0
+					}
+					next[i] = min(next[i-1]+1, row[i]+1, row[i-1]+cost)
+				}
+				if !visit(child, next, depth+1, best, bestDepth) {
+					return false
+				}
+			}
+			return true
+		}
+
+		row := make([]int, len(target)+1)
+		for i := range row {
+			row[i] = i
+		}
+		visit(t.root, row, 0, len(target)+1, 0)
+	}
+}
+
 func (t *Trie) Delete(word string) bool {
 	if word == "" {
 		return false
 	}
 
-	word = strings.ToLower(word)
+	word = t.normalize(word)
 
 	if !t.Search(word) {
 		return false
@@ -147,6 +385,7 @@ func (t *Trie) deleteHelper(node *TrieNode, runes []rune, index int) bool {
 		}
 		node.isEnd = false
 		node.word = ""
+		node.weight = 0
 		t.size--
 		return len(node.children) == 0
 	}
@@ -205,6 +444,611 @@ func (t *Trie) CountWordsWithPrefix(prefix string) int {
 	return len(t.GetWordsWithPrefix(prefix))
 }
 
+type MatchMode int
+
+const (
+	Overlapping MatchMode = iota
+	LeftmostLongest
+)
+
+type Match struct {
+	Pattern string
+	Start   int
+	End     int
+}
+
+type acState struct {
+	next   map[rune]int
+	fail   int
+	output int
+	link   int
+	depth  int
+}
+
+type AhoCorasick struct {
+	states   []acState
+	patterns []string
+	options  TrieOptions
+	maxDepth int
+}
+
+func NewAhoCorasick(patterns ...string) *AhoCorasick {
+	trie := NewTrieWithOptions(TrieOptions{CaseSensitive: true})
+	for _, pattern := range patterns {
+		trie.Insert(pattern)
+	}
+	return trie.AhoCorasick()
+}
+
+func (t *Trie) AhoCorasick() *AhoCorasick {
+	ac := &AhoCorasick{
+		states:  []acState{{output: -1, link: -1}},
+		options: t.options,
+	}
+
+	type pending struct {
+		state int
+		node  *TrieNode
+	}
+
+	queue := deque.NewQueue[pending]()
+	queue.Enqueue(pending{state: 0, node: t.root})
+
+	for !queue.IsEmpty() {
+		current, _ := queue.Dequeue()
+		if len(current.node.children) > 0 {
+			ac.states[current.state].next = make(map[rune]int, len(current.node.children))
+		}
+
+		for _, char := range slices.Sorted(maps.Keys(current.node.children)) {
+			child := current.node.children[char]
+			state := acState{
+				output: -1,
+				depth:  ac.states[current.state].depth + 1,
+			}
+
+			if current.state != 0 {
+				state.fail = ac.transition(ac.states[current.state].fail, char)
+			}
+			if fail := ac.states[state.fail]; fail.output >= 0 {
+				state.link = state.fail
+			} else {
+				state.link = fail.link
+			}
+			if child.isEnd {
+				state.output = len(ac.patterns)
+				ac.patterns = append(ac.patterns, child.word)
+			}
+
+			id := len(ac.states)
+			ac.states = append(ac.states, state)
+			ac.states[current.state].next[char] = id
+			ac.maxDepth = max(ac.maxDepth, state.depth)
+			queue.Enqueue(pending{state: id, node: child})
+		}
+	}
+
+	return ac
+}
+
+func (ac *AhoCorasick) transition(state int, char rune) int {
+	for {
+		if next, ok := ac.states[state].next[char]; ok {
+			return next
+		}
+		if state == 0 {
+			return 0
+		}
+		state = ac.states[state].fail
+	}
+}
+
+func (ac *AhoCorasick) firstOutput(state int) int {
+	if ac.states[state].output >= 0 {
+		return state
+	}
+	return ac.states[state].link
+}
+
+func (ac *AhoCorasick) Patterns() []string {
+	return slices.Clone(ac.patterns)
+}
+
+func leftmostLongest(matches []Match) int {
+	best := 0
+	for i, match := range matches {
+		if match.Start < matches[best].Start || (match.Start == matches[best].Start && match.End > matches[best].End) {
+			best = i
+		}
+	}
+	return best
+}
+
+func (ac *AhoCorasick) Scan(r io.Reader, mode MatchMode, visit func(Match) bool) error {
+	reader, ok := r.(io.RuneReader)
+	if !ok {
+		reader = bufio.NewReader(r)
+	}
+
+	var (
+		offsets  = make([]int, ac.maxDepth+1)
+		state    int
+		position int
+		offset   int
+		lastEnd  int
+		pending  []Match
+	)
+
+	startOf := func(depth int) int {
+		return offsets[(position-depth+1)%len(offsets)]
+	}
+
+	emit := func(final bool) bool {
+		if mode == Overlapping {
+			for _, match := range pending {
+				if !visit(match) {
+					return false
+				}
+			}
+			pending = pending[:0]
+			return true
+		}
+
+		for len(pending) > 0 {
+			best := pending[leftmostLongest(pending)]
+			if depth := ac.states[state].depth; !final && depth > 0 && startOf(depth) <= best.Start {
+				break
+			}
+			if !visit(best) {
+				return false
+			}
+			lastEnd = best.End
+			pending = slices.DeleteFunc(pending, func(match Match) bool {
+				return match.Start < lastEnd
+			})
+		}
+		return true
+	}
+
+	for {
+		char, size, err := reader.ReadRune()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		start := offset
+		offset += size
+
+		key, keep := ac.options.normalizeRune(char)
+		if !keep {
+			for i := range pending {
+				if pending[i].End == start {
+					pending[i].End = offset
+				}
+			}
+			continue
+		}
+
+		if mode == Overlapping && !emit(false) {
+			return nil
+		}
+
+		offsets[position%len(offsets)] = start
+		state = ac.transition(state, key)
+
+		for out := ac.firstOutput(state); out >= 0; out = ac.states[out].link {
+			match := Match{Pattern: ac.patterns[ac.states[out].output], Start: startOf(ac.states[out].depth), End: offset}
+			if match.Start >= lastEnd {
+				pending = append(pending, match)
+			}
+		}
+
+		if mode == LeftmostLongest && !emit(false) {
+			return nil
+		}
+
+		position++
+	}
+
+	emit(true)
+	return nil
+}
+
+func (ac *AhoCorasick) FindAll(text string, mode MatchMode) []Match {
+	matches := []Match{}
+	ac.Scan(strings.NewReader(text), mode, func(match Match) bool {
+		matches = append(matches, match)
+		return true
+	})
+	return matches
+}
+
+type radixNode[V any] struct {
+	prefix   string
+	children []*radixNode[V]
+	value    V
+	hasValue bool
+}
+
+func (n *radixNode[V]) childIndex(label byte) (int, bool) {
+	i := sort.Search(len(n.children), func(i int) bool {
+		return n.children[i].prefix[0] >= label
+	})
+	return i, i < len(n.children) && n.children[i].prefix[0] == label
+}
+
+func (n *radixNode[V]) child(label byte) *radixNode[V] {
+	if i, found := n.childIndex(label); found {
+		return n.children[i]
+	}
+	return nil
+}
+
+func (n *radixNode[V]) insertChild(i int, child *radixNode[V]) {
+	n.children = append(n.children, nil)
+	copy(n.children[i+1:], n.children[i:])
+	n.children[i] = child
+}
+
+func (n *radixNode[V]) removeChild(label byte) {
+	if i, found := n.childIndex(label); found {
+		n.children = append(n.children[:i], n.children[i+1:]...)
+	}
+}
+
+func (n *radixNode[V]) mergeChild() {
+	child := n.children[0]
+	n.prefix += child.prefix
+	n.children = child.children
+	n.value, n.hasValue = child.value, child.hasValue
+}
+
+type RadixTree[V any] struct {
+	root *radixNode[V]
+	size int
+}
+
+func NewRadixTree[V any]() *RadixTree[V] {
+	return &RadixTree[V]{root: &radixNode[V]{}}
+}
+
+func commonPrefixLength(a, b string) int {
+	n := min(len(a), len(b))
+	for i := range n {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+	return n
+}
+
+func (t *RadixTree[V]) Insert(key string, value V) bool {
+	node := t.root
+
+	for key != "" {
+		i, found := node.childIndex(key[0])
+		if !found {
+			node.insertChild(i, &radixNode[V]{prefix: key, value: value, hasValue: true})
+			t.size++
+			return true
+		}
+
+		child := node.children[i]
+		common := commonPrefixLength(key, child.prefix)
+		if common < len(child.prefix) {
+			split := &radixNode[V]{prefix: child.prefix[:common], children: []*radixNode[V]{child}}
+			child.prefix = child.prefix[common:]
+			node.children[i] = split
+			child = split
+		}
+
+		node = child
+		key = key[common:]
+	}
+
+	added := !node.hasValue
+	node.value, node.hasValue = value, true
+	if added {
+		t.size++
+	}
+	return added
+}
+
+func (t *RadixTree[V]) Get(key string) (V, bool) {
+	node := t.root
+
+	for key != "" {
+		child := node.child(key[0])
+		if child == nil || !strings.HasPrefix(key, child.prefix) {
+			var zero V
+			return zero, false
+		}
+		node = child
+		key = key[len(child.prefix):]
+	}
+
+	return node.value, node.hasValue
+}
+
+func (t *RadixTree[V]) Contains(key string) bool {
+	_, found := t.Get(key)
+	return found
+}
+
+func (t *RadixTree[V]) LongestPrefixOf(key string) (string, V, bool) {
+	var (
+		match    string
+		value    V
+		found    bool
+		consumed int
+	)
+
+	node := t.root
+	for {
+		if node.hasValue {
+			match, value, found = key[:consumed], node.value, true
+		}
+		if consumed == len(key) {
+			break
+		}
+
+		child := node.child(key[consumed])
+		if child == nil || !strings.HasPrefix(key[consumed:], child.prefix) {
+			break
+		}
+		node = child
+		consumed += len(child.prefix)
+	}
+
+	return match, value, found
+}
+
+func (t *RadixTree[V]) seek(prefix string) (parent, node *radixNode[V], path string) {
+	node = t.root
+	consumed := 0
+
+	for consumed < len(prefix) {
+		child := node.child(prefix[consumed])
+		if child == nil {
+			return nil, nil, ""
+		}
+
+		rest := prefix[consumed:]
+		if strings.HasPrefix(child.prefix, rest) {
+			return node, child, prefix[:consumed] + child.prefix
+		}
+		if !strings.HasPrefix(rest, child.prefix) {
+			return nil, nil, ""
+		}
+
+		parent, node = node, child
+		consumed += len(child.prefix)
+	}
+
+	return parent, node, prefix
+}
+
+func (t *RadixTree[V]) Delete(key string) bool {
+	var parent *radixNode[V]
+	node := t.root
+
+	for key != "" {
+		child := node.child(key[0])
+		if child == nil || !strings.HasPrefix(key, child.prefix) {
+			return false
+		}
+		parent, node = node, child
+		key = key[len(child.prefix):]
+	}
+
+	if !node.hasValue {
+		return false
+	}
+
+	var zero V
+	node.value, node.hasValue = zero, false
+	t.size--
+
+	if node == t.root {
+		return true
+	}
+
+	switch len(node.children) {
+	case 0:
+		parent.removeChild(node.prefix[0])
+		t.compact(parent)
+	case 1:
+		node.mergeChild()
+	}
+
+	return true
+}
+
+func (t *RadixTree[V]) compact(node *radixNode[V]) {
+	if node != t.root && !node.hasValue && len(node.children) == 1 {
+		node.mergeChild()
+	}
+}
+
+func (t *RadixTree[V]) DeletePrefix(prefix string) int {
+	parent, node, _ := t.seek(prefix)
+	if node == nil {
+		return 0
+	}
+
+	if node == t.root {
+		removed := t.size
+		t.Clear()
+		return removed
+	}
+
+	removed := 0
+	for range walkRadix(node, nil) {
+		removed++
+	}
+
+	parent.removeChild(node.prefix[0])
+	t.compact(parent)
+	t.size -= removed
+	return removed
+}
+
+func (t *RadixTree[V]) Clear() {
+	t.root = &radixNode[V]{}
+	t.size = 0
+}
+
+func walkRadix[V any](node *radixNode[V], key []byte) iter.Seq2[[]byte, *radixNode[V]] {
+	return func(yield func([]byte, *radixNode[V]) bool) {
+		var visit func(node *radixNode[V], key []byte) bool
+		visit = func(node *radixNode[V], key []byte) bool {
+			if node.hasValue && !yield(key, node) {
+				return false
+			}
+			for _, child := range node.children {
+				if !visit(child, append(key, child.prefix...)) {
+					return false
+				}
+			}
+			return true
+		}
+		visit(node, key)
+	}
+}
+
+func (t *RadixTree[V]) WalkPrefix(prefix string, visit func(key string, value V) bool) {
+	_, node, path := t.seek(prefix)
+	if node == nil {
+		return
+	}
+
+	for key, n := range walkRadix(node, []byte(path)) {
+		if !visit(string(key), n.value) {
+			return
+		}
+	}
+}
+
+func (t *RadixTree[V]) WithPrefix(prefix string) iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		t.WalkPrefix(prefix, yield)
+	}
+}
+
+func (t *RadixTree[V]) All() iter.Seq2[string, V] {
+	return t.WithPrefix("")
+}
+
+func (t *RadixTree[V]) Keys() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		t.WalkPrefix("", func(key string, _ V) bool {
+			return yield(key)
+		})
+	}
+}
+
+func (t *RadixTree[V]) Size() int {
+	return t.size
+}
+
+func (t *RadixTree[V]) IsEmpty() bool {
+	return t.size == 0
+}
+
+func IPPrefixKey(prefix netip.Prefix) string {
+	prefix = prefix.Masked()
+	return ipBits(prefix.Addr(), prefix.Bits())
+}
+
+func IPAddrKey(addr netip.Addr) string {
+	return ipBits(addr, addr.BitLen())
+}
+
+func ipBits(addr netip.Addr, bits int) string {
+	if bits < 0 {
+		return ""
+	}
+
+	var key strings.Builder
+	key.Grow(bits + 1)
+	if addr.Is4() {
+		key.WriteByte('4')
+	} else {
+		key.WriteByte('6')
+	}
+
+	raw := addr.AsSlice()
+	for i := range bits {
+		if raw[i/8]&(0x80>>(i%8)) != 0 {
+			key.WriteByte('1')
+		} else {
+			key.WriteByte('0')
+		}
+	}
+	return key.String()
+}
+
+type MemoryStats struct {
+	Keys       int
+	Nodes      int
+	Edges      int
+	LabelBytes int
+}
+
+func (t *Trie) MemoryStats() MemoryStats {
+	stats := MemoryStats{Keys: t.size}
+
+	var visit func(node *TrieNode)
+	visit = func(node *TrieNode) {
+		stats.Nodes++
+		for label, child := range node.children {
+			stats.Edges++
+			stats.LabelBytes += utf8.RuneLen(label)
+			visit(child)
+		}
+	}
+	visit(t.root)
+
+	return stats
+}
+
+func (t *RadixTree[V]) MemoryStats() MemoryStats {
+	stats := MemoryStats{Keys: t.size}
+
+	var visit func(node *radixNode[V])
+	visit = func(node *radixNode[V]) {
+		stats.Nodes++
+		for _, child := range node.children {
+			stats.Edges++
+			stats.LabelBytes += len(child.prefix)
+			visit(child)
+		}
+	}
+	visit(t.root)
+
+	return stats
+}
+
+type MemoryReport struct {
+	Trie  MemoryStats
+	Radix MemoryStats
+}
+
+func CompareMemory(words []string) MemoryReport {
+	trie := NewTrie()
+	radix := NewRadixTree[struct{}]()
+
+	for _, word := range words {
+		trie.Insert(word)
+		radix.Insert(strings.ToLower(word), struct{}{})
+	}
+
+	return MemoryReport{Trie: trie.MemoryStats(), Radix: radix.MemoryStats()}
+}
+
 func Run() any {
 	trie := NewTrie()
 
@@ -219,6 +1063,38 @@ func Run() any {
 		trie.Insert(word)
 	}
 
+	ranked := NewTrie()
+	for word, weight := range map[string]float64{
+		"care": 120, "career": 340, "careful": 95, "cargo": 60, "carbon": 210, "card": 180,
+	} {
+		ranked.InsertWithWeight(word, weight)
+	}
+	var rankedSuggestions, fuzzySuggestions []string
+	for _, suggestion := range ranked.Suggest("car", 3) {
+		rankedSuggestions = append(rankedSuggestions, suggestion.Highlight("[", "]"))
+	}
+	for _, suggestion := range ranked.FuzzySuggest("carr", 1, 3) {
+		fuzzySuggestions = append(fuzzySuggestions, suggestion.Highlight("[", "]"))
+	}
+
+	scrubber := NewAhoCorasick("he", "she", "his", "hers")
+	var overlapping, leftmost []string
+	for _, match := range scrubber.FindAll("ushers", Overlapping) {
+		overlapping = append(overlapping, match.Pattern)
+	}
+	for _, match := range scrubber.FindAll("ushers", LeftmostLongest) {
+		leftmost = append(leftmost, match.Pattern)
+	}
+
+	routes := NewRadixTree[string]()
+	routes.Insert("/", "index")
+	routes.Insert("/api/", "api")
+	routes.Insert("/api/users/", "users")
+	routes.Insert("/static/", "static")
+	route, handler, _ := routes.LongestPrefixOf("/api/users/42")
+
+	report := CompareMemory(words)
+
 	return map[string]any{
 		"total_words":           trie.Size(),
 		"search_app":            trie.Search("app"),
@@ -233,5 +1109,15 @@ func Run() any {
 		"longest_common_prefix": trie.LongestCommonPrefix(),
 		"count_app_words":       trie.CountWordsWithPrefix("app"),
 		"all_words_sample":      trie.GetAllWords()[:10],
+		"ranked_car":            rankedSuggestions,
+		"fuzzy_carr":            fuzzySuggestions,
+		"aho_corasick_all":      overlapping,
+		"aho_corasick_leftmost": leftmost,
+		"radix_route_match":     route,
+		"radix_route_handler":   handler,
+		"memory_trie_nodes":     report.Trie.Nodes,
+		"memory_trie_edges":     report.Trie.Edges,
+		"memory_radix_nodes":    report.Radix.Nodes,
+		"memory_radix_edges":    report.Radix.Edges,
 	}
 }
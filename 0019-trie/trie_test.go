@@ -1,8 +1,15 @@
 package trie
 
 import (
+	"fmt"
+	"maps"
+	"math/rand"
+	"net/netip"
 	"reflect"
+	"slices"
+	"strings"
 	"testing"
+	"testing/iotest"
 )
 
 func TestTrieInsertAndSearch(t *testing.T) {
@@ -284,6 +291,542 @@ func TestTrieGetAllWords(t *testing.T) {
 	}
 }
 
+func TestRadixTreeInsertGetDelete(t *testing.T) {
+	tree := NewRadixTree[int]()
+	keys := []string{"romane", "romanus", "romulus", "rubens", "ruber", "rubicon", "rubicundus", "rom"}
+
+	for i, key := range keys {
+		if !tree.Insert(key, i) {
+			t.Errorf("Insert(%q) reported an existing key", key)
+		}
+	}
+	if tree.Insert("rom", 100) {
+		t.Error("Insert of an existing key should return false")
+	}
+	if tree.Size() != len(keys) {
+		t.Errorf("Expected size %d, got %d", len(keys), tree.Size())
+	}
+
+	if value, ok := tree.Get("rom"); !ok || value != 100 {
+		t.Errorf("Get(rom) = %d, %v, expected 100, true", value, ok)
+	}
+	for _, missing := range []string{"", "r", "ro", "roman", "rubicons", "x"} {
+		if tree.Contains(missing) {
+			t.Errorf("Contains(%q) should be false", missing)
+		}
+	}
+
+	if !tree.Delete("romane") || tree.Delete("romane") || tree.Delete("roman") {
+		t.Error("Delete should remove a stored key exactly once and ignore inner nodes")
+	}
+	if !tree.Contains("romanus") || tree.Contains("romane") {
+		t.Error("Delete removed the wrong key")
+	}
+	if tree.Size() != len(keys)-1 {
+		t.Errorf("Expected size %d after delete, got %d", len(keys)-1, tree.Size())
+	}
+}
+
+func TestRadixTreeSortedIteration(t *testing.T) {
+	tree := NewRadixTree[int]()
+	keys := []string{"team", "test", "toast", "te", "tea", "t", "ten", "a", "zebra"}
+	for i, key := range keys {
+		tree.Insert(key, i)
+	}
+
+	expected := slices.Clone(keys)
+	slices.Sort(expected)
+	if got := slices.Collect(tree.Keys()); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Keys() = %v, expected %v", got, expected)
+	}
+
+	var withPrefix []string
+	for key := range tree.WithPrefix("te") {
+		withPrefix = append(withPrefix, key)
+	}
+	if expected := []string{"te", "tea", "team", "ten", "test"}; !reflect.DeepEqual(withPrefix, expected) {
+		t.Errorf("WithPrefix(te) = %v, expected %v", withPrefix, expected)
+	}
+
+	var walked []string
+	tree.WalkPrefix("tes", func(key string, _ int) bool {
+		walked = append(walked, key)
+		return true
+	})
+	if !reflect.DeepEqual(walked, []string{"test"}) {
+		t.Errorf("WalkPrefix(tes) = %v, expected [test]", walked)
+	}
+
+	count := 0
+	tree.WalkPrefix("", func(string, int) bool {
+		count++
+		return count < 3
+	})
+	if count != 3 {
+		t.Errorf("WalkPrefix should stop when visit returns false, visited %d", count)
+	}
+
+	for key := range tree.WithPrefix("x") {
+		t.Errorf("WithPrefix(x) yielded %q, expected nothing", key)
+	}
+}
+
+func TestRadixTreeLongestPrefixOf(t *testing.T) {
+	routes := NewRadixTree[string]()
+	routes.Insert("/", "index")
+	routes.Insert("/api/", "api")
+	routes.Insert("/api/users/", "users")
+	routes.Insert("/api/users/admin", "admin")
+
+	testCases := []struct {
+		path    string
+		match   string
+		handler string
+		found   bool
+	}{
+		{"/api/users/42", "/api/users/", "users", true},
+		{"/api/users/admin/settings", "/api/users/admin", "admin", true},
+		{"/api/user", "/api/", "api", true},
+		{"/about", "/", "index", true},
+		{"api", "", "", false},
+	}
+
+	for _, tc := range testCases {
+		match, handler, found := routes.LongestPrefixOf(tc.path)
+		if match != tc.match || handler != tc.handler || found != tc.found {
+			t.Errorf("LongestPrefixOf(%q) = %q, %q, %v, expected %q, %q, %v",
+				tc.path, match, handler, found, tc.match, tc.handler, tc.found)
+		}
+	}
+}
+
+func TestRadixTreeIPRouting(t *testing.T) {
+	table := NewRadixTree[string]()
+	for prefix, hop := range map[string]string{
+		"0.0.0.0/0":      "default",
+		"10.0.0.0/8":     "corp",
+		"10.1.0.0/16":    "lab",
+		"192.168.1.0/24": "home",
+		"2001:db8::/32":  "v6-doc",
+	} {
+		table.Insert(IPPrefixKey(netip.MustParsePrefix(prefix)), hop)
+	}
+
+	testCases := map[string]string{
+		"10.1.2.3":    "lab",
+		"10.200.0.1":  "corp",
+		"192.168.1.9": "home",
+		"8.8.8.8":     "default",
+		"2001:db8::1": "v6-doc",
+	}
+	for addr, expected := range testCases {
+		_, hop, _ := table.LongestPrefixOf(IPAddrKey(netip.MustParseAddr(addr)))
+		if hop != expected {
+			t.Errorf("route for %s = %q, expected %q", addr, hop, expected)
+		}
+	}
+
+	if _, _, found := table.LongestPrefixOf(IPAddrKey(netip.MustParseAddr("2002::1"))); found {
+		t.Error("IPv6 address should not match IPv4 routes")
+	}
+}
+
+func TestRadixTreeDeletePrefix(t *testing.T) {
+	tree := NewRadixTree[bool]()
+	for _, key := range []string{"car", "card", "care", "careful", "cat", "dog"} {
+		tree.Insert(key, true)
+	}
+
+	if removed := tree.DeletePrefix("care"); removed != 2 {
+		t.Errorf("DeletePrefix(care) removed %d, expected 2", removed)
+	}
+	if removed := tree.DeletePrefix("ca"); removed != 3 {
+		t.Errorf("DeletePrefix(ca) removed %d, expected 3", removed)
+	}
+	if removed := tree.DeletePrefix("x"); removed != 0 {
+		t.Errorf("DeletePrefix(x) removed %d, expected 0", removed)
+	}
+	if got := slices.Collect(tree.Keys()); !reflect.DeepEqual(got, []string{"dog"}) {
+		t.Errorf("Keys() after DeletePrefix = %v, expected [dog]", got)
+	}
+	if removed := tree.DeletePrefix(""); removed != 1 || !tree.IsEmpty() {
+		t.Errorf("DeletePrefix(\"\") removed %d, expected 1 and an empty tree", removed)
+	}
+}
+
+func TestRadixTreeMatchesMap(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	tree := NewRadixTree[int]()
+	reference := make(map[string]int)
+
+	randomKey := func() string {
+		var key strings.Builder
+		for range rng.Intn(6) {
+			key.WriteByte("abc"[rng.Intn(3)])
+		}
+		return key.String()
+	}
+
+	for step := range 5000 {
+		key := randomKey()
+		switch rng.Intn(4) {
+		case 0, 1:
+			_, exists := reference[key]
+			if tree.Insert(key, step) == exists {
+				t.Fatalf("Insert(%q) disagreed with map about existence", key)
+			}
+			reference[key] = step
+		case 2:
+			_, exists := reference[key]
+			if tree.Delete(key) != exists {
+				t.Fatalf("Delete(%q) disagreed with map", key)
+			}
+			delete(reference, key)
+		case 3:
+			removed := tree.DeletePrefix(key)
+			expected := 0
+			for k := range reference {
+				if strings.HasPrefix(k, key) {
+					delete(reference, k)
+					expected++
+				}
+			}
+			if removed != expected {
+				t.Fatalf("DeletePrefix(%q) removed %d, expected %d", key, removed, expected)
+			}
+		}
+
+		if tree.Size() != len(reference) {
+			t.Fatalf("size %d, expected %d", tree.Size(), len(reference))
+		}
+	}
+
+	if got := maps.Collect(tree.All()); !maps.Equal(got, reference) {
+		t.Errorf("All() = %v, expected %v", got, reference)
+	}
+	expectedKeys := slices.Sorted(maps.Keys(reference))
+	if got := slices.Collect(tree.Keys()); !reflect.DeepEqual(got, expectedKeys) {
+		t.Errorf("Keys() = %v, expected %v", got, expectedKeys)
+	}
+}
+
+func TestCompareMemory(t *testing.T) {
+	words := []string{"application", "applications", "applicable", "applied", "appliance", "apply"}
+	report := CompareMemory(words)
+
+	if report.Trie.Keys != len(words) || report.Radix.Keys != len(words) {
+		t.Errorf("Expected %d keys in both reports, got %+v", len(words), report)
+	}
+	if report.Radix.Nodes >= report.Trie.Nodes {
+		t.Errorf("Radix tree should use fewer nodes: %+v", report)
+	}
+	if report.Radix.Edges >= report.Trie.Edges {
+		t.Errorf("Radix tree should use fewer edges: %+v", report)
+	}
+	if report.Radix.LabelBytes != report.Trie.LabelBytes {
+		t.Errorf("Both structures should store the same label bytes: %+v", report)
+	}
+	if report.Trie.Edges != report.Trie.Nodes-1 || report.Radix.Edges != report.Radix.Nodes-1 {
+		t.Errorf("Every node but the root should have one incoming edge: %+v", report)
+	}
+}
+
+func TestTrieRankedSuggestions(t *testing.T) {
+	trie := NewTrie()
+	trie.InsertWithWeight("care", 120)
+	trie.InsertWithWeight("career", 340)
+	trie.InsertWithWeight("careful", 95)
+	trie.InsertWithWeight("card", 180)
+	trie.InsertWithWeight("cargo", 180)
+	trie.Insert("cat")
+
+	suggestions := trie.Suggest("car", 3)
+	var words []string
+	for _, suggestion := range suggestions {
+		words = append(words, suggestion.Word)
+	}
+	if expected := []string{"career", "card", "cargo"}; !reflect.DeepEqual(words, expected) {
+		t.Errorf("Suggest(car, 3) = %v, expected %v", words, expected)
+	}
+
+	if got := trie.AutoComplete("ca", 2); !reflect.DeepEqual(got, []string{"career", "card"}) {
+		t.Errorf("AutoComplete(ca, 2) = %v, expected [career card]", got)
+	}
+
+	trie.InsertWithWeight("cat", 1000)
+	trie.Insert("cat")
+	if weight, ok := trie.Weight("cat"); !ok || weight != 1000 {
+		t.Errorf("Weight(cat) = %v, %v, expected 1000, true", weight, ok)
+	}
+	if got := trie.AutoComplete("c", 1); !reflect.DeepEqual(got, []string{"cat"}) {
+		t.Errorf("AutoComplete(c, 1) = %v, expected [cat]", got)
+	}
+
+	if got := trie.Suggest("car", 1)[0].Highlight("<b>", "</b>"); got != "<b>car</b>eer" {
+		t.Errorf("Highlight = %q, expected <b>car</b>eer", got)
+	}
+	if got := trie.Suggest("xyz", 3); len(got) != 0 {
+		t.Errorf("Suggest(xyz) = %v, expected none", got)
+	}
+}
+
+func TestTrieFuzzySearch(t *testing.T) {
+	trie := NewTrie()
+	for word, weight := range map[string]float64{
+		"hello": 10, "help": 30, "hold": 5, "world": 20, "word": 25,
+	} {
+		trie.InsertWithWeight(word, weight)
+	}
+
+	testCases := []struct {
+		query    string
+		distance int
+		expected []string
+	}{
+		{"helo", 1, []string{"help", "hello"}},
+		{"wrld", 1, []string{"world"}},
+		{"word", 1, []string{"word", "world"}},
+		{"hxld", 1, []string{"hold"}},
+		{"xyz", 2, nil},
+	}
+
+	for _, tc := range testCases {
+		var words []string
+		for _, suggestion := range trie.FuzzySearch(tc.query, tc.distance, 10) {
+			words = append(words, suggestion.Word)
+		}
+		if !reflect.DeepEqual(words, tc.expected) {
+			t.Errorf("FuzzySearch(%q, %d) = %v, expected %v", tc.query, tc.distance, words, tc.expected)
+		}
+	}
+}
+
+func TestTrieFuzzySuggest(t *testing.T) {
+	trie := NewTrie()
+	trie.InsertWithWeight("apple", 50)
+	trie.InsertWithWeight("application", 80)
+	trie.InsertWithWeight("apply", 10)
+	trie.InsertWithWeight("banana", 100)
+
+	suggestions := trie.FuzzySuggest("apl", 1, 10)
+	var words []string
+	for _, suggestion := range suggestions {
+		if suggestion.Distance != 1 {
+			t.Errorf("%s: expected distance 1, got %d", suggestion.Word, suggestion.Distance)
+		}
+		words = append(words, suggestion.Word)
+	}
+	if expected := []string{"application", "apple", "apply"}; !reflect.DeepEqual(words, expected) {
+		t.Errorf("FuzzySuggest(apl, 1) = %v, expected %v", words, expected)
+	}
+	if got := suggestions[1].Highlight("[", "]"); got != "[appl]e" {
+		t.Errorf("Highlight = %q, expected [appl]e", got)
+	}
+
+	exact := trie.FuzzySuggest("app", 1, 1)
+	if len(exact) != 1 || exact[0].Word != "application" || exact[0].Distance != 0 {
+		t.Errorf("FuzzySuggest(app, 1, 1) = %+v, expected application at distance 0", exact)
+	}
+	if got := trie.FuzzySuggest("zzzz", 1, 5); len(got) != 0 {
+		t.Errorf("FuzzySuggest(zzzz) = %v, expected none", got)
+	}
+}
+
+func TestTrieNormalizationOptions(t *testing.T) {
+	sensitive := NewTrieWithOptions(TrieOptions{CaseSensitive: true})
+	sensitive.Insert("Go")
+	sensitive.Insert("go")
+	if sensitive.Size() != 2 || !sensitive.Search("Go") || sensitive.Search("GO") {
+		t.Error("Case-sensitive trie should keep Go and go apart")
+	}
+
+	folded := NewTrieWithOptions(TrieOptions{FoldDiacritics: true})
+	folded.InsertWithWeight("Café", 5)
+	folded.InsertWithWeight("Crème brûlée", 3)
+	folded.Insert("cafe\u0301")
+
+	if folded.Size() != 2 {
+		t.Errorf("Expected decomposed and precomposed spellings to collapse, size %d", folded.Size())
+	}
+	for _, query := range []string{"cafe", "CAFÉ", "café"} {
+		if !folded.Search(query) {
+			t.Errorf("Search(%q) should find café", query)
+		}
+	}
+	if got := folded.AutoComplete("creme b", 5); !reflect.DeepEqual(got, []string{"crème brûlée"}) {
+		t.Errorf("AutoComplete(creme b) = %v, expected [crème brûlée]", got)
+	}
+	if got := folded.Suggest("cre", 1)[0].Highlight("[", "]"); got != "[crè]me brûlée" {
+		t.Errorf("Highlight = %q, expected [crè]me brûlée", got)
+	}
+
+	plain := NewTrie()
+	plain.Insert("Café")
+	if plain.Search("cafe") || !plain.Search("CAFÉ") {
+		t.Error("Default trie should fold case but keep diacritics")
+	}
+}
+
+func bruteForceMatches(patterns []string, text string, mode MatchMode) []Match {
+	var all []Match
+	for end := 1; end <= len(text); end++ {
+		for start := end - 1; start >= 0; start-- {
+			if slices.Contains(patterns, text[start:end]) {
+				all = append(all, Match{Pattern: text[start:end], Start: start, End: end})
+			}
+		}
+	}
+	if mode == Overlapping {
+		return all
+	}
+
+	var result []Match
+	lastEnd := 0
+	for {
+		best := -1
+		for i, match := range all {
+			if match.Start < lastEnd {
+				continue
+			}
+			if best < 0 || match.Start < all[best].Start ||
+				(match.Start == all[best].Start && match.End > all[best].End) {
+				best = i
+			}
+		}
+		if best < 0 {
+			return result
+		}
+		result = append(result, all[best])
+		lastEnd = all[best].End
+	}
+}
+
+func TestAhoCorasickOverlapping(t *testing.T) {
+	ac := NewAhoCorasick("he", "she", "his", "hers")
+	expected := []Match{
+		{Pattern: "she", Start: 1, End: 4},
+		{Pattern: "he", Start: 2, End: 4},
+		{Pattern: "hers", Start: 2, End: 6},
+	}
+
+	if got := ac.FindAll("ushers", Overlapping); !reflect.DeepEqual(got, expected) {
+		t.Errorf("FindAll(ushers) = %v, expected %v", got, expected)
+	}
+	if got := ac.FindAll("nothing here?", Overlapping); len(got) != 1 || got[0].Pattern != "he" {
+		t.Errorf("FindAll(nothing here?) = %v, expected a single he", got)
+	}
+}
+
+func TestAhoCorasickLeftmostLongest(t *testing.T) {
+	testCases := []struct {
+		patterns []string
+		text     string
+		expected []string
+	}{
+		{[]string{"he", "she", "his", "hers"}, "ushers", []string{"she"}},
+		{[]string{"abcd", "bc", "cdef"}, "abcdef", []string{"abcd"}},
+		{[]string{"bcd", "abcdef"}, "abcdefg", []string{"abcdef"}},
+		{[]string{"bcd", "abcdef"}, "abcdebcd", []string{"bcd", "bcd"}},
+		{[]string{"a", "ab", "abc"}, "abcabab", []string{"abc", "ab", "ab"}},
+	}
+
+	for _, tc := range testCases {
+		var got []string
+		for _, match := range NewAhoCorasick(tc.patterns...).FindAll(tc.text, LeftmostLongest) {
+			got = append(got, match.Pattern)
+		}
+		if !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("LeftmostLongest(%v, %q) = %v, expected %v", tc.patterns, tc.text, got, tc.expected)
+		}
+	}
+}
+
+func TestAhoCorasickMatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(11))
+	randomString := func(n int) string {
+		var b strings.Builder
+		for range n {
+			b.WriteByte("abc"[rng.Intn(3)])
+		}
+		return b.String()
+	}
+
+	for range 300 {
+		patterns := make([]string, 1+rng.Intn(6))
+		for i := range patterns {
+			patterns[i] = randomString(1 + rng.Intn(4))
+		}
+		text := randomString(rng.Intn(30))
+		ac := NewAhoCorasick(patterns...)
+
+		for _, mode := range []MatchMode{Overlapping, LeftmostLongest} {
+			got := ac.FindAll(text, mode)
+			expected := bruteForceMatches(patterns, text, mode)
+			slices.SortFunc(got, func(a, b Match) int { return a.End*100 + a.Start - b.End*100 - b.Start })
+			slices.SortFunc(expected, func(a, b Match) int { return a.End*100 + a.Start - b.End*100 - b.Start })
+			if len(got) != len(expected) || (len(got) > 0 && !reflect.DeepEqual(got, expected)) {
+				t.Fatalf("mode %d patterns %v text %q: got %v, expected %v", mode, patterns, text, got, expected)
+			}
+		}
+	}
+}
+
+func TestAhoCorasickScanReader(t *testing.T) {
+	ac := NewAhoCorasick("AKIA", "password=", "secret")
+	text := "user=bob password=hunter2 token=AKIAXYZ secret"
+
+	var fromReader []Match
+	err := ac.Scan(iotest.OneByteReader(strings.NewReader(text)), Overlapping, func(match Match) bool {
+		fromReader = append(fromReader, match)
+		return true
+	})
+	if err != nil {
+		t.Fatalf("Scan returned error: %v", err)
+	}
+	if !reflect.DeepEqual(fromReader, ac.FindAll(text, Overlapping)) {
+		t.Errorf("Scan over a reader = %v, expected %v", fromReader, ac.FindAll(text, Overlapping))
+	}
+	for _, match := range fromReader {
+		if text[match.Start:match.End] != match.Pattern {
+			t.Errorf("Match %+v does not point at its pattern", match)
+		}
+	}
+
+	count := 0
+	ac.Scan(strings.NewReader(text), Overlapping, func(Match) bool {
+		count++
+		return false
+	})
+	if count != 1 {
+		t.Errorf("Scan should stop when visit returns false, visited %d", count)
+	}
+
+	failing := iotest.ErrReader(iotest.ErrTimeout)
+	if err := ac.Scan(failing, Overlapping, func(Match) bool { return true }); err != iotest.ErrTimeout {
+		t.Errorf("Scan error = %v, expected %v", err, iotest.ErrTimeout)
+	}
+}
+
+func TestAhoCorasickFromTrie(t *testing.T) {
+	trie := NewTrieWithOptions(TrieOptions{FoldDiacritics: true})
+	trie.Insert("Café")
+	trie.Insert("naïve")
+	ac := trie.AhoCorasick()
+
+	text := "A NAIVE order at the cafe\u0301 and a café"
+	matches := ac.FindAll(text, LeftmostLongest)
+	var found []string
+	for _, match := range matches {
+		found = append(found, text[match.Start:match.End])
+	}
+	if expected := []string{"NAIVE", "cafe\u0301", "café"}; !reflect.DeepEqual(found, expected) {
+		t.Errorf("matched text %q, expected %q", found, expected)
+	}
+	if matches[0].Pattern != "naïve" {
+		t.Errorf("Pattern = %q, expected the inserted spelling naïve", matches[0].Pattern)
+	}
+}
+
 func TestRun(t *testing.T) {
 	result := Run()
 	resultMap, ok := result.(map[string]any)
@@ -351,3 +894,39 @@ func BenchmarkTrieAutoComplete(b *testing.B) {
 		trie.AutoComplete("app", 5)
 	}
 }
+
+func BenchmarkRadixTreeLongestPrefixOf(b *testing.B) {
+	routes := NewRadixTree[int]()
+	for i, route := range []string{"/", "/api/", "/api/users/", "/api/orders/", "/static/", "/static/css/"} {
+		routes.Insert(route, i)
+	}
+
+	for b.Loop() {
+		routes.LongestPrefixOf("/api/users/42/profile")
+	}
+}
+
+func BenchmarkTrieFuzzySuggest(b *testing.B) {
+	trie := NewTrie()
+	words := []string{"apple", "application", "apply", "appreciate", "approach", "banana", "band", "bandana"}
+	for i, word := range words {
+		trie.InsertWithWeight(word, float64(i))
+	}
+
+	for b.Loop() {
+		trie.FuzzySuggest("aple", 2, 5)
+	}
+}
+
+func BenchmarkAhoCorasick(b *testing.B) {
+	patterns := make([]string, 1000)
+	for i := range patterns {
+		patterns[i] = fmt.Sprintf("secret-%04d", i*7)
+	}
+	ac := NewAhoCorasick(patterns...)
+	line := strings.Repeat("user=alice action=login token=secret-0693 ip=10.0.0.1 ", 20)
+
+	for b.Loop() {
+		ac.FindAll(line, LeftmostLongest)
+	}
+}
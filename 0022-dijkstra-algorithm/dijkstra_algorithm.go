@@ -1,8 +1,9 @@
 package dijkstra_algorithm
 
 import (
-	"container/heap"
 	"math"
+
+	"github.com/celj/dsa/0018-heap"
 )
 
 type Edge struct {
@@ -18,38 +19,6 @@ type Graph struct {
 type Item struct {
 	Vertex   int
 	Distance int
-	Index    int
-}
-
-type PriorityQueue []*Item
-
-func (pq PriorityQueue) Len() int { return len(pq) }
-
-func (pq PriorityQueue) Less(i, j int) bool {
-	return pq[i].Distance < pq[j].Distance
-}
-
-func (pq PriorityQueue) Swap(i, j int) {
-	pq[i], pq[j] = pq[j], pq[i]
-	pq[i].Index = i
-	pq[j].Index = j
-}
-
-func (pq *PriorityQueue) Push(x any) {
-	n := len(*pq)
-	item := x.(*Item)
-	item.Index = n
-	*pq = append(*pq, item)
-}
-
-func (pq *PriorityQueue) Pop() any {
-	old := *pq
-	n := len(old)
-	item := old[n-1]
-	old[n-1] = nil
-	item.Index = -1
-	*pq = old[0 : n-1]
-	return item
 }
 
 type DijkstraResult struct {
@@ -76,7 +45,15 @@ func (g *Graph) AddBidirectionalEdge(u, v, weight int) {
 	g.AddEdge(v, u, weight)
 }
 
+func ByDistance(a, b Item) bool {
+	return a.Distance < b.Distance
+}
+
 func (g *Graph) Dijkstra(source int) *DijkstraResult {
+	return g.DijkstraWithQueue(source, heap.NewDaryHeap(2, ByDistance))
+}
+
+func (g *Graph) DijkstraWithQueue(source int, pq heap.PriorityQueue[Item]) *DijkstraResult {
 	if source < 0 || source >= g.Vertices {
 		return nil
 	}
@@ -92,17 +69,11 @@ func (g *Graph) Dijkstra(source int) *DijkstraResult {
 
 	distances[source] = 0
 
-	pq := make(PriorityQueue, 0)
-	heap.Init(&pq)
-	heap.Push(&pq, &Item{Vertex: source, Distance: 0})
-
-	for pq.Len() > 0 {
-		current := heap.Pop(&pq).(*Item)
-
-		if visited[current.Vertex] {
-			continue
-		}
+	handles := make([]heap.Handle[Item], g.Vertices)
+	handles[source] = pq.Push(Item{Vertex: source, Distance: 0})
 
+	for !pq.IsEmpty() {
+		current, _ := pq.Pop()
 		visited[current.Vertex] = true
 
 		for _, edge := range g.AdjList[current.Vertex] {
@@ -115,7 +86,12 @@ func (g *Graph) Dijkstra(source int) *DijkstraResult {
 			if newDistance < distances[edge.To] {
 				distances[edge.To] = newDistance
 				previous[edge.To] = current.Vertex
-				heap.Push(&pq, &Item{Vertex: edge.To, Distance: newDistance})
+				next := Item{Vertex: edge.To, Distance: newDistance}
+				if handles[edge.To] == nil {
+					handles[edge.To] = pq.Push(next)
+				} else {
+					pq.DecreaseKey(handles[edge.To], next)
+				}
 			}
 		}
 	}
@@ -1,9 +1,13 @@
 package dijkstra_algorithm
 
 import (
+	"fmt"
 	"math"
+	"math/rand"
 	"reflect"
 	"testing"
+
+	"github.com/celj/dsa/0018-heap"
 )
 
 func TestNewGraph(t *testing.T) {
@@ -243,6 +247,50 @@ func TestEdgeCases(t *testing.T) {
 	}
 }
 
+func heapFactories() []struct {
+	name     string
+	newQueue func() heap.PriorityQueue[Item]
+} {
+	return []struct {
+		name     string
+		newQueue func() heap.PriorityQueue[Item]
+	}{
+		{"binary", func() heap.PriorityQueue[Item] { return heap.NewDaryHeap(2, ByDistance) }},
+		{"4-ary", func() heap.PriorityQueue[Item] { return heap.NewDaryHeap(4, ByDistance) }},
+		{"8-ary", func() heap.PriorityQueue[Item] { return heap.NewDaryHeap(8, ByDistance) }},
+		{"binomial", func() heap.PriorityQueue[Item] { return heap.NewBinomialHeap(ByDistance) }},
+		{"fibonacci", func() heap.PriorityQueue[Item] { return heap.NewFibonacciHeap(ByDistance) }},
+		{"pairing", func() heap.PriorityQueue[Item] { return heap.NewPairingHeap(ByDistance) }},
+	}
+}
+
+func randomDenseGraph(vertices int, density float64, seed int64) *Graph {
+	rng := rand.New(rand.NewSource(seed))
+	g := NewGraph(vertices)
+	for i := range vertices {
+		for j := range vertices {
+			if i != j && rng.Float64() < density {
+				g.AddEdge(i, j, 1+rng.Intn(100))
+			}
+		}
+	}
+	return g
+}
+
+func TestDijkstraWithEveryHeap(t *testing.T) {
+	g := randomDenseGraph(120, 0.3, 11)
+	expected := g.Dijkstra(0)
+
+	for _, factory := range heapFactories() {
+		t.Run(factory.name, func(t *testing.T) {
+			result := g.DijkstraWithQueue(0, factory.newQueue())
+			if !reflect.DeepEqual(result.Distances, expected.Distances) {
+				t.Errorf("Distances differ from binary heap result")
+			}
+		})
+	}
+}
+
 func TestRun(t *testing.T) {
 	result := Run()
 	if result == nil {
@@ -322,6 +370,19 @@ func BenchmarkDijkstraDenseGraph(b *testing.B) {
 	}
 }
 
+func BenchmarkDijkstraHeaps(b *testing.B) {
+	for _, size := range []int{200, 1000} {
+		g := randomDenseGraph(size, 0.5, 7)
+		for _, factory := range heapFactories() {
+			b.Run(fmt.Sprintf("%s/%d", factory.name, size), func(b *testing.B) {
+				for b.Loop() {
+					g.DijkstraWithQueue(0, factory.newQueue())
+				}
+			})
+		}
+	}
+}
+
 func BenchmarkGetPath(b *testing.B) {
 	g := NewGraph(100)
 	for i := range 99 {
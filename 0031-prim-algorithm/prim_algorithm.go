@@ -1,10 +1,11 @@
 package prim_algorithm
 
 import (
-	"container/heap"
 	"errors"
 	"fmt"
 	"math"
+
+	"github.com/celj/dsa/0018-heap"
 )
 
 type Edge struct {
@@ -19,30 +20,6 @@ type Graph struct {
 	edges    []Edge
 }
 
-type PriorityQueue []*Edge
-
-func (pq PriorityQueue) Len() int { return len(pq) }
-
-func (pq PriorityQueue) Less(i, j int) bool {
-	return pq[i].Weight < pq[j].Weight
-}
-
-func (pq PriorityQueue) Swap(i, j int) {
-	pq[i], pq[j] = pq[j], pq[i]
-}
-
-func (pq *PriorityQueue) Push(x any) {
-	*pq = append(*pq, x.(*Edge))
-}
-
-func (pq *PriorityQueue) Pop() any {
-	old := *pq
-	n := len(old)
-	item := old[n-1]
-	*pq = old[0 : n-1]
-	return item
-}
-
 type MST struct {
 	edges      []Edge
 	totalCost  float64
@@ -132,30 +109,34 @@ func (g *Graph) PrimMST() (*MST, error) {
 	mstEdges := []Edge{}
 	totalCost := 0.0
 
-	pq := &PriorityQueue{}
-	heap.Init(pq)
+	pq := heap.NewIndexedHeap(func(a, b Edge) bool { return a.Weight < b.Weight })
+	entries := make([]*heap.Entry[Edge], g.vertices)
 
-	visited[0] = true
-	for _, edge := range g.adjList[0] {
-		heap.Push(pq, &edge)
+	relax := func(vertex int) {
+		for _, edge := range g.adjList[vertex] {
+			if visited[edge.To] {
+				continue
+			}
+			entry := entries[edge.To]
+			if !pq.Contains(entry) {
+				entries[edge.To] = pq.Push(edge)
+			} else if edge.Weight < entry.Value.Weight {
+				pq.Update(entry, edge)
+			}
+		}
 	}
 
-	for pq.Len() > 0 && len(mstEdges) < g.vertices-1 {
-		edge := heap.Pop(pq).(*Edge)
+	visited[0] = true
+	relax(0)
 
-		if visited[edge.To] {
-			continue
-		}
+	for !pq.IsEmpty() && len(mstEdges) < g.vertices-1 {
+		edge, _ := pq.Pop()
 
 		visited[edge.To] = true
-		mstEdges = append(mstEdges, *edge)
+		mstEdges = append(mstEdges, edge)
 		totalCost += edge.Weight
 
-		for _, nextEdge := range g.adjList[edge.To] {
-			if !visited[nextEdge.To] {
-				heap.Push(pq, &nextEdge)
-			}
-		}
+		relax(edge.To)
 	}
 
 	isComplete := len(mstEdges) == g.vertices-1
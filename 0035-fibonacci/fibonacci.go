@@ -1,17 +1,31 @@
 package fibonacci
 
+import "github.com/celj/dsa/0058-dynamic-programming"
+
 func Run() any {
 	n := 10
 	iterative := FibonacciIterative(n)
 	recursive := FibonacciRecursive(n)
+	memoized := FibonacciMemoized(n)
+	tabulated := FibonacciTabulated(n)
 
 	return map[string]any{
 		"n":         n,
 		"iterative": iterative,
 		"recursive": recursive,
+		"memoized":  memoized,
+		"tabulated": tabulated,
 	}
 }
 
+var fibonacciProblem = dynamic_programming.Problem[int, int]{
+	Goal: dynamic_programming.Sum,
+	Base: func(n int) (int, bool) { return n, n <= 1 },
+	Choices: func(n int) []dynamic_programming.Choice[int, int] {
+		return []dynamic_programming.Choice[int, int]{{Next: n - 1}, {Next: n - 2}}
+	},
+}
+
 func FibonacciIterative(n int) int {
 	if n <= 1 {
 		return n
@@ -25,6 +39,29 @@ func FibonacciIterative(n int) int {
 	return current
 }
 
+func FibonacciTabulated(n int) int {
+	if n <= 1 {
+		return n
+	}
+
+	table := dynamic_programming.Table[int]{
+		Stages: n - 1,
+		States: func(stage int) []int { return []int{stage + 2} },
+		Window: 3,
+	}
+	sol, _ := dynamic_programming.Tabulate(fibonacciProblem, table, n)
+	return sol.Value
+}
+
+func FibonacciMemoized(n int) int {
+	if n <= 1 {
+		return n
+	}
+
+	sol, _ := dynamic_programming.Memoize(fibonacciProblem, n)
+	return sol.Value
+}
+
 func FibonacciRecursive(n int) int {
 	if n <= 1 {
 		return n
@@ -62,3 +62,18 @@ func TestFibonacciRecursive(t *testing.T) {
 		}
 	}
 }
+
+func TestFibonacciMemoizedAndTabulated(t *testing.T) {
+	for n := range 91 {
+		want := FibonacciIterative(n)
+		if got := FibonacciMemoized(n); got != want {
+			t.Errorf("For n=%d, expected %d from memoization, got %d", n, want, got)
+		}
+		if got := FibonacciTabulated(n); got != want {
+			t.Errorf("For n=%d, expected %d from tabulation, got %d", n, want, got)
+		}
+	}
+	if got := FibonacciIterative(90); got != 2880067194370816120 {
+		t.Errorf("For n=90, expected 2880067194370816120, got %d", got)
+	}
+}
@@ -1,5 +1,7 @@
 package max_subarray
 
+import "github.com/celj/dsa/0058-dynamic-programming"
+
 type Result struct {
 	MaxSum   int
 	Subarray []int
@@ -44,6 +46,87 @@ func maxSubArray(nums []int) Result {
 	}
 }
 
+type phase int
+
+const (
+	before phase = iota
+	inside
+	after
+)
+
+type position struct {
+	Index int
+	Phase phase
+}
+
+func maxSubArrayProblem(nums []int) dynamic_programming.Problem[position, int] {
+	take := func(i int) func(int) int {
+		return func(sum int) int { return sum + nums[i] }
+	}
+
+	return dynamic_programming.Problem[position, int]{
+		Goal: dynamic_programming.Maximize,
+		Base: func(p position) (int, bool) {
+			return 0, p.Phase == before
+		},
+		Choices: func(p position) []dynamic_programming.Choice[position, int] {
+			i := p.Index
+			switch {
+			case p.Phase == inside && i == 0:
+				return []dynamic_programming.Choice[position, int]{{Next: position{i, before}}}
+			case p.Phase == inside:
+				return []dynamic_programming.Choice[position, int]{
+					{Next: position{i - 1, inside}, Value: take(i - 1)},
+					{Next: position{i, before}},
+				}
+			case i == 0:
+				return nil
+			default:
+				return []dynamic_programming.Choice[position, int]{
+					{Next: position{i - 1, after}},
+					{Next: position{i - 1, inside}, Value: take(i - 1)},
+				}
+			}
+		},
+	}
+}
+
+func maxSubArrayTable(nums []int) dynamic_programming.Table[position] {
+	return dynamic_programming.Table[position]{
+		Stages: len(nums) + 1,
+		States: func(i int) []position { return []position{{i, inside}, {i, after}} },
+		Window: 2,
+	}
+}
+
+func maxSubArrayDP(nums []int) Result {
+	if len(nums) == 0 {
+		return Result{MaxSum: 0, Subarray: []int{}, StartIdx: 0, EndIdx: 0}
+	}
+
+	sol, err := dynamic_programming.Tabulate(maxSubArrayProblem(nums), maxSubArrayTable(nums), position{len(nums), after})
+	if err != nil {
+		return Result{MaxSum: 0, Subarray: []int{}, StartIdx: 0, EndIdx: 0}
+	}
+
+	start, end := len(nums), -1
+	for _, p := range sol.Path[1:] {
+		if p.Phase == inside {
+			start, end = min(start, p.Index), max(end, p.Index)
+		}
+	}
+
+	subarray := make([]int, end-start+1)
+	copy(subarray, nums[start:end+1])
+
+	return Result{
+		MaxSum:   sol.Value,
+		Subarray: subarray,
+		StartIdx: start,
+		EndIdx:   end,
+	}
+}
+
 func Run() any {
 	testCases := [][]int{
 		{-2, 1, -3, 4, -1, 2, 1, -5, 4},
@@ -1,6 +1,12 @@
 package max_subarray
 
-import "testing"
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/celj/dsa/0058-dynamic-programming"
+)
 
 func TestMaxSubArray(t *testing.T) {
 	testCases := []struct {
@@ -31,6 +37,47 @@ func TestMaxSubArray(t *testing.T) {
 	}
 }
 
+func TestMaxSubArrayMatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for trial := range 300 {
+		nums := make([]int, 1+rng.Intn(15))
+		for i := range nums {
+			nums[i] = rng.Intn(21) - 10
+		}
+
+		want := nums[0]
+		for i := range nums {
+			sum := 0
+			for j := i; j < len(nums); j++ {
+				sum += nums[j]
+				want = max(want, sum)
+			}
+		}
+
+		result := maxSubArray(nums)
+		if result.MaxSum != want {
+			t.Fatalf("trial %d: expected %d for %v, got %d", trial, want, nums, result.MaxSum)
+		}
+		if !slices.Equal(result.Subarray, nums[result.StartIdx:result.EndIdx+1]) {
+			t.Fatalf("trial %d: subarray %v does not match indices %d..%d of %v", trial, result.Subarray, result.StartIdx, result.EndIdx, nums)
+		}
+
+		framework := maxSubArrayDP(nums)
+		if framework.MaxSum != want {
+			t.Fatalf("trial %d: expected the tabulated variant to agree on %d for %v, got %d", trial, want, nums, framework.MaxSum)
+		}
+		if !slices.Equal(framework.Subarray, nums[framework.StartIdx:framework.EndIdx+1]) {
+			t.Fatalf("trial %d: tabulated subarray %v does not match indices %d..%d of %v", trial, framework.Subarray, framework.StartIdx, framework.EndIdx, nums)
+		}
+
+		memo, err := dynamic_programming.Memoize(maxSubArrayProblem(nums), position{len(nums), after})
+		if err != nil || memo.Value != want {
+			t.Fatalf("trial %d: expected memoization to agree on %d, got %d (%v)", trial, want, memo.Value, err)
+		}
+	}
+}
+
 func TestRun(t *testing.T) {
 	result := Run()
 	if result == nil {
@@ -71,3 +118,11 @@ func BenchmarkMaxSubArray(b *testing.B) {
 		maxSubArray(nums)
 	}
 }
+
+func BenchmarkMaxSubArrayDP(b *testing.B) {
+	nums := []int{-2, 1, -3, 4, -1, 2, 1, -5, 4}
+
+	for b.Loop() {
+		maxSubArrayDP(nums)
+	}
+}
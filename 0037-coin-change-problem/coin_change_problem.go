@@ -1,6 +1,10 @@
 package coin_change_problem
 
-import "math"
+import (
+	"math"
+
+	"github.com/celj/dsa/0058-dynamic-programming"
+)
 
 type Result struct {
 	Coins  []int
@@ -8,6 +12,50 @@ type Result struct {
 	Result int
 }
 
+func coinChangeProblem(coins []int) dynamic_programming.Problem[int, int] {
+	return dynamic_programming.Problem[int, int]{
+		Goal: dynamic_programming.Minimize,
+		Base: func(amount int) (int, bool) { return 0, amount == 0 },
+		Choices: func(amount int) []dynamic_programming.Choice[int, int] {
+			var choices []dynamic_programming.Choice[int, int]
+			for _, coin := range coins {
+				if coin > 0 && coin <= amount {
+					choices = append(choices, dynamic_programming.Choice[int, int]{
+						Next:  amount - coin,
+						Value: func(n int) int { return n + 1 },
+					})
+				}
+			}
+			return choices
+		},
+	}
+}
+
+func coinChangeTable(coins []int, amount int) dynamic_programming.Table[int] {
+	largest := 0
+	for _, coin := range coins {
+		largest = max(largest, coin)
+	}
+	return dynamic_programming.Table[int]{
+		Stages: amount,
+		States: func(stage int) []int { return []int{stage + 1} },
+		Window: largest + 1,
+	}
+}
+
+func coinChangeDP(coins []int, amount int) ([]int, bool) {
+	sol, err := dynamic_programming.Tabulate(coinChangeProblem(coins), coinChangeTable(coins, amount), amount)
+	if err != nil {
+		return nil, false
+	}
+
+	used := make([]int, 0, len(sol.Path)-1)
+	for i := 1; i < len(sol.Path); i++ {
+		used = append(used, sol.Path[i-1]-sol.Path[i])
+	}
+	return used, true
+}
+
 func coinChange(coins []int, amount int) int {
 	dp := make([]int, amount+1)
 	for i := 1; i <= amount; i++ {
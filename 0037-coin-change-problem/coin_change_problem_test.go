@@ -1,6 +1,12 @@
 package coin_change_problem
 
-import "testing"
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/celj/dsa/0058-dynamic-programming"
+)
 
 func TestCoinChange(t *testing.T) {
 	testCases := []struct {
@@ -26,6 +32,44 @@ func TestCoinChange(t *testing.T) {
 	}
 }
 
+func TestCoinChangeDP(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for trial := range 200 {
+		coins := make([]int, 1+rng.Intn(4))
+		for i := range coins {
+			coins[i] = 1 + rng.Intn(12)
+		}
+		amount := rng.Intn(60)
+
+		used, ok := coinChangeDP(coins, amount)
+		memo, err := dynamic_programming.Memoize(coinChangeProblem(coins), amount)
+		if want := coinChange(coins, amount); (want < 0) == ok || (ok && len(used) != want) {
+			t.Fatalf("trial %d: expected the tabulated variant to agree with coinChange on %d coins for %d from %v, got %v", trial, want, amount, coins, used)
+		}
+		if !ok {
+			if err == nil {
+				t.Fatalf("trial %d: tabulation found no change for %d from %v, memoization found %v", trial, amount, coins, memo.Path)
+			}
+			continue
+		}
+		if err != nil || memo.Value != len(used) {
+			t.Fatalf("trial %d: expected memoization to agree on %d coins, got %d (%v)", trial, len(used), memo.Value, err)
+		}
+
+		sum := 0
+		for _, coin := range used {
+			if !slices.Contains(coins, coin) {
+				t.Fatalf("trial %d: used coin %d is not in %v", trial, coin, coins)
+			}
+			sum += coin
+		}
+		if sum != amount {
+			t.Fatalf("trial %d: coins %v sum to %d, expected %d", trial, used, sum, amount)
+		}
+	}
+}
+
 func TestRun(t *testing.T) {
 	result := Run()
 	if result == nil {
@@ -64,3 +108,12 @@ func BenchmarkCoinChange(b *testing.B) {
 		coinChange(coins, amount)
 	}
 }
+
+func BenchmarkCoinChangeDP(b *testing.B) {
+	coins := []int{1, 2, 5}
+	amount := 11
+
+	for b.Loop() {
+		coinChangeDP(coins, amount)
+	}
+}
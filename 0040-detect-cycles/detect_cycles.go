@@ -1,32 +1,44 @@
 package detect_cycles
 
-const (
-	WHITE = iota
-	GRAY
-	BLACK
+import (
+	"errors"
+	"iter"
+	"maps"
+	"math"
+	"slices"
+	"sync"
+)
+
+var (
+	ErrNoCycle        = errors.New("graph has no cycle")
+	ErrNegativeWeight = errors.New("edge weights must not be negative")
 )
 
 type Graph struct {
 	adjacencyList [][]int
+	weights       [][]float64
 	numNodes      int
 }
 
-type CycleDetector struct {
-	graph  *Graph
-	color  []int
-	parent []int
-	cycles [][]int
-}
-
 func NewGraph(numNodes int) *Graph {
 	return &Graph{
 		adjacencyList: make([][]int, numNodes),
+		weights:       make([][]float64, numNodes),
 		numNodes:      numNodes,
 	}
 }
 
 func (g *Graph) AddEdge(from, to int) {
+	g.AddWeightedEdge(from, to, 1)
+}
+
+func (g *Graph) AddWeightedEdge(from, to int, weight float64) {
 	g.adjacencyList[from] = append(g.adjacencyList[from], to)
+	g.weights[from] = append(g.weights[from], weight)
+}
+
+func (g *Graph) NumNodes() int {
+	return g.numNodes
 }
 
 func buildGraphFromBlockers(blockers [][]bool) *Graph {
@@ -48,115 +60,588 @@ func buildGraphFromBlockers(blockers [][]bool) *Graph {
 	return graph
 }
 
-func NewCycleDetector(graph *Graph) *CycleDetector {
-	return &CycleDetector{
-		graph:  graph,
-		color:  make([]int, graph.numNodes),
-		parent: make([]int, graph.numNodes),
-		cycles: [][]int{},
+type johnsonSearch struct {
+	adjacency [][]int
+	start     int
+	blocked   []bool
+	blockMap  []map[int]bool
+	stack     []int
+	maxLength int
+	visit     func([]int) bool
+	stopped   bool
+}
+
+func (g *Graph) ElementaryCycles(maxLength int) [][]int {
+	cycles := [][]int{}
+	g.WalkCycles(maxLength, func(cycle []int) bool {
+		cycles = append(cycles, cycle)
+		return true
+	})
+	return cycles
+}
+
+func (g *Graph) Cycles(maxLength int) iter.Seq[[]int] {
+	return func(yield func([]int) bool) {
+		g.WalkCycles(maxLength, yield)
+	}
+}
+
+func (g *Graph) WalkCycles(maxLength int, visit func([]int) bool) {
+	search := &johnsonSearch{
+		blocked:   make([]bool, g.numNodes),
+		blockMap:  make([]map[int]bool, g.numNodes),
+		maxLength: maxLength,
+		visit:     visit,
+	}
+
+	for start := range g.numNodes {
+		component := g.componentFrom(start)
+		if len(component) == 0 {
+			continue
+		}
+
+		search.adjacency = g.inducedAdjacency(component)
+		search.start = start
+		for _, node := range component {
+			search.blocked[node] = false
+			search.blockMap[node] = make(map[int]bool)
+		}
+
+		search.circuit(start)
+		if search.stopped {
+			return
+		}
 	}
 }
 
-func (cd *CycleDetector) dfs(node int, path []int) {
-	cd.color[node] = GRAY
-	path = append(path, node)
+func (g *Graph) componentFrom(start int) []int {
+	index := make([]int, g.numNodes)
+	lowLink := make([]int, g.numNodes)
+	onStack := make([]bool, g.numNodes)
+	for i := range index {
+		index[i] = -1
+	}
 
-	for _, neighbor := range cd.graph.adjacencyList[node] {
-		if cd.color[neighbor] == GRAY {
-			cycle := cd.extractCycle(path, neighbor)
-			if len(cycle) > 0 {
-				cd.cycles = append(cd.cycles, cycle)
+	stack := []int{}
+	counter := 0
+	var component []int
+
+	var strongConnect func(node int)
+	strongConnect = func(node int) {
+		index[node] = counter
+		lowLink[node] = counter
+		counter++
+		stack = append(stack, node)
+		onStack[node] = true
+
+		for _, neighbor := range g.adjacencyList[node] {
+			if neighbor < start {
+				continue
 			}
-		} else if cd.color[neighbor] == WHITE {
-			cd.parent[neighbor] = node
-			cd.dfs(neighbor, path)
+			if index[neighbor] == -1 {
+				strongConnect(neighbor)
+				lowLink[node] = min(lowLink[node], lowLink[neighbor])
+			} else if onStack[neighbor] {
+				lowLink[node] = min(lowLink[node], index[neighbor])
+			}
+		}
+
+		if lowLink[node] != index[node] {
+			return
+		}
+
+		members := []int{}
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			members = append(members, top)
+			if top == node {
+				break
+			}
+		}
+
+		if slices.Contains(members, start) {
+			component = members
 		}
 	}
 
-	cd.color[node] = BLACK
+	strongConnect(start)
+
+	if len(component) == 1 && !slices.Contains(g.adjacencyList[start], start) {
+		return nil
+	}
+
+	return component
 }
 
-func (cd *CycleDetector) extractCycle(path []int, backEdgeTarget int) []int {
-	cycle := []int{}
+func (g *Graph) inducedAdjacency(component []int) [][]int {
+	inComponent := make([]bool, g.numNodes)
+	for _, node := range component {
+		inComponent[node] = true
+	}
+
+	adjacency := make([][]int, g.numNodes)
+	for _, node := range component {
+		seen := make(map[int]bool)
+		for _, neighbor := range g.adjacencyList[node] {
+			if inComponent[neighbor] && !seen[neighbor] {
+				seen[neighbor] = true
+				adjacency[node] = append(adjacency[node], neighbor)
+			}
+		}
+	}
+
+	return adjacency
+}
+
+func (js *johnsonSearch) circuit(node int) bool {
 	found := false
+	js.stack = append(js.stack, node)
+	js.blocked[node] = true
+
+	for _, neighbor := range js.adjacency[node] {
+		if js.stopped {
+			break
+		}
 
-	for _, node := range path {
-		if node == backEdgeTarget {
+		if neighbor == js.start {
 			found = true
+			if !js.visit(slices.Clone(js.stack)) {
+				js.stopped = true
+			}
+		} else if !js.blocked[neighbor] {
+			if js.maxLength > 0 && len(js.stack) >= js.maxLength {
+				found = true
+			} else if js.circuit(neighbor) {
+				found = true
+			}
 		}
-		if found {
-			cycle = append(cycle, node)
+	}
+
+	if found {
+		js.unblock(node)
+	} else {
+		for _, neighbor := range js.adjacency[node] {
+			js.blockMap[neighbor][node] = true
 		}
 	}
 
-	return cycle
+	js.stack = js.stack[:len(js.stack)-1]
+	return found
 }
 
-func (cd *CycleDetector) findAllCycles() [][]int {
-	for i := range cd.graph.numNodes {
-		cd.color[i] = WHITE
-		cd.parent[i] = -1
+func (js *johnsonSearch) unblock(node int) {
+	js.blocked[node] = false
+	for waiting := range js.blockMap[node] {
+		delete(js.blockMap[node], waiting)
+		if js.blocked[waiting] {
+			js.unblock(waiting)
+		}
 	}
+}
 
-	for i := range cd.graph.numNodes {
-		if cd.color[i] == WHITE {
-			cd.dfs(i, []int{})
+func (g *Graph) MinimumWeightCycle() ([]int, float64, error) {
+	for _, weights := range g.weights {
+		for _, w := range weights {
+			if w < 0 {
+				return nil, 0, ErrNegativeWeight
+			}
 		}
 	}
 
-	return cd.removeDuplicateCycles(cd.cycles)
+	bestWeight := math.Inf(1)
+	var bestCycle []int
+
+	for source := range g.numNodes {
+		distances, previous := g.shortestPathsFrom(source)
+
+		for node := range g.numNodes {
+			if math.IsInf(distances[node], 1) {
+				continue
+			}
+			for i, neighbor := range g.adjacencyList[node] {
+				if neighbor != source {
+					continue
+				}
+				total := distances[node] + g.weights[node][i]
+				if total < bestWeight {
+					bestWeight = total
+					bestCycle = tracePath(previous, source, node)
+				}
+			}
+		}
+	}
+
+	if bestCycle == nil {
+		return nil, 0, ErrNoCycle
+	}
+
+	return bestCycle, bestWeight, nil
 }
 
-func (cd *CycleDetector) removeDuplicateCycles(cycles [][]int) [][]int {
-	seen := make(map[string]bool)
-	uniqueCycles := [][]int{}
+func (g *Graph) shortestPathsFrom(source int) ([]float64, []int) {
+	distances := make([]float64, g.numNodes)
+	previous := make([]int, g.numNodes)
+	visited := make([]bool, g.numNodes)
 
-	for _, cycle := range cycles {
-		if len(cycle) == 0 {
-			continue
+	for i := range g.numNodes {
+		distances[i] = math.Inf(1)
+		previous[i] = -1
+	}
+	distances[source] = 0
+
+	for range g.numNodes {
+		current := -1
+		for node := range g.numNodes {
+			if !visited[node] && !math.IsInf(distances[node], 1) &&
+				(current == -1 || distances[node] < distances[current]) {
+				current = node
+			}
+		}
+		if current == -1 {
+			break
+		}
+
+		visited[current] = true
+		for i, neighbor := range g.adjacencyList[current] {
+			candidate := distances[current] + g.weights[current][i]
+			if candidate < distances[neighbor] {
+				distances[neighbor] = candidate
+				previous[neighbor] = current
+			}
+		}
+	}
+
+	return distances, previous
+}
+
+func tracePath(previous []int, source, target int) []int {
+	path := []int{}
+	for node := target; node != source; node = previous[node] {
+		path = append(path, node)
+	}
+	path = append(path, source)
+	slices.Reverse(path)
+	return path
+}
+
+func (g *Graph) ShortestCycle() []int {
+	var best []int
+
+	for source := range g.numNodes {
+		distances := make([]int, g.numNodes)
+		previous := make([]int, g.numNodes)
+		for i := range distances {
+			distances[i] = -1
+			previous[i] = -1
+		}
+		distances[source] = 0
+
+		queue := []int{source}
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+
+			if best != nil && distances[current]+1 >= len(best) {
+				break
+			}
+
+			if slices.Contains(g.adjacencyList[current], source) {
+				best = tracePath(previous, source, current)
+				break
+			}
+
+			for _, neighbor := range g.adjacencyList[current] {
+				if distances[neighbor] == -1 {
+					distances[neighbor] = distances[current] + 1
+					previous[neighbor] = current
+					queue = append(queue, neighbor)
+				}
+			}
+		}
+	}
+
+	return best
+}
+
+func (g *Graph) Girth() int {
+	return len(g.ShortestCycle())
+}
+
+func (g *Graph) FeedbackArcSet() [][2]int {
+	removed := make([]bool, g.numNodes)
+	inWeight := make([]float64, g.numNodes)
+	outWeight := make([]float64, g.numNodes)
+	inDegree := make([]int, g.numNodes)
+	outDegree := make([]int, g.numNodes)
+	incoming := make([][]int, g.numNodes)
+	incomingWeights := make([][]float64, g.numNodes)
+
+	for from := range g.numNodes {
+		for i, to := range g.adjacencyList[from] {
+			if from == to {
+				continue
+			}
+			outWeight[from] += g.weights[from][i]
+			inWeight[to] += g.weights[from][i]
+			outDegree[from]++
+			inDegree[to]++
+			incoming[to] = append(incoming[to], from)
+			incomingWeights[to] = append(incomingWeights[to], g.weights[from][i])
+		}
+	}
+
+	detach := func(node int) {
+		removed[node] = true
+		for i, to := range g.adjacencyList[node] {
+			if to != node && !removed[to] {
+				inWeight[to] -= g.weights[node][i]
+				inDegree[to]--
+			}
+		}
+		for i, from := range incoming[node] {
+			if !removed[from] {
+				outWeight[from] -= incomingWeights[node][i]
+				outDegree[from]--
+			}
+		}
+	}
+
+	head := []int{}
+	tail := []int{}
+	remaining := g.numNodes
+
+	for remaining > 0 {
+		progressed := true
+		for progressed {
+			progressed = false
+			for node := range g.numNodes {
+				if removed[node] {
+					continue
+				}
+				if outDegree[node] == 0 {
+					tail = append(tail, node)
+				} else if inDegree[node] == 0 {
+					head = append(head, node)
+				} else {
+					continue
+				}
+				detach(node)
+				remaining--
+				progressed = true
+			}
+		}
+
+		if remaining == 0 {
+			break
 		}
 
-		normalized := cd.normalizeCycle(cycle)
-		key := cd.cycleToString(normalized)
+		best := -1
+		for node := range g.numNodes {
+			if removed[node] {
+				continue
+			}
+			if best == -1 || outWeight[node]-inWeight[node] > outWeight[best]-inWeight[best] {
+				best = node
+			}
+		}
+		head = append(head, best)
+		detach(best)
+		remaining--
+	}
 
-		if !seen[key] {
-			seen[key] = true
-			uniqueCycles = append(uniqueCycles, cycle)
+	slices.Reverse(tail)
+	order := append(head, tail...)
+	position := make([]int, g.numNodes)
+	for i, node := range order {
+		position[node] = i
+	}
+
+	arcs := [][2]int{}
+	for from := range g.numNodes {
+		for _, to := range g.adjacencyList[from] {
+			if position[from] >= position[to] {
+				arcs = append(arcs, [2]int{from, to})
+			}
 		}
 	}
 
-	return uniqueCycles
+	return arcs
 }
 
-func (cd *CycleDetector) normalizeCycle(cycle []int) []int {
-	if len(cycle) == 0 {
-		return cycle
+type Deadlock struct {
+	Cycle  []int
+	Victim int
+}
+
+type WaitForGraph struct {
+	mu    sync.Mutex
+	waits map[int]map[int]int
+	cost  func(node int) float64
+}
+
+func NewWaitForGraph(cost func(node int) float64) *WaitForGraph {
+	if cost == nil {
+		cost = func(int) float64 { return 0 }
+	}
+	return &WaitForGraph{
+		waits: make(map[int]map[int]int),
+		cost:  cost,
 	}
+}
 
-	minIdx := 0
-	for i := 1; i < len(cycle); i++ {
-		if cycle[i] < cycle[minIdx] {
-			minIdx = i
+func NewWaitForGraphFromBlockers(blockers [][]bool, cost func(node int) float64) *WaitForGraph {
+	wfg := NewWaitForGraph(cost)
+	for i := range blockers {
+		for j := range blockers[i] {
+			if blockers[i][j] {
+				wfg.addEdge(i, j)
+			}
 		}
 	}
+	return wfg
+}
+
+func (wfg *WaitForGraph) AddWait(waiter, holder int) (*Deadlock, bool) {
+	wfg.mu.Lock()
+	defer wfg.mu.Unlock()
+
+	wfg.addEdge(waiter, holder)
 
-	normalized := make([]int, len(cycle))
-	for i := range len(cycle) {
-		normalized[i] = cycle[(minIdx+i)%len(cycle)]
+	path := wfg.pathBetween(holder, waiter)
+	if path == nil {
+		return nil, false
 	}
 
-	return normalized
+	cycle := append([]int{waiter}, path[:len(path)-1]...)
+	return &Deadlock{Cycle: cycle, Victim: wfg.victim(cycle)}, true
 }
 
-func (cd *CycleDetector) cycleToString(cycle []int) string {
-	result := ""
-	for i, node := range cycle {
-		if i > 0 {
-			result += ","
+func (wfg *WaitForGraph) addEdge(waiter, holder int) {
+	if wfg.waits[waiter] == nil {
+		wfg.waits[waiter] = make(map[int]int)
+	}
+	wfg.waits[waiter][holder]++
+}
+
+func (wfg *WaitForGraph) RemoveWait(waiter, holder int) bool {
+	wfg.mu.Lock()
+	defer wfg.mu.Unlock()
+
+	holders, ok := wfg.waits[waiter]
+	if !ok || holders[holder] == 0 {
+		return false
+	}
+
+	holders[holder]--
+	if holders[holder] == 0 {
+		delete(holders, holder)
+	}
+	if len(holders) == 0 {
+		delete(wfg.waits, waiter)
+	}
+	return true
+}
+
+func (wfg *WaitForGraph) RemoveNode(node int) {
+	wfg.mu.Lock()
+	defer wfg.mu.Unlock()
+
+	delete(wfg.waits, node)
+	for waiter, holders := range wfg.waits {
+		delete(holders, node)
+		if len(holders) == 0 {
+			delete(wfg.waits, waiter)
 		}
-		result += string(rune('0' + node))
 	}
-	return result
+}
+
+func (wfg *WaitForGraph) WaitsFor(waiter int) []int {
+	wfg.mu.Lock()
+	defer wfg.mu.Unlock()
+
+	return slices.Sorted(maps.Keys(wfg.waits[waiter]))
+}
+
+func (wfg *WaitForGraph) pathBetween(from, to int) []int {
+	if from == to {
+		return []int{from}
+	}
+
+	previous := map[int]int{from: from}
+	queue := []int{from}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, next := range slices.Sorted(maps.Keys(wfg.waits[current])) {
+			if _, seen := previous[next]; seen {
+				continue
+			}
+			previous[next] = current
+			if next == to {
+				path := []int{to}
+				for node := to; node != from; {
+					node = previous[node]
+					path = append(path, node)
+				}
+				slices.Reverse(path)
+				return path
+			}
+			queue = append(queue, next)
+		}
+	}
+
+	return nil
+}
+
+func (wfg *WaitForGraph) victim(cycle []int) int {
+	victim := cycle[0]
+	for _, node := range cycle[1:] {
+		cost, victimCost := wfg.cost(node), wfg.cost(victim)
+		if cost < victimCost || (cost == victimCost && node > victim) {
+			victim = node
+		}
+	}
+	return victim
+}
+
+func (wfg *WaitForGraph) Deadlocks() []Deadlock {
+	wfg.mu.Lock()
+	defer wfg.mu.Unlock()
+
+	nodeSet := make(map[int]bool)
+	for waiter, holders := range wfg.waits {
+		nodeSet[waiter] = true
+		for holder := range holders {
+			nodeSet[holder] = true
+		}
+	}
+
+	nodes := slices.Sorted(maps.Keys(nodeSet))
+	index := make(map[int]int, len(nodes))
+	for i, node := range nodes {
+		index[node] = i
+	}
+
+	graph := NewGraph(len(nodes))
+	for _, waiter := range nodes {
+		for _, holder := range slices.Sorted(maps.Keys(wfg.waits[waiter])) {
+			graph.AddEdge(index[waiter], index[holder])
+		}
+	}
+
+	deadlocks := []Deadlock{}
+	for cycle := range graph.Cycles(0) {
+		for i, position := range cycle {
+			cycle[i] = nodes[position]
+		}
+		deadlocks = append(deadlocks, Deadlock{Cycle: cycle, Victim: wfg.victim(cycle)})
+	}
+
+	return deadlocks
 }
 
 func detectCycles(blockers [][]bool) [][]int {
@@ -164,9 +649,7 @@ func detectCycles(blockers [][]bool) [][]int {
 		return [][]int{}
 	}
 
-	graph := buildGraphFromBlockers(blockers)
-	detector := NewCycleDetector(graph)
-	return detector.findAllCycles()
+	return buildGraphFromBlockers(blockers).ElementaryCycles(0)
 }
 
 type TestCase struct {
@@ -1,7 +1,10 @@
 package detect_cycles
 
 import (
+	"errors"
+	"math/rand"
 	"reflect"
+	"slices"
 	"sort"
 	"testing"
 )
@@ -130,43 +133,6 @@ func TestBuildGraphFromBlockers(t *testing.T) {
 	}
 }
 
-func TestCycleNormalization(t *testing.T) {
-	detector := &CycleDetector{}
-
-	testCases := []struct {
-		input    []int
-		expected []int
-	}{
-		{[]int{0, 1, 2}, []int{0, 1, 2}},
-		{[]int{1, 2, 0}, []int{0, 1, 2}},
-		{[]int{2, 0, 1}, []int{0, 1, 2}},
-		{[]int{3, 1, 2}, []int{1, 2, 3}},
-		{[]int{}, []int{}},
-		{[]int{5}, []int{5}},
-	}
-
-	for _, tc := range testCases {
-		result := detector.normalizeCycle(tc.input)
-		if !reflect.DeepEqual(result, tc.expected) {
-			t.Errorf("Input %v: expected %v, got %v", tc.input, tc.expected, result)
-		}
-	}
-}
-
-func TestExtractCycle(t *testing.T) {
-	detector := &CycleDetector{}
-
-	path := []int{0, 1, 2, 3}
-	backEdgeTarget := 1
-
-	result := detector.extractCycle(path, backEdgeTarget)
-	expected := []int{1, 2, 3}
-
-	if !reflect.DeepEqual(result, expected) {
-		t.Errorf("Expected %v, got %v", expected, result)
-	}
-}
-
 func TestRun(t *testing.T) {
 	result := Run()
 	if result == nil {
@@ -215,6 +181,403 @@ func TestLargeGraph(t *testing.T) {
 	}
 }
 
+func TestDetectCyclesSharedVertices(t *testing.T) {
+	blockers := [][]bool{
+		{false, true, true},
+		{true, false, false},
+		{false, true, false},
+	}
+
+	result := detectCycles(blockers)
+	sortCycles(result)
+	expected := [][]int{{0, 1}, {0, 1, 2}}
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func completeGraph(n int) *Graph {
+	graph := NewGraph(n)
+	for i := range n {
+		for j := range n {
+			if i != j {
+				graph.AddEdge(i, j)
+			}
+		}
+	}
+	return graph
+}
+
+func bruteForceCycles(graph *Graph, maxLength int) map[string]bool {
+	found := make(map[string]bool)
+
+	var walk func(start, node int, path []int, visited []bool)
+	walk = func(start, node int, path []int, visited []bool) {
+		for _, neighbor := range graph.adjacencyList[node] {
+			if neighbor == start {
+				found[cycleKey(path)] = true
+			} else if neighbor > start && !visited[neighbor] &&
+				(maxLength == 0 || len(path) < maxLength) {
+				visited[neighbor] = true
+				walk(start, neighbor, append(path, neighbor), visited)
+				visited[neighbor] = false
+			}
+		}
+	}
+
+	for start := range graph.numNodes {
+		visited := make([]bool, graph.numNodes)
+		visited[start] = true
+		walk(start, start, []int{start}, visited)
+	}
+
+	return found
+}
+
+func cycleKey(cycle []int) string {
+	start := 0
+	for i := range cycle {
+		if cycle[i] < cycle[start] {
+			start = i
+		}
+	}
+
+	key := ""
+	for i := range cycle {
+		key += string(rune('A'+cycle[(start+i)%len(cycle)])) + ","
+	}
+	return key
+}
+
+func TestElementaryCyclesCompleteGraph(t *testing.T) {
+	testCases := []struct {
+		maxLength int
+		expected  int
+	}{
+		{0, 20},
+		{2, 6},
+		{3, 14},
+		{4, 20},
+	}
+
+	for _, tc := range testCases {
+		cycles := completeGraph(4).ElementaryCycles(tc.maxLength)
+		if len(cycles) != tc.expected {
+			t.Errorf("maxLength %d: expected %d cycles, got %d", tc.maxLength, tc.expected, len(cycles))
+		}
+
+		seen := make(map[string]bool)
+		for _, cycle := range cycles {
+			if tc.maxLength > 0 && len(cycle) > tc.maxLength {
+				t.Errorf("Cycle %v exceeds max length %d", cycle, tc.maxLength)
+			}
+			key := cycleKey(cycle)
+			if seen[key] {
+				t.Errorf("Cycle %v reported more than once", cycle)
+			}
+			seen[key] = true
+		}
+	}
+}
+
+func TestElementaryCyclesMatchBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+
+	for trial := range 50 {
+		n := 2 + rng.Intn(6)
+		graph := NewGraph(n)
+		for i := range n {
+			for j := range n {
+				if rng.Float64() < 0.35 {
+					graph.AddEdge(i, j)
+				}
+			}
+		}
+
+		for _, maxLength := range []int{0, 2, 3} {
+			expected := bruteForceCycles(graph, maxLength)
+			cycles := graph.ElementaryCycles(maxLength)
+
+			got := make(map[string]bool)
+			for _, cycle := range cycles {
+				got[cycleKey(cycle)] = true
+			}
+
+			if len(cycles) != len(expected) || !reflect.DeepEqual(got, expected) {
+				t.Fatalf("Trial %d, maxLength %d: expected %d cycles, got %d (%v)",
+					trial, maxLength, len(expected), len(cycles), cycles)
+			}
+		}
+	}
+}
+
+func TestElementaryCyclesParallelEdges(t *testing.T) {
+	graph := NewGraph(2)
+	graph.AddEdge(0, 1)
+	graph.AddEdge(0, 1)
+	graph.AddEdge(1, 0)
+
+	cycles := graph.ElementaryCycles(0)
+	if !reflect.DeepEqual(cycles, [][]int{{0, 1}}) {
+		t.Errorf("Expected [[0 1]], got %v", cycles)
+	}
+}
+
+func TestCyclesIteratorStopsEarly(t *testing.T) {
+	graph := completeGraph(5)
+
+	count := 0
+	for cycle := range graph.Cycles(0) {
+		if len(cycle) == 0 {
+			t.Error("Expected non-empty cycle")
+		}
+		count++
+		if count == 3 {
+			break
+		}
+	}
+
+	if count != 3 {
+		t.Errorf("Expected iteration to stop at 3 cycles, got %d", count)
+	}
+
+	visited := 0
+	graph.WalkCycles(2, func(cycle []int) bool {
+		visited++
+		return visited < 4
+	})
+	if visited != 4 {
+		t.Errorf("Expected callback to stop after 4 cycles, got %d", visited)
+	}
+}
+
+func TestMinimumWeightCycle(t *testing.T) {
+	graph := NewGraph(4)
+	graph.AddWeightedEdge(0, 1, 1)
+	graph.AddWeightedEdge(1, 2, 1)
+	graph.AddWeightedEdge(2, 0, 1)
+	graph.AddWeightedEdge(1, 3, 10)
+	graph.AddWeightedEdge(3, 1, 10)
+	graph.AddWeightedEdge(2, 3, 0.5)
+	graph.AddWeightedEdge(3, 0, 0.25)
+
+	cycle, weight, err := graph.MinimumWeightCycle()
+	if err != nil {
+		t.Fatalf("Expected a cycle, got %v", err)
+	}
+
+	if weight != 2.75 {
+		t.Errorf("Expected weight 2.75, got %v", weight)
+	}
+
+	if cycleKey(cycle) != cycleKey([]int{0, 1, 2, 3}) {
+		t.Errorf("Expected cycle [0 1 2 3], got %v", cycle)
+	}
+
+	acyclic := NewGraph(3)
+	acyclic.AddEdge(0, 1)
+	acyclic.AddEdge(1, 2)
+	if _, _, err := acyclic.MinimumWeightCycle(); !errors.Is(err, ErrNoCycle) {
+		t.Errorf("Expected ErrNoCycle for an acyclic graph, got %v", err)
+	}
+
+	graph.AddWeightedEdge(0, 3, -1)
+	if _, _, err := graph.MinimumWeightCycle(); !errors.Is(err, ErrNegativeWeight) {
+		t.Errorf("Expected ErrNegativeWeight for a negative edge, got %v", err)
+	}
+
+	negativeAcyclic := NewGraph(2)
+	negativeAcyclic.AddWeightedEdge(0, 1, -2)
+	if _, _, err := negativeAcyclic.MinimumWeightCycle(); !errors.Is(err, ErrNegativeWeight) {
+		t.Errorf("Expected ErrNegativeWeight even without a cycle, got %v", err)
+	}
+}
+
+func TestGirth(t *testing.T) {
+	testCases := []struct {
+		name     string
+		graph    *Graph
+		expected int
+	}{
+		{"Acyclic", buildGraphFromBlockers([][]bool{{false, true}, {false, false}}), 0},
+		{"Self-loop", buildGraphFromBlockers([][]bool{{true, false}, {true, false}}), 1},
+		{"Complete", completeGraph(5), 2},
+		{"Ring", buildGraphFromBlockers([][]bool{
+			{false, false, false, true},
+			{true, false, false, false},
+			{false, true, false, false},
+			{false, false, true, false},
+		}), 4},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if girth := tc.graph.Girth(); girth != tc.expected {
+				t.Errorf("Expected girth %d, got %d", tc.expected, girth)
+			}
+		})
+	}
+}
+
+func TestFeedbackArcSet(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+
+	for range 30 {
+		n := 1 + rng.Intn(8)
+		graph := NewGraph(n)
+		for i := range n {
+			for j := range n {
+				if rng.Float64() < 0.3 {
+					graph.AddEdge(i, j)
+				}
+			}
+		}
+
+		arcs := graph.FeedbackArcSet()
+		pruned := NewGraph(n)
+		for from := range n {
+			for _, to := range graph.adjacencyList[from] {
+				if !slices.Contains(arcs, [2]int{from, to}) {
+					pruned.AddEdge(from, to)
+				}
+			}
+		}
+
+		if cycles := pruned.ElementaryCycles(0); len(cycles) != 0 {
+			t.Fatalf("Expected acyclic graph after removing %v, found %v", arcs, cycles)
+		}
+	}
+
+	if arcs := completeGraph(2).FeedbackArcSet(); len(arcs) != 1 {
+		t.Errorf("Expected 1 feedback arc for a 2-cycle, got %v", arcs)
+	}
+}
+
+func TestWaitForGraphDetectsDeadlockOnAdd(t *testing.T) {
+	wfg := NewWaitForGraph(nil)
+
+	if _, ok := wfg.AddWait(1, 2); ok {
+		t.Error("Expected no deadlock after first wait")
+	}
+	if _, ok := wfg.AddWait(2, 3); ok {
+		t.Error("Expected no deadlock after second wait")
+	}
+
+	deadlock, ok := wfg.AddWait(3, 1)
+	if !ok {
+		t.Fatal("Expected deadlock when closing the cycle")
+	}
+
+	if !reflect.DeepEqual(deadlock.Cycle, []int{3, 1, 2}) {
+		t.Errorf("Expected cycle [3 1 2], got %v", deadlock.Cycle)
+	}
+	if deadlock.Victim != 3 {
+		t.Errorf("Expected youngest transaction 3 as victim, got %d", deadlock.Victim)
+	}
+
+	wfg.RemoveNode(deadlock.Victim)
+	if deadlocks := wfg.Deadlocks(); len(deadlocks) != 0 {
+		t.Errorf("Expected no deadlocks after aborting victim, got %v", deadlocks)
+	}
+	if _, ok := wfg.AddWait(2, 1); !ok {
+		t.Error("Expected deadlock between 1 and 2")
+	}
+}
+
+func TestWaitForGraphVictimCost(t *testing.T) {
+	work := map[int]float64{10: 5, 20: 1, 30: 9}
+	wfg := NewWaitForGraph(func(node int) float64 { return work[node] })
+
+	wfg.AddWait(10, 20)
+	wfg.AddWait(20, 30)
+	deadlock, ok := wfg.AddWait(30, 10)
+	if !ok {
+		t.Fatal("Expected deadlock")
+	}
+	if deadlock.Victim != 20 {
+		t.Errorf("Expected cheapest transaction 20 as victim, got %d", deadlock.Victim)
+	}
+}
+
+func TestWaitForGraphRemoveWait(t *testing.T) {
+	wfg := NewWaitForGraph(nil)
+
+	wfg.AddWait(1, 2)
+	wfg.AddWait(1, 2)
+	if !wfg.RemoveWait(1, 2) {
+		t.Error("Expected first removal to succeed")
+	}
+	if !reflect.DeepEqual(wfg.WaitsFor(1), []int{2}) {
+		t.Errorf("Expected 1 to still wait for 2, got %v", wfg.WaitsFor(1))
+	}
+	if !wfg.RemoveWait(1, 2) {
+		t.Error("Expected second removal to succeed")
+	}
+	if wfg.RemoveWait(1, 2) {
+		t.Error("Expected removal of missing edge to fail")
+	}
+
+	if _, ok := wfg.AddWait(2, 1); ok {
+		t.Error("Expected no deadlock after edge was released")
+	}
+
+	if deadlock, ok := wfg.AddWait(4, 4); !ok || !reflect.DeepEqual(deadlock.Cycle, []int{4}) {
+		t.Errorf("Expected self-deadlock [4], got %v", deadlock)
+	}
+}
+
+func TestWaitForGraphFromBlockers(t *testing.T) {
+	blockers := [][]bool{
+		{false, false, true, false, false},
+		{true, false, false, false, false},
+		{false, true, false, false, false},
+		{false, false, false, false, true},
+		{false, false, false, true, false},
+	}
+
+	deadlocks := NewWaitForGraphFromBlockers(blockers, nil).Deadlocks()
+
+	cycles := [][]int{}
+	for _, deadlock := range deadlocks {
+		cycles = append(cycles, deadlock.Cycle)
+		if !slices.Contains(deadlock.Cycle, deadlock.Victim) {
+			t.Errorf("Victim %d is not part of cycle %v", deadlock.Victim, deadlock.Cycle)
+		}
+	}
+
+	expected := detectCycles(blockers)
+	sortCycles(cycles)
+	sortCycles(expected)
+	if !reflect.DeepEqual(cycles, expected) {
+		t.Errorf("Expected %v, got %v", expected, cycles)
+	}
+}
+
+func TestWaitForGraphConcurrentAccess(t *testing.T) {
+	wfg := NewWaitForGraph(nil)
+	done := make(chan struct{})
+
+	for worker := range 8 {
+		go func() {
+			defer func() { done <- struct{}{} }()
+			for i := range 200 {
+				waiter := worker*1000 + i
+				wfg.AddWait(waiter, waiter+1)
+				wfg.RemoveWait(waiter, waiter+1)
+			}
+		}()
+	}
+
+	for range 8 {
+		<-done
+	}
+
+	if deadlocks := wfg.Deadlocks(); len(deadlocks) != 0 {
+		t.Errorf("Expected no deadlocks, got %v", deadlocks)
+	}
+}
+
 func BenchmarkRun(b *testing.B) {
 	for b.Loop() {
 		Run()
@@ -253,3 +616,25 @@ func BenchmarkLargeGraph(b *testing.B) {
 		detectCycles(blockers)
 	}
 }
+
+func BenchmarkElementaryCycles(b *testing.B) {
+	graph := completeGraph(7)
+
+	b.ResetTimer()
+	for b.Loop() {
+		graph.ElementaryCycles(0)
+	}
+}
+
+func BenchmarkWaitForGraphAddWait(b *testing.B) {
+	wfg := NewWaitForGraph(nil)
+	for i := range 1000 {
+		wfg.AddWait(i, i+1)
+	}
+
+	b.ResetTimer()
+	for b.Loop() {
+		wfg.AddWait(1000, 0)
+		wfg.RemoveWait(1000, 0)
+	}
+}
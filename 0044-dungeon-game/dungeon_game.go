@@ -1,5 +1,65 @@
 package dungeon_game
 
+import "github.com/celj/dsa/0058-dynamic-programming"
+
+type Point struct {
+	Row, Col int
+}
+
+func dungeonProblem(dungeon [][]int) dynamic_programming.Problem[Point, int] {
+	m, n := len(dungeon), len(dungeon[0])
+	enter := func(room Point) func(int) int {
+		return func(need int) int { return max(1, need-dungeon[room.Row][room.Col]) }
+	}
+
+	return dynamic_programming.Problem[Point, int]{
+		Goal: dynamic_programming.Minimize,
+		Base: func(room Point) (int, bool) {
+			if room.Row == m-1 && room.Col == n-1 {
+				return enter(room)(1), true
+			}
+			return 0, false
+		},
+		Choices: func(room Point) []dynamic_programming.Choice[Point, int] {
+			var choices []dynamic_programming.Choice[Point, int]
+			if room.Row+1 < m {
+				choices = append(choices, dynamic_programming.Choice[Point, int]{Next: Point{Row: room.Row + 1, Col: room.Col}, Value: enter(room)})
+			}
+			if room.Col+1 < n {
+				choices = append(choices, dynamic_programming.Choice[Point, int]{Next: Point{Row: room.Row, Col: room.Col + 1}, Value: enter(room)})
+			}
+			return choices
+		},
+	}
+}
+
+func dungeonTable(dungeon [][]int) dynamic_programming.Table[Point] {
+	m, n := len(dungeon), len(dungeon[0])
+	return dynamic_programming.Table[Point]{
+		Stages: m,
+		States: func(stage int) []Point {
+			row := make([]Point, 0, n)
+			for col := n - 1; col >= 0; col-- {
+				row = append(row, Point{Row: m - 1 - stage, Col: col})
+			}
+			return row
+		},
+		Window: 2,
+	}
+}
+
+func rescueRoute(dungeon [][]int) (int, []Point) {
+	if len(dungeon) == 0 || len(dungeon[0]) == 0 {
+		return 1, nil
+	}
+
+	sol, err := dynamic_programming.Tabulate(dungeonProblem(dungeon), dungeonTable(dungeon), Point{})
+	if err != nil {
+		return 1, nil
+	}
+	return sol.Value, sol.Path
+}
+
 func calculateMinimumHP(dungeon [][]int) int {
 	m := len(dungeon)
 	if m == 0 {
@@ -52,5 +112,11 @@ func Run() any {
 		{-5, -10, 1},
 		{10, 30, -5},
 	}
-	return calculateMinimumHP(dungeon)
+	_, route := rescueRoute(dungeon)
+
+	return map[string]any{
+		"dungeon":    dungeon,
+		"minimum_hp": calculateMinimumHP(dungeon),
+		"route":      route,
+	}
 }
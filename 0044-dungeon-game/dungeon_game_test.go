@@ -1,6 +1,11 @@
 package dungeon_game
 
-import "testing"
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/celj/dsa/0058-dynamic-programming"
+)
 
 func TestCalculateMinimumHP(t *testing.T) {
 	testCases := []struct {
@@ -60,3 +65,66 @@ func BenchmarkCalculateMinimumHP(b *testing.B) {
 		calculateMinimumHP(dungeon)
 	}
 }
+
+func TestRescueRoute(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for trial := range 200 {
+		m, n := 1+rng.Intn(6), 1+rng.Intn(6)
+		dungeon := make([][]int, m)
+		for i := range dungeon {
+			dungeon[i] = make([]int, n)
+			for j := range dungeon[i] {
+				dungeon[i][j] = rng.Intn(21) - 12
+			}
+		}
+
+		hp, route := rescueRoute(dungeon)
+		if want := calculateMinimumHP(dungeon); hp != want {
+			t.Fatalf("trial %d: expected the tabulated variant to agree with calculateMinimumHP on %d HP, got %d", trial, want, hp)
+		}
+		if len(route) != m+n-1 || route[0] != (Point{}) || route[len(route)-1] != (Point{Row: m - 1, Col: n - 1}) {
+			t.Fatalf("trial %d: expected a route from the corner to the princess, got %v", trial, route)
+		}
+
+		health := hp
+		for i, room := range route {
+			if i > 0 {
+				step := room.Row - route[i-1].Row + room.Col - route[i-1].Col
+				if step != 1 || room.Row < route[i-1].Row || room.Col < route[i-1].Col {
+					t.Fatalf("trial %d: route %v does not move right or down", trial, route)
+				}
+			}
+			health += dungeon[room.Row][room.Col]
+			if health <= 0 {
+				t.Fatalf("trial %d: knight with %d HP dies at %v on route %v", trial, hp, room, route)
+			}
+		}
+
+		memo, err := dynamic_programming.Memoize(dungeonProblem(dungeon), Point{})
+		if err != nil || memo.Value != hp {
+			t.Fatalf("trial %d: expected memoization to agree on %d HP, got %d (%v)", trial, hp, memo.Value, err)
+		}
+	}
+}
+
+func BenchmarkRescueRoute(b *testing.B) {
+	dungeon := [][]int{
+		{-2, -3, 3},
+		{-5, -10, 1},
+		{10, 30, -5},
+	}
+	for b.Loop() {
+		rescueRoute(dungeon)
+	}
+}
+
+func TestRun(t *testing.T) {
+	result, ok := Run().(map[string]any)
+	if !ok {
+		t.Fatal("Expected result to be a map")
+	}
+	if result["minimum_hp"] != 7 || len(result["route"].([]Point)) != 5 {
+		t.Errorf("unexpected result %v", result)
+	}
+}
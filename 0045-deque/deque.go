@@ -0,0 +1,342 @@
+package deque
+
+import (
+	"errors"
+	"iter"
+)
+
+const minCapacity = 8
+
+var (
+	ErrEmpty = errors.New("container is empty")
+	ErrFull  = errors.New("container is full")
+)
+
+type Deque[T any] struct {
+	buffer []T
+	head   int
+	size   int
+	limit  int
+}
+
+func NewDeque[T any]() *Deque[T] {
+	return &Deque[T]{buffer: make([]T, minCapacity)}
+}
+
+func NewBoundedDeque[T any](limit int) *Deque[T] {
+	if limit <= 0 {
+		limit = 1
+	}
+	return &Deque[T]{buffer: make([]T, min(limit, minCapacity)), limit: limit}
+}
+
+func (d *Deque[T]) index(offset int) int {
+	return (d.head + offset) % len(d.buffer)
+}
+
+func (d *Deque[T]) resize(capacity int) {
+	buffer := make([]T, capacity)
+	for i := range d.size {
+		buffer[i] = d.buffer[d.index(i)]
+	}
+	d.buffer = buffer
+	d.head = 0
+}
+
+func (d *Deque[T]) grow() error {
+	if d.IsFull() {
+		return ErrFull
+	}
+	if d.size == len(d.buffer) {
+		capacity := len(d.buffer) * 2
+		if d.limit > 0 {
+			capacity = min(capacity, d.limit)
+		}
+		d.resize(capacity)
+	}
+	return nil
+}
+
+func (d *Deque[T]) shrink() {
+	if len(d.buffer) > minCapacity && d.size <= len(d.buffer)/4 {
+		d.resize(max(len(d.buffer)/2, minCapacity))
+	}
+}
+
+func (d *Deque[T]) PushBack(item T) error {
+	if err := d.grow(); err != nil {
+		return err
+	}
+	d.buffer[d.index(d.size)] = item
+	d.size++
+	return nil
+}
+
+func (d *Deque[T]) PushFront(item T) error {
+	if err := d.grow(); err != nil {
+		return err
+	}
+	d.head = (d.head - 1 + len(d.buffer)) % len(d.buffer)
+	d.buffer[d.head] = item
+	d.size++
+	return nil
+}
+
+func (d *Deque[T]) PopFront() (T, error) {
+	var zero T
+	if d.size == 0 {
+		return zero, ErrEmpty
+	}
+
+	item := d.buffer[d.head]
+	d.buffer[d.head] = zero
+	d.head = d.index(1)
+	d.size--
+	d.shrink()
+	return item, nil
+}
+
+func (d *Deque[T]) PopBack() (T, error) {
+	var zero T
+	if d.size == 0 {
+		return zero, ErrEmpty
+	}
+
+	tail := d.index(d.size - 1)
+	item := d.buffer[tail]
+	d.buffer[tail] = zero
+	d.size--
+	d.shrink()
+	return item, nil
+}
+
+func (d *Deque[T]) Front() (T, error) {
+	return d.At(0)
+}
+
+func (d *Deque[T]) Back() (T, error) {
+	return d.At(d.size - 1)
+}
+
+func (d *Deque[T]) At(i int) (T, error) {
+	var zero T
+	if d.size == 0 {
+		return zero, ErrEmpty
+	}
+	if i < 0 || i >= d.size {
+		return zero, errors.New("index out of bounds")
+	}
+	return d.buffer[d.index(i)], nil
+}
+
+func (d *Deque[T]) PeekN(n int) []T {
+	n = max(min(n, d.size), 0)
+	result := make([]T, n)
+	for i := range n {
+		result[i] = d.buffer[d.index(i)]
+	}
+	return result
+}
+
+func (d *Deque[T]) Drain() []T {
+	result := d.ToSlice()
+	d.Clear()
+	return result
+}
+
+func (d *Deque[T]) Clear() {
+	d.buffer = make([]T, min(len(d.buffer), minCapacity))
+	d.head = 0
+	d.size = 0
+}
+
+func (d *Deque[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := range d.size {
+			if !yield(i, d.buffer[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+func (d *Deque[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := range d.size {
+			if !yield(d.buffer[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+func (d *Deque[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := d.size - 1; i >= 0; i-- {
+			if !yield(i, d.buffer[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+func (d *Deque[T]) ToSlice() []T {
+	return d.PeekN(d.size)
+}
+
+func (d *Deque[T]) Size() int {
+	return d.size
+}
+
+func (d *Deque[T]) Capacity() int {
+	return len(d.buffer)
+}
+
+func (d *Deque[T]) Limit() int {
+	return d.limit
+}
+
+func (d *Deque[T]) IsEmpty() bool {
+	return d.size == 0
+}
+
+func (d *Deque[T]) IsFull() bool {
+	return d.limit > 0 && d.size == d.limit
+}
+
+type Stack[T any] struct {
+	items *Deque[T]
+}
+
+func NewStack[T any]() *Stack[T] {
+	return &Stack[T]{items: NewDeque[T]()}
+}
+
+func NewBoundedStack[T any](limit int) *Stack[T] {
+	return &Stack[T]{items: NewBoundedDeque[T](limit)}
+}
+
+func (s *Stack[T]) Push(item T) error {
+	return s.items.PushBack(item)
+}
+
+func (s *Stack[T]) Pop() (T, error) {
+	return s.items.PopBack()
+}
+
+func (s *Stack[T]) Peek() (T, error) {
+	return s.items.Back()
+}
+
+func (s *Stack[T]) PeekN(n int) []T {
+	n = max(min(n, s.items.size), 0)
+	result := make([]T, n)
+	for i := range n {
+		result[i] = s.items.buffer[s.items.index(s.items.size-1-i)]
+	}
+	return result
+}
+
+func (s *Stack[T]) Drain() []T {
+	result := s.PeekN(s.items.size)
+	s.items.Clear()
+	return result
+}
+
+func (s *Stack[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, item := range s.items.Backward() {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+func (s *Stack[T]) Size() int {
+	return s.items.Size()
+}
+
+func (s *Stack[T]) IsEmpty() bool {
+	return s.items.IsEmpty()
+}
+
+func (s *Stack[T]) IsFull() bool {
+	return s.items.IsFull()
+}
+
+type Queue[T any] struct {
+	items *Deque[T]
+}
+
+func NewQueue[T any]() *Queue[T] {
+	return &Queue[T]{items: NewDeque[T]()}
+}
+
+func NewBoundedQueue[T any](limit int) *Queue[T] {
+	return &Queue[T]{items: NewBoundedDeque[T](limit)}
+}
+
+func (q *Queue[T]) Enqueue(item T) error {
+	return q.items.PushBack(item)
+}
+
+func (q *Queue[T]) Dequeue() (T, error) {
+	return q.items.PopFront()
+}
+
+func (q *Queue[T]) Front() (T, error) {
+	return q.items.Front()
+}
+
+func (q *Queue[T]) PeekN(n int) []T {
+	return q.items.PeekN(n)
+}
+
+func (q *Queue[T]) Drain() []T {
+	return q.items.Drain()
+}
+
+func (q *Queue[T]) All() iter.Seq[T] {
+	return q.items.Values()
+}
+
+func (q *Queue[T]) Size() int {
+	return q.items.Size()
+}
+
+func (q *Queue[T]) IsEmpty() bool {
+	return q.items.IsEmpty()
+}
+
+func (q *Queue[T]) IsFull() bool {
+	return q.items.IsFull()
+}
+
+func Run() any {
+	d := NewDeque[int]()
+	for i := 1; i <= 5; i++ {
+		d.PushBack(i * 10)
+	}
+	d.PushFront(0)
+	back, _ := d.PopBack()
+
+	s := NewStack[string]()
+	for _, word := range []string{"a", "b", "c"} {
+		s.Push(word)
+	}
+
+	q := NewBoundedQueue[int](2)
+	q.Enqueue(1)
+	q.Enqueue(2)
+	overflow := q.Enqueue(3)
+
+	return map[string]any{
+		"deque":          d.ToSlice(),
+		"popped_back":    back,
+		"stack_peek_2":   s.PeekN(2),
+		"stack_drain":    s.Drain(),
+		"queue_overflow": overflow != nil,
+		"queue_drain":    q.Drain(),
+	}
+}
@@ -0,0 +1,300 @@
+package deque
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestDequeBothEnds(t *testing.T) {
+	d := NewDeque[int]()
+
+	d.PushBack(2)
+	d.PushBack(3)
+	d.PushFront(1)
+	d.PushFront(0)
+
+	if !reflect.DeepEqual(d.ToSlice(), []int{0, 1, 2, 3}) {
+		t.Errorf("Expected [0 1 2 3], got %v", d.ToSlice())
+	}
+
+	front, err := d.PopFront()
+	if err != nil || front != 0 {
+		t.Errorf("Expected front 0, got %d (%v)", front, err)
+	}
+
+	back, err := d.PopBack()
+	if err != nil || back != 3 {
+		t.Errorf("Expected back 3, got %d (%v)", back, err)
+	}
+
+	if value, _ := d.At(1); value != 2 {
+		t.Errorf("Expected At(1) = 2, got %d", value)
+	}
+	if _, err := d.At(5); err == nil {
+		t.Error("Expected error for out of bounds index")
+	}
+}
+
+func TestDequeEmpty(t *testing.T) {
+	d := NewDeque[string]()
+
+	if _, err := d.PopFront(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty, got %v", err)
+	}
+	if _, err := d.PopBack(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty, got %v", err)
+	}
+	if _, err := d.Front(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty, got %v", err)
+	}
+	if _, err := d.Back(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty, got %v", err)
+	}
+	if len(d.PeekN(3)) != 0 {
+		t.Error("Expected PeekN on empty deque to be empty")
+	}
+}
+
+func TestDequeGrowAndShrink(t *testing.T) {
+	d := NewDeque[int]()
+
+	for i := range 1000 {
+		if i%2 == 0 {
+			d.PushBack(i)
+		} else {
+			d.PushFront(i)
+		}
+	}
+
+	if d.Size() != 1000 {
+		t.Errorf("Expected size 1000, got %d", d.Size())
+	}
+	if d.Capacity() < 1000 {
+		t.Errorf("Expected capacity >= 1000, got %d", d.Capacity())
+	}
+
+	grown := d.Capacity()
+	for range 990 {
+		d.PopFront()
+	}
+
+	if d.Capacity() >= grown {
+		t.Errorf("Expected capacity to shrink below %d, got %d", grown, d.Capacity())
+	}
+	if d.Capacity() < d.Size() {
+		t.Errorf("Capacity %d smaller than size %d", d.Capacity(), d.Size())
+	}
+}
+
+func TestDequeMatchesSliceModel(t *testing.T) {
+	d := NewDeque[int]()
+	model := []int{}
+
+	for i := range 5000 {
+		switch i % 7 {
+		case 0, 1, 2:
+			d.PushBack(i)
+			model = append(model, i)
+		case 3, 4:
+			d.PushFront(i)
+			model = append([]int{i}, model...)
+		case 5:
+			value, err := d.PopFront()
+			if len(model) == 0 {
+				if err == nil {
+					t.Fatal("Expected error on empty deque")
+				}
+				continue
+			}
+			if value != model[0] {
+				t.Fatalf("Step %d: expected %d, got %d", i, model[0], value)
+			}
+			model = model[1:]
+		case 6:
+			value, err := d.PopBack()
+			if len(model) == 0 {
+				if err == nil {
+					t.Fatal("Expected error on empty deque")
+				}
+				continue
+			}
+			if value != model[len(model)-1] {
+				t.Fatalf("Step %d: expected %d, got %d", i, model[len(model)-1], value)
+			}
+			model = model[:len(model)-1]
+		}
+	}
+
+	if !reflect.DeepEqual(d.ToSlice(), model) {
+		t.Error("Deque contents diverged from slice model")
+	}
+}
+
+func TestDequeIterators(t *testing.T) {
+	d := NewDeque[int]()
+	for i := range 5 {
+		d.PushBack(i)
+	}
+
+	if values := slices.Collect(d.Values()); !reflect.DeepEqual(values, []int{0, 1, 2, 3, 4}) {
+		t.Errorf("Expected forward values, got %v", values)
+	}
+
+	backward := []int{}
+	for i, value := range d.Backward() {
+		if i != value {
+			t.Errorf("Expected index %d to hold %d", i, value)
+		}
+		backward = append(backward, value)
+		if len(backward) == 3 {
+			break
+		}
+	}
+	if !reflect.DeepEqual(backward, []int{4, 3, 2}) {
+		t.Errorf("Expected [4 3 2], got %v", backward)
+	}
+
+	if drained := d.Drain(); len(drained) != 5 || !d.IsEmpty() {
+		t.Errorf("Expected drain of 5 items leaving empty deque, got %v", drained)
+	}
+}
+
+func TestBoundedDeque(t *testing.T) {
+	d := NewBoundedDeque[int](3)
+
+	for i := range 3 {
+		if err := d.PushBack(i); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	if !d.IsFull() {
+		t.Error("Expected bounded deque to be full")
+	}
+	if err := d.PushFront(9); !errors.Is(err, ErrFull) {
+		t.Errorf("Expected ErrFull, got %v", err)
+	}
+
+	d.PopFront()
+	if err := d.PushFront(9); err != nil {
+		t.Errorf("Unexpected error after pop: %v", err)
+	}
+	if !reflect.DeepEqual(d.ToSlice(), []int{9, 1, 2}) {
+		t.Errorf("Expected [9 1 2], got %v", d.ToSlice())
+	}
+
+	large := NewBoundedDeque[int](20)
+	for i := range 20 {
+		large.PushBack(i)
+	}
+	if large.Capacity() != 20 {
+		t.Errorf("Expected capacity capped at 20, got %d", large.Capacity())
+	}
+}
+
+func TestStack(t *testing.T) {
+	s := NewStack[string]()
+	for _, item := range []string{"a", "b", "c", "d"} {
+		s.Push(item)
+	}
+
+	if top, _ := s.Peek(); top != "d" {
+		t.Errorf("Expected top d, got %s", top)
+	}
+	if peek := s.PeekN(2); !reflect.DeepEqual(peek, []string{"d", "c"}) {
+		t.Errorf("Expected [d c], got %v", peek)
+	}
+	if all := slices.Collect(s.All()); !reflect.DeepEqual(all, []string{"d", "c", "b", "a"}) {
+		t.Errorf("Expected [d c b a], got %v", all)
+	}
+
+	popped, _ := s.Pop()
+	if popped != "d" {
+		t.Errorf("Expected d, got %s", popped)
+	}
+
+	if drained := s.Drain(); !reflect.DeepEqual(drained, []string{"c", "b", "a"}) {
+		t.Errorf("Expected [c b a], got %v", drained)
+	}
+	if _, err := s.Pop(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty, got %v", err)
+	}
+
+	bounded := NewBoundedStack[int](1)
+	bounded.Push(1)
+	if err := bounded.Push(2); !errors.Is(err, ErrFull) {
+		t.Errorf("Expected ErrFull, got %v", err)
+	}
+}
+
+func TestQueue(t *testing.T) {
+	q := NewQueue[int]()
+	for i := range 4 {
+		q.Enqueue(i)
+	}
+
+	if front, _ := q.Front(); front != 0 {
+		t.Errorf("Expected front 0, got %d", front)
+	}
+	if peek := q.PeekN(10); !reflect.DeepEqual(peek, []int{0, 1, 2, 3}) {
+		t.Errorf("Expected [0 1 2 3], got %v", peek)
+	}
+
+	value, _ := q.Dequeue()
+	if value != 0 {
+		t.Errorf("Expected 0, got %d", value)
+	}
+	if all := slices.Collect(q.All()); !reflect.DeepEqual(all, []int{1, 2, 3}) {
+		t.Errorf("Expected [1 2 3], got %v", all)
+	}
+
+	bounded := NewBoundedQueue[int](2)
+	bounded.Enqueue(1)
+	bounded.Enqueue(2)
+	if err := bounded.Enqueue(3); !errors.Is(err, ErrFull) {
+		t.Errorf("Expected ErrFull, got %v", err)
+	}
+	if drained := bounded.Drain(); !reflect.DeepEqual(drained, []int{1, 2}) {
+		t.Errorf("Expected [1 2], got %v", drained)
+	}
+}
+
+func TestRun(t *testing.T) {
+	result := Run()
+	if result == nil {
+		t.Error("Expected non-nil result")
+	}
+}
+
+func BenchmarkRun(b *testing.B) {
+	for b.Loop() {
+		Run()
+	}
+}
+
+func BenchmarkDequePushPop(b *testing.B) {
+	d := NewDeque[int]()
+
+	for b.Loop() {
+		for i := range 1000 {
+			d.PushBack(i)
+		}
+		for range 1000 {
+			d.PopFront()
+		}
+	}
+}
+
+func BenchmarkSliceQueuePushPop(b *testing.B) {
+	for b.Loop() {
+		queue := []int{}
+		for i := range 1000 {
+			queue = append(queue, i)
+		}
+		for len(queue) > 0 {
+			queue = queue[1:]
+		}
+	}
+}
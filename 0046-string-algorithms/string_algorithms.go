@@ -0,0 +1,388 @@
+package string_algorithms
+
+import (
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+)
+
+func saIs(s []int, upper int) []int {
+	n := len(s)
+	switch n {
+	case 0:
+		return []int{}
+	case 1:
+		return []int{0}
+	case 2:
+		if s[0] < s[1] {
+			return []int{0, 1}
+		}
+		return []int{1, 0}
+	}
+
+	sa := make([]int, n)
+	ls := make([]bool, n)
+	for i := n - 2; i >= 0; i-- {
+		if s[i] == s[i+1] {
+			ls[i] = ls[i+1]
+		} else {
+			ls[i] = s[i] < s[i+1]
+		}
+	}
+
+	sumL := make([]int, upper+1)
+	sumS := make([]int, upper+1)
+	for i := range n {
+		if !ls[i] {
+			sumS[s[i]]++
+		} else {
+			sumL[s[i]+1]++
+		}
+	}
+	for i := 0; i <= upper; i++ {
+		sumS[i] += sumL[i]
+		if i < upper {
+			sumL[i+1] += sumS[i]
+		}
+	}
+
+	buf := make([]int, upper+1)
+	induce := func(lms []int) {
+		for i := range sa {
+			sa[i] = -1
+		}
+
+		copy(buf, sumS)
+		for _, d := range lms {
+			if d == n {
+				continue
+			}
+			sa[buf[s[d]]] = d
+			buf[s[d]]++
+		}
+
+		copy(buf, sumL)
+		sa[buf[s[n-1]]] = n - 1
+		buf[s[n-1]]++
+		for i := range n {
+			v := sa[i]
+			if v >= 1 && !ls[v-1] {
+				sa[buf[s[v-1]]] = v - 1
+				buf[s[v-1]]++
+			}
+		}
+
+		copy(buf, sumL)
+		for i := n - 1; i >= 0; i-- {
+			v := sa[i]
+			if v >= 1 && ls[v-1] {
+				buf[s[v-1]+1]--
+				sa[buf[s[v-1]+1]] = v - 1
+			}
+		}
+	}
+
+	lmsMap := make([]int, n+1)
+	for i := range lmsMap {
+		lmsMap[i] = -1
+	}
+	var lms []int
+	for i := 1; i < n; i++ {
+		if !ls[i-1] && ls[i] {
+			lmsMap[i] = len(lms)
+			lms = append(lms, i)
+		}
+	}
+	m := len(lms)
+
+	induce(lms)
+
+	if m > 0 {
+		sortedLms := make([]int, 0, m)
+		for _, v := range sa {
+			if lmsMap[v] != -1 {
+				sortedLms = append(sortedLms, v)
+			}
+		}
+
+		recS := make([]int, m)
+		recUpper := 0
+		recS[lmsMap[sortedLms[0]]] = 0
+		for i := 1; i < m; i++ {
+			l, r := sortedLms[i-1], sortedLms[i]
+			endL, endR := n, n
+			if lmsMap[l]+1 < m {
+				endL = lms[lmsMap[l]+1]
+			}
+			if lmsMap[r]+1 < m {
+				endR = lms[lmsMap[r]+1]
+			}
+
+			same := endL-l == endR-r
+			if same {
+				for l < endL && s[l] == s[r] {
+					l++
+					r++
+				}
+				if l == n || s[l] != s[r] {
+					same = false
+				}
+			}
+			if !same {
+				recUpper++
+			}
+			recS[lmsMap[sortedLms[i]]] = recUpper
+		}
+
+		var recSA []int
+		if recUpper == m-1 {
+			recSA = make([]int, m)
+			for i, name := range recS {
+				recSA[name] = i
+			}
+		} else {
+			recSA = saIs(recS, recUpper)
+		}
+		for i := range m {
+			sortedLms[i] = lms[recSA[i]]
+		}
+		induce(sortedLms)
+	}
+
+	return sa
+}
+
+func kasai(s []int, sa []int) []int {
+	n := len(s)
+	rank := make([]int, n)
+	for i, suffix := range sa {
+		rank[suffix] = i
+	}
+
+	lcp := make([]int, n)
+	h := 0
+	for i := range n {
+		if rank[i] == 0 {
+			h = 0
+			continue
+		}
+		j := sa[rank[i]-1]
+		for i+h < n && j+h < n && s[i+h] == s[j+h] {
+			h++
+		}
+		lcp[rank[i]] = h
+		if h > 0 {
+			h--
+		}
+	}
+	return lcp
+}
+
+func bytesToInts(text string) []int {
+	s := make([]int, len(text))
+	for i := range len(text) {
+		s[i] = int(text[i])
+	}
+	return s
+}
+
+type SuffixArray struct {
+	text string
+	sa   []int
+	lcp  []int
+}
+
+func NewSuffixArray(text string) *SuffixArray {
+	s := bytesToInts(text)
+	sa := saIs(s, 255)
+	return &SuffixArray{text: text, sa: sa, lcp: kasai(s, sa)}
+}
+
+func (a *SuffixArray) Text() string {
+	return a.text
+}
+
+func (a *SuffixArray) Suffixes() []int {
+	return slices.Clone(a.sa)
+}
+
+func (a *SuffixArray) LCP() []int {
+	return slices.Clone(a.lcp)
+}
+
+func (a *SuffixArray) bounds(pattern string) (int, int) {
+	compare := func(i int) int {
+		suffix := a.text[a.sa[i]:]
+		if len(suffix) > len(pattern) {
+			suffix = suffix[:len(pattern)]
+		}
+		return strings.Compare(suffix, pattern)
+	}
+
+	low := sort.Search(len(a.sa), func(i int) bool { return compare(i) >= 0 })
+	high := sort.Search(len(a.sa), func(i int) bool { return compare(i) > 0 })
+	return low, high
+}
+
+func (a *SuffixArray) Lookup(pattern string) []int {
+	low, high := a.bounds(pattern)
+	positions := slices.Clone(a.sa[low:high])
+	sort.Ints(positions)
+	return positions
+}
+
+func (a *SuffixArray) Count(pattern string) int {
+	low, high := a.bounds(pattern)
+	return high - low
+}
+
+func (a *SuffixArray) Contains(pattern string) bool {
+	return a.Count(pattern) > 0
+}
+
+func (a *SuffixArray) LongestRepeatedSubstring() string {
+	best, at := 0, 0
+	for i, length := range a.lcp {
+		if length > best {
+			best, at = length, a.sa[i]
+		}
+	}
+	return a.text[at : at+best]
+}
+
+func (a *SuffixArray) CountDistinctSubstrings() int {
+	n := len(a.text)
+	total := n * (n + 1) / 2
+	for _, length := range a.lcp {
+		total -= length
+	}
+	return total
+}
+
+func LongestCommonSubstring(a, b string) string {
+	s := make([]int, 0, len(a)+len(b)+1)
+	for i := range len(a) {
+		s = append(s, int(a[i])+1)
+	}
+	s = append(s, 0)
+	for i := range len(b) {
+		s = append(s, int(b[i])+1)
+	}
+
+	sa := saIs(s, 256)
+	lcp := kasai(s, sa)
+
+	best, at := 0, 0
+	for i := 1; i < len(sa); i++ {
+		inA, prevInA := sa[i] < len(a), sa[i-1] < len(a)
+		if inA != prevInA && lcp[i] > best {
+			best, at = lcp[i], min(sa[i], sa[i-1])
+		}
+	}
+	return a[at : at+best]
+}
+
+type samState struct {
+	length int
+	link   int
+	next   map[byte]int
+}
+
+type SuffixAutomaton struct {
+	states []samState
+	last   int
+}
+
+func NewSuffixAutomaton(text string) *SuffixAutomaton {
+	sam := &SuffixAutomaton{
+		states: []samState{{link: -1, next: make(map[byte]int)}},
+	}
+	for i := range len(text) {
+		sam.Extend(text[i])
+	}
+	return sam
+}
+
+func (sam *SuffixAutomaton) Extend(c byte) {
+	current := len(sam.states)
+	sam.states = append(sam.states, samState{
+		length: sam.states[sam.last].length + 1,
+		next:   make(map[byte]int),
+	})
+
+	p := sam.last
+	for p != -1 {
+		if _, ok := sam.states[p].next[c]; ok {
+			break
+		}
+		sam.states[p].next[c] = current
+		p = sam.states[p].link
+	}
+
+	switch {
+	case p == -1:
+		sam.states[current].link = 0
+	case sam.states[sam.states[p].next[c]].length == sam.states[p].length+1:
+		sam.states[current].link = sam.states[p].next[c]
+	default:
+		q := sam.states[p].next[c]
+		clone := len(sam.states)
+		sam.states = append(sam.states, samState{
+			length: sam.states[p].length + 1,
+			link:   sam.states[q].link,
+			next:   maps.Clone(sam.states[q].next),
+		})
+		for p != -1 && sam.states[p].next[c] == q {
+			sam.states[p].next[c] = clone
+			p = sam.states[p].link
+		}
+		sam.states[q].link = clone
+		sam.states[current].link = clone
+	}
+
+	sam.last = current
+}
+
+func (sam *SuffixAutomaton) Contains(pattern string) bool {
+	state := 0
+	for i := range len(pattern) {
+		next, ok := sam.states[state].next[pattern[i]]
+		if !ok {
+			return false
+		}
+		state = next
+	}
+	return true
+}
+
+func (sam *SuffixAutomaton) CountDistinctSubstrings() int {
+	total := 0
+	for _, state := range sam.states[1:] {
+		total += state.length - sam.states[state.link].length
+	}
+	return total
+}
+
+func (sam *SuffixAutomaton) Size() int {
+	return len(sam.states)
+}
+
+func Run() any {
+	text := "banana"
+	sa := NewSuffixArray(text)
+	sam := NewSuffixAutomaton(text)
+
+	return map[string]any{
+		"text":                       text,
+		"suffix_array":               sa.Suffixes(),
+		"lcp_array":                  sa.LCP(),
+		"lookup_ana":                 sa.Lookup("ana"),
+		"count_a":                    sa.Count("a"),
+		"longest_repeated_substring": sa.LongestRepeatedSubstring(),
+		"longest_common_substring":   LongestCommonSubstring("xabxac", "abcabxabcd"),
+		"distinct_substrings_sa":     sa.CountDistinctSubstrings(),
+		"distinct_substrings_sam":    sam.CountDistinctSubstrings(),
+		"automaton_states":           sam.Size(),
+	}
+}
@@ -0,0 +1,255 @@
+package string_algorithms
+
+import (
+	"math/rand"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/celj/dsa/0019-trie"
+)
+
+func naiveSuffixArray(text string) []int {
+	sa := make([]int, len(text))
+	for i := range sa {
+		sa[i] = i
+	}
+	slices.SortFunc(sa, func(a, b int) int { return strings.Compare(text[a:], text[b:]) })
+	return sa
+}
+
+func randomText(rng *rand.Rand, n int, alphabet string) string {
+	var b strings.Builder
+	for range n {
+		b.WriteByte(alphabet[rng.Intn(len(alphabet))])
+	}
+	return b.String()
+}
+
+func TestSuffixArrayBanana(t *testing.T) {
+	sa := NewSuffixArray("banana")
+
+	if expected := []int{5, 3, 1, 0, 4, 2}; !reflect.DeepEqual(sa.Suffixes(), expected) {
+		t.Errorf("Suffixes() = %v, expected %v", sa.Suffixes(), expected)
+	}
+	if expected := []int{0, 1, 3, 0, 0, 2}; !reflect.DeepEqual(sa.LCP(), expected) {
+		t.Errorf("LCP() = %v, expected %v", sa.LCP(), expected)
+	}
+	if got := sa.Lookup("ana"); !reflect.DeepEqual(got, []int{1, 3}) {
+		t.Errorf("Lookup(ana) = %v, expected [1 3]", got)
+	}
+	if got := sa.Count("n"); got != 2 {
+		t.Errorf("Count(n) = %d, expected 2", got)
+	}
+	if sa.Contains("nab") || !sa.Contains("banana") || !sa.Contains("") {
+		t.Error("Contains gave the wrong answer")
+	}
+	if got := sa.LongestRepeatedSubstring(); got != "ana" {
+		t.Errorf("LongestRepeatedSubstring() = %q, expected ana", got)
+	}
+}
+
+func TestSuffixArrayMatchesNaive(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	texts := []string{"", "a", "ab", "ba", "aaaaaaaa", "mississippi", "abracadabra", "\x00\xff\x00\xff"}
+	for range 200 {
+		texts = append(texts, randomText(rng, rng.Intn(60), "ab"))
+		texts = append(texts, randomText(rng, rng.Intn(60), "abcd"))
+		texts = append(texts, randomText(rng, rng.Intn(200), "abcdefghijklmnopqrstuvwxyz"))
+	}
+
+	for _, text := range texts {
+		sa := NewSuffixArray(text)
+		expected := naiveSuffixArray(text)
+		if !reflect.DeepEqual(sa.Suffixes(), expected) {
+			t.Fatalf("Suffixes(%q) = %v, expected %v", text, sa.Suffixes(), expected)
+		}
+
+		lcp := sa.LCP()
+		for i := 1; i < len(expected); i++ {
+			a, b := text[expected[i-1]:], text[expected[i]:]
+			n := 0
+			for n < len(a) && n < len(b) && a[n] == b[n] {
+				n++
+			}
+			if lcp[i] != n {
+				t.Fatalf("LCP(%q)[%d] = %d, expected %d", text, i, lcp[i], n)
+			}
+		}
+	}
+}
+
+func TestSearchCrossCheckedWithTrie(t *testing.T) {
+	rng := rand.New(rand.NewSource(5))
+
+	for range 50 {
+		text := randomText(rng, 1+rng.Intn(40), "abc")
+		sa := NewSuffixArray(text)
+
+		suffixes := trie.NewTrieWithOptions(trie.TrieOptions{CaseSensitive: true})
+		for i := range len(text) {
+			suffixes.Insert(text[i:])
+		}
+
+		for range 20 {
+			pattern := randomText(rng, 1+rng.Intn(4), "abc")
+			if sa.Contains(pattern) != suffixes.StartsWith(pattern) {
+				t.Fatalf("Contains(%q) in %q disagrees with the suffix trie", pattern, text)
+			}
+			if got, expected := sa.Count(pattern), suffixes.CountWordsWithPrefix(pattern); got != expected {
+				t.Fatalf("Count(%q) in %q = %d, suffix trie says %d", pattern, text, got, expected)
+			}
+		}
+
+		distinct := suffixes.MemoryStats().Nodes - 1
+		if got := sa.CountDistinctSubstrings(); got != distinct {
+			t.Fatalf("CountDistinctSubstrings(%q) = %d, suffix trie has %d nodes", text, got, distinct)
+		}
+		if got := NewSuffixAutomaton(text).CountDistinctSubstrings(); got != distinct {
+			t.Fatalf("SuffixAutomaton.CountDistinctSubstrings(%q) = %d, suffix trie has %d nodes", text, got, distinct)
+		}
+	}
+}
+
+func TestLookupPositions(t *testing.T) {
+	text := "abracadabra abracadabra"
+	sa := NewSuffixArray(text)
+
+	for _, pattern := range []string{"abra", "a", "cad", "ra a", "zzz"} {
+		var expected []int
+		for i := 0; i+len(pattern) <= len(text); i++ {
+			if text[i:i+len(pattern)] == pattern {
+				expected = append(expected, i)
+			}
+		}
+		if got := sa.Lookup(pattern); len(got) != len(expected) || (len(got) > 0 && !reflect.DeepEqual(got, expected)) {
+			t.Errorf("Lookup(%q) = %v, expected %v", pattern, got, expected)
+		}
+	}
+}
+
+func TestLongestCommonSubstring(t *testing.T) {
+	testCases := []struct {
+		a, b     string
+		expected string
+	}{
+		{"xabxac", "abcabxabcd", "abxa"},
+		{"GeeksforGeeks", "GeeksQuiz", "Geeks"},
+		{"abc", "xyz", ""},
+		{"", "abc", ""},
+		{"same", "same", "same"},
+	}
+
+	for _, tc := range testCases {
+		if got := LongestCommonSubstring(tc.a, tc.b); got != tc.expected {
+			t.Errorf("LongestCommonSubstring(%q, %q) = %q, expected %q", tc.a, tc.b, got, tc.expected)
+		}
+	}
+}
+
+func TestLongestSubstringsMatchBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(9))
+
+	for range 100 {
+		a := randomText(rng, rng.Intn(25), "ab")
+		b := randomText(rng, rng.Intn(25), "ab")
+
+		best := 0
+		for i := range len(a) {
+			for j := range len(b) {
+				n := 0
+				for i+n < len(a) && j+n < len(b) && a[i+n] == b[j+n] {
+					n++
+				}
+				best = max(best, n)
+			}
+		}
+		got := LongestCommonSubstring(a, b)
+		if len(got) != best || !strings.Contains(a, got) || !strings.Contains(b, got) {
+			t.Fatalf("LongestCommonSubstring(%q, %q) = %q, expected length %d", a, b, got, best)
+		}
+
+		repeated := 0
+		for length := 1; length < len(a); length++ {
+			for i := 0; i+length <= len(a); i++ {
+				if strings.Contains(a[i+1:], a[i:i+length]) {
+					repeated = length
+				}
+			}
+		}
+		if got := NewSuffixArray(a).LongestRepeatedSubstring(); len(got) != repeated {
+			t.Fatalf("LongestRepeatedSubstring(%q) = %q, expected length %d", a, got, repeated)
+		}
+	}
+}
+
+func TestSuffixAutomaton(t *testing.T) {
+	sam := NewSuffixAutomaton("abcbc")
+
+	for _, pattern := range []string{"", "a", "bcb", "cbc", "abcbc"} {
+		if !sam.Contains(pattern) {
+			t.Errorf("Contains(%q) should be true", pattern)
+		}
+	}
+	for _, pattern := range []string{"ac", "cc", "abcbcb"} {
+		if sam.Contains(pattern) {
+			t.Errorf("Contains(%q) should be false", pattern)
+		}
+	}
+
+	if got := sam.CountDistinctSubstrings(); got != 12 {
+		t.Errorf("CountDistinctSubstrings() = %d, expected 12", got)
+	}
+	if sam.Size() > 2*5 {
+		t.Errorf("automaton has %d states, expected at most 2n", sam.Size())
+	}
+
+	sam.Extend('a')
+	if !sam.Contains("bca") {
+		t.Error("Extend should make new substrings reachable")
+	}
+}
+
+func TestRun(t *testing.T) {
+	result := Run()
+	if result == nil {
+		t.Error("Expected non-nil result")
+	}
+
+	resultMap, ok := result.(map[string]any)
+	if !ok {
+		t.Fatal("Expected result to be a map")
+	}
+	if resultMap["distinct_substrings_sa"] != resultMap["distinct_substrings_sam"] {
+		t.Errorf("suffix array and automaton disagree: %v vs %v",
+			resultMap["distinct_substrings_sa"], resultMap["distinct_substrings_sam"])
+	}
+}
+
+func BenchmarkSuffixArray(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	text := randomText(rng, 100000, "acgt")
+
+	for b.Loop() {
+		NewSuffixArray(text)
+	}
+}
+
+func BenchmarkSuffixAutomaton(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	text := randomText(rng, 100000, "acgt")
+
+	for b.Loop() {
+		NewSuffixAutomaton(text)
+	}
+}
+
+func BenchmarkLookup(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	sa := NewSuffixArray(randomText(rng, 100000, "acgt"))
+
+	for b.Loop() {
+		sa.Lookup("acgtac")
+	}
+}
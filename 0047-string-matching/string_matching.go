@@ -0,0 +1,439 @@
+package string_matching
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"slices"
+)
+
+const scanChunkSize = 64 * 1024
+
+type Match struct {
+	Pattern string
+	Offset  int
+}
+
+type Matcher interface {
+	Index(text string) int
+	FindAll(text string) []Match
+	Scan(r io.Reader, visit func(Match) bool) error
+}
+
+type finder interface {
+	find(text string, minEnd int, visit func(Match) bool) bool
+	maxLength() int
+}
+
+func index(f finder, text string, emptyPattern bool) int {
+	if emptyPattern {
+		return 0
+	}
+
+	offset := -1
+	f.find(text, 0, func(match Match) bool {
+		offset = match.Offset
+		return false
+	})
+	return offset
+}
+
+func findAll(f finder, text string) []Match {
+	matches := []Match{}
+	f.find(text, 0, func(match Match) bool {
+		matches = append(matches, match)
+		return true
+	})
+	return matches
+}
+
+func scanWindows(r io.Reader, f finder, visit func(Match) bool) error {
+	overlap := f.maxLength() - 1
+	if overlap < 0 {
+		return nil
+	}
+
+	buf := make([]byte, 0, max(scanChunkSize, 2*(overlap+1)))
+	base := 0
+
+	for {
+		carry := len(buf)
+		n, err := io.ReadFull(r, buf[carry:cap(buf)])
+		buf = buf[:carry+n]
+
+		if n > 0 {
+			stopped := !f.find(string(buf), carry, func(match Match) bool {
+				match.Offset += base
+				return visit(match)
+			})
+			if stopped {
+				return nil
+			}
+
+			keep := min(overlap, len(buf))
+			base += len(buf) - keep
+			buf = append(buf[:0], buf[len(buf)-keep:]...)
+		}
+
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+type KMP struct {
+	pattern string
+	failure []int
+}
+
+func NewKMP(pattern string) *KMP {
+	failure := make([]int, len(pattern))
+	k := 0
+	for i := 1; i < len(pattern); i++ {
+		for k > 0 && pattern[i] != pattern[k] {
+			k = failure[k-1]
+		}
+		if pattern[i] == pattern[k] {
+			k++
+		}
+		failure[i] = k
+	}
+	return &KMP{pattern: pattern, failure: failure}
+}
+
+func (m *KMP) step(state int, c byte) int {
+	for state > 0 && c != m.pattern[state] {
+		state = m.failure[state-1]
+	}
+	if c == m.pattern[state] {
+		state++
+	}
+	return state
+}
+
+func (m *KMP) find(text string, minEnd int, visit func(Match) bool) bool {
+	if m.pattern == "" {
+		return true
+	}
+
+	state := 0
+	for i := range len(text) {
+		state = m.step(state, text[i])
+		if state == len(m.pattern) {
+			state = m.failure[state-1]
+			if i+1 > minEnd && !visit(Match{Pattern: m.pattern, Offset: i + 1 - len(m.pattern)}) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (m *KMP) maxLength() int {
+	return len(m.pattern)
+}
+
+func (m *KMP) Index(text string) int {
+	return index(m, text, m.pattern == "")
+}
+
+func (m *KMP) FindAll(text string) []Match {
+	return findAll(m, text)
+}
+
+func (m *KMP) Scan(r io.Reader, visit func(Match) bool) error {
+	if m.pattern == "" {
+		return nil
+	}
+
+	reader, ok := r.(io.ByteReader)
+	if !ok {
+		reader = bufio.NewReader(r)
+	}
+
+	state := 0
+	for offset := 0; ; offset++ {
+		c, err := reader.ReadByte()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		state = m.step(state, c)
+		if state == len(m.pattern) {
+			state = m.failure[state-1]
+			if !visit(Match{Pattern: m.pattern, Offset: offset + 1 - len(m.pattern)}) {
+				return nil
+			}
+		}
+	}
+}
+
+type ZMatcher struct {
+	pattern string
+	z       []int
+}
+
+func ZFunction(s string) []int {
+	z := make([]int, len(s))
+	if len(s) == 0 {
+		return z
+	}
+
+	z[0] = len(s)
+	l, r := 0, 0
+	for i := 1; i < len(s); i++ {
+		if i < r {
+			z[i] = min(z[i-l], r-i)
+		}
+		for i+z[i] < len(s) && s[z[i]] == s[i+z[i]] {
+			z[i]++
+		}
+		if i+z[i] > r {
+			l, r = i, i+z[i]
+		}
+	}
+	return z
+}
+
+func NewZMatcher(pattern string) *ZMatcher {
+	return &ZMatcher{pattern: pattern, z: ZFunction(pattern)}
+}
+
+func (m *ZMatcher) find(text string, minEnd int, visit func(Match) bool) bool {
+	size := len(m.pattern)
+	if size == 0 {
+		return true
+	}
+
+	l, r := 0, 0
+	for i := range len(text) {
+		k := 0
+		if i < r {
+			k = min(m.z[i-l], r-i)
+		}
+		for k < size && i+k < len(text) && text[i+k] == m.pattern[k] {
+			k++
+		}
+		if i+k > r {
+			l, r = i, i+k
+		}
+		if k == size && i+size > minEnd && !visit(Match{Pattern: m.pattern, Offset: i}) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *ZMatcher) maxLength() int {
+	return len(m.pattern)
+}
+
+func (m *ZMatcher) Index(text string) int {
+	return index(m, text, m.pattern == "")
+}
+
+func (m *ZMatcher) FindAll(text string) []Match {
+	return findAll(m, text)
+}
+
+func (m *ZMatcher) Scan(r io.Reader, visit func(Match) bool) error {
+	return scanWindows(r, m, visit)
+}
+
+type Horspool struct {
+	pattern string
+	shift   [256]int
+}
+
+func NewHorspool(pattern string) *Horspool {
+	m := &Horspool{pattern: pattern}
+	for i := range m.shift {
+		m.shift[i] = len(pattern)
+	}
+	for i := 0; i < len(pattern)-1; i++ {
+		m.shift[pattern[i]] = len(pattern) - 1 - i
+	}
+	return m
+}
+
+func (m *Horspool) find(text string, minEnd int, visit func(Match) bool) bool {
+	size := len(m.pattern)
+	if size == 0 {
+		return true
+	}
+
+	last := m.pattern[size-1]
+	for i := 0; i+size <= len(text); {
+		c := text[i+size-1]
+		if c == last && text[i:i+size-1] == m.pattern[:size-1] && i+size > minEnd {
+			if !visit(Match{Pattern: m.pattern, Offset: i}) {
+				return false
+			}
+		}
+		i += m.shift[c]
+	}
+	return true
+}
+
+func (m *Horspool) maxLength() int {
+	return len(m.pattern)
+}
+
+func (m *Horspool) Index(text string) int {
+	return index(m, text, m.pattern == "")
+}
+
+func (m *Horspool) FindAll(text string) []Match {
+	return findAll(m, text)
+}
+
+func (m *Horspool) Scan(r io.Reader, visit func(Match) bool) error {
+	return scanWindows(r, m, visit)
+}
+
+const rabinKarpBase = 1099511628211
+
+type rabinKarpGroup struct {
+	length   int
+	power    uint64
+	patterns map[uint64][]string
+}
+
+type RabinKarp struct {
+	groups []rabinKarpGroup
+	empty  bool
+}
+
+func rollingHash(s string) uint64 {
+	var hash uint64
+	for i := range len(s) {
+		hash = hash*rabinKarpBase + uint64(s[i])
+	}
+	return hash
+}
+
+func NewRabinKarp(patterns ...string) *RabinKarp {
+	byLength := make(map[int]*rabinKarpGroup)
+	seen := make(map[string]bool)
+	var lengths []int
+	empty := false
+
+	for _, pattern := range patterns {
+		if pattern == "" {
+			empty = true
+			continue
+		}
+		if seen[pattern] {
+			continue
+		}
+		seen[pattern] = true
+
+		group, ok := byLength[len(pattern)]
+		if !ok {
+			power := uint64(1)
+			for range len(pattern) - 1 {
+				power *= rabinKarpBase
+			}
+			group = &rabinKarpGroup{length: len(pattern), power: power, patterns: make(map[uint64][]string)}
+			byLength[len(pattern)] = group
+			lengths = append(lengths, len(pattern))
+		}
+		hash := rollingHash(pattern)
+		group.patterns[hash] = append(group.patterns[hash], pattern)
+	}
+
+	slices.Sort(lengths)
+	m := &RabinKarp{empty: empty}
+	for _, length := range lengths {
+		m.groups = append(m.groups, *byLength[length])
+	}
+	return m
+}
+
+func (m *RabinKarp) find(text string, minEnd int, visit func(Match) bool) bool {
+	hashes := make([]uint64, len(m.groups))
+	for g, group := range m.groups {
+		if group.length <= len(text) {
+			hashes[g] = rollingHash(text[:group.length])
+		}
+	}
+
+	for i := range len(text) {
+		for g, group := range m.groups {
+			end := i + group.length
+			if end > len(text) {
+				break
+			}
+			if i > 0 {
+				hashes[g] = (hashes[g]-uint64(text[i-1])*group.power)*rabinKarpBase + uint64(text[end-1])
+			}
+			if end <= minEnd {
+				continue
+			}
+			for _, pattern := range group.patterns[hashes[g]] {
+				if text[i:end] == pattern && !visit(Match{Pattern: pattern, Offset: i}) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+func (m *RabinKarp) maxLength() int {
+	if len(m.groups) == 0 {
+		return 0
+	}
+	return m.groups[len(m.groups)-1].length
+}
+
+func (m *RabinKarp) Index(text string) int {
+	return index(m, text, m.empty)
+}
+
+func (m *RabinKarp) FindAll(text string) []Match {
+	return findAll(m, text)
+}
+
+func (m *RabinKarp) Scan(r io.Reader, visit func(Match) bool) error {
+	return scanWindows(r, m, visit)
+}
+
+func Run() any {
+	text := "abracadabra abracadabra"
+	pattern := "abra"
+
+	matchers := map[string]Matcher{
+		"kmp":        NewKMP(pattern),
+		"z":          NewZMatcher(pattern),
+		"horspool":   NewHorspool(pattern),
+		"rabin_karp": NewRabinKarp(pattern),
+	}
+
+	results := make(map[string]any)
+	for name, matcher := range matchers {
+		var offsets []int
+		for _, match := range matcher.FindAll(text) {
+			offsets = append(offsets, match.Offset)
+		}
+		results[name] = offsets
+	}
+
+	var multi []string
+	for _, match := range NewRabinKarp("cad", "bra", "a a").FindAll(text) {
+		multi = append(multi, match.Pattern)
+	}
+
+	results["text"] = text
+	results["pattern"] = pattern
+	results["kmp_failure"] = NewKMP(pattern).failure
+	results["z_function"] = ZFunction("aabxaab")
+	results["rabin_karp_multi"] = multi
+	return results
+}
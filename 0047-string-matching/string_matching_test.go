@@ -0,0 +1,250 @@
+package string_matching
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func naiveFindAll(text string, patterns ...string) []Match {
+	matches := []Match{}
+	for i := range len(text) {
+		for _, pattern := range patterns {
+			if pattern != "" && strings.HasPrefix(text[i:], pattern) {
+				matches = append(matches, Match{Pattern: pattern, Offset: i})
+			}
+		}
+	}
+	return matches
+}
+
+func singleMatchers(pattern string) map[string]Matcher {
+	return map[string]Matcher{
+		"KMP":       NewKMP(pattern),
+		"Z":         NewZMatcher(pattern),
+		"Horspool":  NewHorspool(pattern),
+		"RabinKarp": NewRabinKarp(pattern),
+	}
+}
+
+func randomText(rng *rand.Rand, n int, alphabet string) string {
+	var b strings.Builder
+	for range n {
+		b.WriteByte(alphabet[rng.Intn(len(alphabet))])
+	}
+	return b.String()
+}
+
+func TestFindAll(t *testing.T) {
+	text := "abracadabra abracadabra"
+	expected := []Match{{"abra", 0}, {"abra", 7}, {"abra", 12}, {"abra", 19}}
+
+	for name, matcher := range singleMatchers("abra") {
+		if got := matcher.FindAll(text); !reflect.DeepEqual(got, expected) {
+			t.Errorf("%s.FindAll = %v, expected %v", name, got, expected)
+		}
+		if got := matcher.Index(text); got != 0 {
+			t.Errorf("%s.Index = %d, expected 0", name, got)
+		}
+		if got := matcher.Index("cadabr"); got != -1 {
+			t.Errorf("%s.Index(cadabr) = %d, expected -1", name, got)
+		}
+	}
+}
+
+func TestOverlappingAndEdgeCases(t *testing.T) {
+	testCases := []struct {
+		pattern, text string
+		expected      []int
+	}{
+		{"aa", "aaaa", []int{0, 1, 2}},
+		{"aba", "ababababa", []int{0, 2, 4, 6}},
+		{"a", "", nil},
+		{"abc", "ab", nil},
+		{"", "abc", nil},
+		{"abc", "abc", []int{0}},
+		{"\x00\xff", "\xff\x00\xff\x00", []int{1}},
+	}
+
+	for _, tc := range testCases {
+		for name, matcher := range singleMatchers(tc.pattern) {
+			var offsets []int
+			for _, match := range matcher.FindAll(tc.text) {
+				offsets = append(offsets, match.Offset)
+			}
+			if !reflect.DeepEqual(offsets, tc.expected) {
+				t.Errorf("%s(%q).FindAll(%q) = %v, expected %v", name, tc.pattern, tc.text, offsets, tc.expected)
+			}
+		}
+	}
+}
+
+func TestEmptyPatternIndex(t *testing.T) {
+	for _, text := range []string{"", "abc"} {
+		for name, matcher := range singleMatchers("") {
+			if got := matcher.Index(text); got != strings.Index(text, "") {
+				t.Errorf("%s(\"\").Index(%q) = %d, expected %d", name, text, got, strings.Index(text, ""))
+			}
+		}
+	}
+
+	if got := NewRabinKarp("zzz", "").Index("abc"); got != 0 {
+		t.Errorf("NewRabinKarp(\"zzz\", \"\").Index(\"abc\") = %d, expected 0", got)
+	}
+	if got := NewRabinKarp("zzz").Index("abc"); got != -1 {
+		t.Errorf("NewRabinKarp(\"zzz\").Index(\"abc\") = %d, expected -1", got)
+	}
+}
+
+func TestMatchersAgreeWithNaive(t *testing.T) {
+	rng := rand.New(rand.NewSource(13))
+
+	for range 500 {
+		text := randomText(rng, rng.Intn(80), "ab")
+		pattern := randomText(rng, 1+rng.Intn(5), "ab")
+		expected := naiveFindAll(text, pattern)
+
+		for name, matcher := range singleMatchers(pattern) {
+			if got := matcher.FindAll(text); !reflect.DeepEqual(got, expected) {
+				t.Fatalf("%s(%q).FindAll(%q) = %v, expected %v", name, pattern, text, got, expected)
+			}
+			if got, want := matcher.Index(text), strings.Index(text, pattern); got != want {
+				t.Fatalf("%s(%q).Index(%q) = %d, strings.Index = %d", name, pattern, text, got, want)
+			}
+		}
+	}
+}
+
+func TestRabinKarpMultiplePatterns(t *testing.T) {
+	rng := rand.New(rand.NewSource(17))
+
+	for range 300 {
+		text := randomText(rng, rng.Intn(60), "abc")
+		patterns := make([]string, 1+rng.Intn(5))
+		for i := range patterns {
+			patterns[i] = randomText(rng, 1+rng.Intn(4), "abc")
+		}
+
+		got := NewRabinKarp(patterns...).FindAll(text)
+		expected := naiveFindAll(text, slices.Compact(slices.Sorted(slices.Values(patterns)))...)
+		byPosition := func(a, b Match) int {
+			if a.Offset != b.Offset {
+				return a.Offset - b.Offset
+			}
+			return strings.Compare(a.Pattern, b.Pattern)
+		}
+		slices.SortFunc(got, byPosition)
+		slices.SortFunc(expected, byPosition)
+		if !reflect.DeepEqual(got, expected) {
+			t.Fatalf("RabinKarp(%v).FindAll(%q) = %v, expected %v", patterns, text, got, expected)
+		}
+	}
+}
+
+func TestScanReader(t *testing.T) {
+	rng := rand.New(rand.NewSource(19))
+	text := randomText(rng, 3*scanChunkSize+17, "ab")
+	pattern := "abbaab"
+	expected := naiveFindAll(text, pattern)
+
+	for name, matcher := range singleMatchers(pattern) {
+		var got []Match
+		err := matcher.Scan(iotest.HalfReader(strings.NewReader(text)), func(match Match) bool {
+			got = append(got, match)
+			return true
+		})
+		if err != nil {
+			t.Fatalf("%s.Scan returned %v", name, err)
+		}
+		if !reflect.DeepEqual(got, expected) {
+			t.Fatalf("%s.Scan found %d matches, expected %d", name, len(got), len(expected))
+		}
+
+		count := 0
+		matcher.Scan(strings.NewReader(text), func(Match) bool {
+			count++
+			return count < 3
+		})
+		if count != 3 {
+			t.Errorf("%s.Scan should stop when visit returns false, visited %d", name, count)
+		}
+
+		if err := matcher.Scan(iotest.ErrReader(iotest.ErrTimeout), func(Match) bool { return true }); err != iotest.ErrTimeout {
+			t.Errorf("%s.Scan error = %v, expected %v", name, err, iotest.ErrTimeout)
+		}
+	}
+}
+
+func TestScanAcrossChunkBoundaries(t *testing.T) {
+	patterns := []string{"xy", "needle", "longer-needle"}
+	text := strings.Repeat(".", scanChunkSize-3) + "longer-needle" + strings.Repeat(".", scanChunkSize-1) + "xy"
+	matcher := NewRabinKarp(patterns...)
+
+	var got []Match
+	if err := matcher.Scan(iotest.OneByteReader(strings.NewReader(text)), func(match Match) bool {
+		got = append(got, match)
+		return true
+	}); err != nil {
+		t.Fatalf("Scan returned %v", err)
+	}
+	if expected := matcher.FindAll(text); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Scan = %v, expected %v", got, expected)
+	}
+}
+
+func TestZFunction(t *testing.T) {
+	if got := ZFunction("aabxaab"); !reflect.DeepEqual(got, []int{7, 1, 0, 0, 3, 1, 0}) {
+		t.Errorf("ZFunction(aabxaab) = %v", got)
+	}
+	if got := ZFunction(""); len(got) != 0 {
+		t.Errorf("ZFunction(\"\") = %v, expected empty", got)
+	}
+}
+
+func TestRun(t *testing.T) {
+	result := Run()
+	if result == nil {
+		t.Error("Expected non-nil result")
+	}
+
+	resultMap, ok := result.(map[string]any)
+	if !ok {
+		t.Fatal("Expected result to be a map")
+	}
+	for _, name := range []string{"kmp", "z", "horspool", "rabin_karp"} {
+		if !reflect.DeepEqual(resultMap[name], []int{0, 7, 12, 19}) {
+			t.Errorf("%s offsets = %v, expected [0 7 12 19]", name, resultMap[name])
+		}
+	}
+}
+
+func BenchmarkIndex(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	inputs := []struct {
+		name, text, pattern string
+	}{
+		{"Random", randomText(rng, 1<<20, "abcdefghijklmnopqrstuvwxyz") + "needleinahaystack", "needleinahaystack"},
+		{"DNA", randomText(rng, 1<<20, "acgt") + "acgtacgtacgtacgtaa", "acgtacgtacgtacgtaa"},
+		{"Repetitive", strings.Repeat("a", 1<<20) + "b", strings.Repeat("a", 63) + "b"},
+	}
+
+	for _, input := range inputs {
+		b.Run(fmt.Sprintf("%s/strings.Index", input.name), func(b *testing.B) {
+			for b.Loop() {
+				strings.Index(input.text, input.pattern)
+			}
+		})
+		for _, name := range []string{"KMP", "Z", "Horspool", "RabinKarp"} {
+			matcher := singleMatchers(input.pattern)[name]
+			b.Run(fmt.Sprintf("%s/%s", input.name, name), func(b *testing.B) {
+				for b.Loop() {
+					matcher.Index(input.text)
+				}
+			})
+		}
+	}
+}
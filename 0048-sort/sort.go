@@ -0,0 +1,569 @@
+package sort
+
+import (
+	"cmp"
+	"math/bits"
+
+	"github.com/celj/dsa/0012-quick-sort"
+	"github.com/celj/dsa/0013-merge-sort"
+)
+
+const (
+	insertionThreshold = 12
+	minMerge           = 32
+)
+
+type Tracer interface {
+	Compare(i, j int)
+	Swap(i, j int)
+	Write(i, value int)
+	Copy(dst, src int)
+}
+
+func Sort[T any](s []T, cmp func(a, b T) int) {
+	PDQSort(s, cmp)
+}
+
+func SortFunc[S ~[]E, E any](x S, cmp func(a, b E) int) {
+	PDQSort(x, cmp)
+}
+
+func SortStableFunc[S ~[]E, E any](x S, cmp func(a, b E) int) {
+	TimSort(x, cmp)
+}
+
+func IsSortedFunc[S ~[]E, E any](x S, cmp func(a, b E) int) bool {
+	for i := 1; i < len(x); i++ {
+		if cmp(x[i], x[i-1]) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func search(n int, pred func(int) bool) int {
+	low, high := 0, n
+	for low < high {
+		mid := int(uint(low+high) >> 1)
+		if !pred(mid) {
+			low = mid + 1
+		} else {
+			high = mid
+		}
+	}
+	return low
+}
+
+func InsertionSort[T any](s []T, cmp func(a, b T) int) {
+	insertionSort(s, 0, cmp, nil)
+}
+
+func insertionSort[T any](s []T, offset int, cmp func(a, b T) int, t Tracer) {
+	for i := 1; i < len(s); i++ {
+		for j := i; j > 0 && less(s, offset, j, j-1, cmp, t); j-- {
+			swap(s, offset, j, j-1, t)
+		}
+	}
+}
+
+func less[T any](s []T, offset, i, j int, cmp func(a, b T) int, t Tracer) bool {
+	if t != nil {
+		t.Compare(offset+i, offset+j)
+	}
+	return cmp(s[i], s[j]) < 0
+}
+
+func swap[T any](s []T, offset, i, j int, t Tracer) {
+	s[i], s[j] = s[j], s[i]
+	if t != nil {
+		t.Swap(offset+i, offset+j)
+	}
+}
+
+func siftDown[T any](s []T, offset, root, size int, cmp func(a, b T) int, t Tracer) {
+	for {
+		child := 2*root + 1
+		if child >= size {
+			return
+		}
+		if child+1 < size && less(s, offset, child, child+1, cmp, t) {
+			child++
+		}
+		if !less(s, offset, root, child, cmp, t) {
+			return
+		}
+		swap(s, offset, root, child, t)
+		root = child
+	}
+}
+
+func HeapSort[T any](s []T, cmp func(a, b T) int) {
+	heapSort(s, 0, cmp, nil)
+}
+
+func heapSort[T any](s []T, offset int, cmp func(a, b T) int, t Tracer) {
+	for i := (len(s) - 1) / 2; i >= 0; i-- {
+		siftDown(s, offset, i, len(s), cmp, t)
+	}
+	for i := len(s) - 1; i > 0; i-- {
+		swap(s, offset, 0, i, t)
+		siftDown(s, offset, 0, i, cmp, t)
+	}
+}
+
+func IntroSort[T any](s []T, cmp func(a, b T) int) {
+	introSort(s, 0, 2*bits.Len(uint(len(s))), cmp, nil)
+}
+
+func introSort[T any](s []T, offset, depth int, cmp func(a, b T) int, t Tracer) {
+	for len(s) > insertionThreshold {
+		if depth == 0 {
+			heapSort(s, offset, cmp, t)
+			return
+		}
+		depth--
+
+		median := quick_sort.MedianOfThreeFuncTraced(s, offset, cmp, t)
+		swap(s, offset, median, len(s)-1, t)
+		pivot := quick_sort.PartitionFuncTraced(s, offset, cmp, t)
+
+		if pivot < len(s)-pivot-1 {
+			introSort(s[:pivot], offset, depth, cmp, t)
+			s, offset = s[pivot+1:], offset+pivot+1
+		} else {
+			introSort(s[pivot+1:], offset+pivot+1, depth, cmp, t)
+			s = s[:pivot]
+		}
+	}
+	insertionSort(s, offset, cmp, t)
+}
+
+type sortedHint int
+
+const (
+	unknownHint sortedHint = iota
+	increasingHint
+	decreasingHint
+)
+
+type xorshift uint64
+
+func (r *xorshift) next() uint64 {
+	*r ^= *r << 13
+	*r ^= *r >> 7
+	*r ^= *r << 17
+	return uint64(*r)
+}
+
+func PDQSort[T any](s []T, cmp func(a, b T) int) {
+	pdqsort(s, 0, len(s), bits.Len(uint(len(s))), cmp, nil)
+}
+
+func pdqsort[T any](data []T, a, b, limit int, cmp func(a, b T) int, t Tracer) {
+	wasBalanced, wasPartitioned := true, true
+
+	for {
+		length := b - a
+		if length <= insertionThreshold {
+			insertionSort(data[a:b], a, cmp, t)
+			return
+		}
+		if limit == 0 {
+			heapSort(data[a:b], a, cmp, t)
+			return
+		}
+		if !wasBalanced {
+			breakPatterns(data, a, b, t)
+			limit--
+		}
+
+		pivot, hint := choosePivot(data, a, b, cmp, t)
+		if hint == decreasingHint {
+			reverseRange(data, a, b, t)
+			pivot = (b - 1) - (pivot - a)
+			hint = increasingHint
+		}
+
+		if wasBalanced && wasPartitioned && hint == increasingHint && partialInsertionSort(data, a, b, cmp, t) {
+			return
+		}
+
+		if a > 0 && !less(data, 0, a-1, pivot, cmp, t) {
+			a = partitionEqual(data, a, b, pivot, cmp, t)
+			continue
+		}
+
+		mid, alreadyPartitioned := partition(data, a, b, pivot, cmp, t)
+		wasPartitioned = alreadyPartitioned
+
+		leftLength, rightLength := mid-a, b-mid
+		balanceThreshold := length / 8
+		if leftLength < rightLength {
+			wasBalanced = leftLength >= balanceThreshold
+			pdqsort(data, a, mid, limit, cmp, t)
+			a = mid + 1
+		} else {
+			wasBalanced = rightLength >= balanceThreshold
+			pdqsort(data, mid+1, b, limit, cmp, t)
+			b = mid
+		}
+	}
+}
+
+func partition[T any](data []T, a, b, pivot int, cmp func(a, b T) int, t Tracer) (int, bool) {
+	swap(data, 0, a, pivot, t)
+	i, j := a+1, b-1
+
+	for i <= j && less(data, 0, i, a, cmp, t) {
+		i++
+	}
+	for i <= j && !less(data, 0, j, a, cmp, t) {
+		j--
+	}
+	if i > j {
+		swap(data, 0, j, a, t)
+		return j, true
+	}
+	swap(data, 0, i, j, t)
+	i++
+	j--
+
+	for {
+		for i <= j && less(data, 0, i, a, cmp, t) {
+			i++
+		}
+		for i <= j && !less(data, 0, j, a, cmp, t) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		swap(data, 0, i, j, t)
+		i++
+		j--
+	}
+	swap(data, 0, j, a, t)
+	return j, false
+}
+
+func partitionEqual[T any](data []T, a, b, pivot int, cmp func(a, b T) int, t Tracer) int {
+	swap(data, 0, a, pivot, t)
+	i, j := a+1, b-1
+
+	for {
+		for i <= j && !less(data, 0, a, i, cmp, t) {
+			i++
+		}
+		for i <= j && less(data, 0, a, j, cmp, t) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		swap(data, 0, i, j, t)
+		i++
+		j--
+	}
+	return i
+}
+
+func partialInsertionSort[T any](data []T, a, b int, cmp func(a, b T) int, t Tracer) bool {
+	const (
+		maxSteps         = 5
+		shortestShifting = 50
+	)
+
+	i := a + 1
+	for range maxSteps {
+		for i < b && !less(data, 0, i, i-1, cmp, t) {
+			i++
+		}
+		if i == b {
+			return true
+		}
+		if b-a < shortestShifting {
+			return false
+		}
+
+		swap(data, 0, i, i-1, t)
+		for j := i - 1; j >= 1 && less(data, 0, j, j-1, cmp, t); j-- {
+			swap(data, 0, j, j-1, t)
+		}
+		for j := i + 1; j < b && less(data, 0, j, j-1, cmp, t); j++ {
+			swap(data, 0, j, j-1, t)
+		}
+	}
+	return false
+}
+
+func breakPatterns[T any](data []T, a, b int, t Tracer) {
+	length := b - a
+	if length < 8 {
+		return
+	}
+
+	random := xorshift(length)
+	modulus := uint(1) << bits.Len(uint(length))
+	idx := a + (length/4)*2 - 1
+	for i := range 3 {
+		other := int(uint(random.next()) & (modulus - 1))
+		if other >= length {
+			other -= length
+		}
+		swap(data, 0, idx-1+i, a+other, t)
+	}
+}
+
+func choosePivot[T any](data []T, a, b int, cmp func(a, b T) int, t Tracer) (int, sortedHint) {
+	const (
+		shortestNinther = 50
+		maxSwaps        = 4 * 3
+	)
+
+	length := b - a
+	swaps := 0
+	i, j, k := a+length/4*1, a+length/4*2, a+length/4*3
+
+	if length >= 8 {
+		if length >= shortestNinther {
+			i = medianAdjacent(data, i, &swaps, cmp, t)
+			j = medianAdjacent(data, j, &swaps, cmp, t)
+			k = medianAdjacent(data, k, &swaps, cmp, t)
+		}
+		j = median(data, i, j, k, &swaps, cmp, t)
+	}
+
+	switch swaps {
+	case 0:
+		return j, increasingHint
+	case maxSwaps:
+		return j, decreasingHint
+	default:
+		return j, unknownHint
+	}
+}
+
+func order2[T any](data []T, a, b int, swaps *int, cmp func(a, b T) int, t Tracer) (int, int) {
+	if less(data, 0, b, a, cmp, t) {
+		*swaps++
+		return b, a
+	}
+	return a, b
+}
+
+func median[T any](data []T, a, b, c int, swaps *int, cmp func(a, b T) int, t Tracer) int {
+	a, b = order2(data, a, b, swaps, cmp, t)
+	b, c = order2(data, b, c, swaps, cmp, t)
+	_, b = order2(data, a, b, swaps, cmp, t)
+	return b
+}
+
+func medianAdjacent[T any](data []T, a int, swaps *int, cmp func(a, b T) int, t Tracer) int {
+	return median(data, a-1, a, a+1, swaps, cmp, t)
+}
+
+func reverseRange[T any](data []T, a, b int, t Tracer) {
+	for i, j := a, b-1; i < j; i, j = i+1, j-1 {
+		swap(data, 0, i, j, t)
+	}
+}
+
+type run struct {
+	start  int
+	length int
+}
+
+type timSort[T any] struct {
+	data  []T
+	cmp   func(a, b T) int
+	runs  []run
+	buf   []T
+	trace Tracer
+}
+
+func TimSort[T any](s []T, cmp func(a, b T) int) {
+	timSortTraced(s, cmp, nil)
+}
+
+func timSortTraced[T any](s []T, cmp func(a, b T) int, t Tracer) {
+	if len(s) < 2 {
+		return
+	}
+
+	ts := &timSort[T]{data: s, cmp: cmp, trace: t}
+	minRun := minRunLength(len(s))
+
+	for low := 0; low < len(s); {
+		length := ts.countRunAndMakeAscending(low)
+		if length < minRun {
+			forced := min(minRun, len(s)-low)
+			ts.binaryInsertionSort(low, low+forced, length)
+			length = forced
+		}
+
+		ts.runs = append(ts.runs, run{start: low, length: length})
+		ts.mergeCollapse()
+		low += length
+	}
+
+	for len(ts.runs) > 1 {
+		n := len(ts.runs) - 2
+		if n > 0 && ts.runs[n-1].length < ts.runs[n+1].length {
+			n--
+		}
+		ts.mergeAt(n)
+	}
+}
+
+func minRunLength(n int) int {
+	extra := 0
+	for n >= minMerge {
+		extra |= n & 1
+		n >>= 1
+	}
+	return n + extra
+}
+
+func (ts *timSort[T]) less(i, j int) bool {
+	return less(ts.data, 0, i, j, ts.cmp, ts.trace)
+}
+
+func (ts *timSort[T]) countRunAndMakeAscending(low int) int {
+	if low == len(ts.data)-1 {
+		return 1
+	}
+
+	end := low + 2
+	if ts.less(low+1, low) {
+		for end < len(ts.data) && ts.less(end, end-1) {
+			end++
+		}
+		reverseRange(ts.data, low, end, ts.trace)
+	} else {
+		for end < len(ts.data) && !ts.less(end, end-1) {
+			end++
+		}
+	}
+	return end - low
+}
+
+func (ts *timSort[T]) binaryInsertionSort(low, high, sorted int) {
+	s := ts.data
+	t := ts.trace
+	hole := len(s)
+	for i := low + max(sorted, 1); i < high; i++ {
+		pivot := s[i]
+		position := low + search(i-low, func(j int) bool { return ts.less(i, low+j) })
+		if t != nil {
+			t.Copy(hole, i)
+			for k := i; k > position; k-- {
+				t.Copy(k, k-1)
+			}
+			t.Copy(position, hole)
+		}
+		copy(s[position+1:i+1], s[position:i])
+		s[position] = pivot
+	}
+}
+
+func (ts *timSort[T]) mergeCollapse() {
+	for len(ts.runs) > 1 {
+		n := len(ts.runs) - 2
+		runs := ts.runs
+
+		if (n > 0 && runs[n-1].length <= runs[n].length+runs[n+1].length) ||
+			(n > 1 && runs[n-2].length <= runs[n-1].length+runs[n].length) {
+			if runs[n-1].length < runs[n+1].length {
+				n--
+			}
+		} else if runs[n].length > runs[n+1].length {
+			return
+		}
+		ts.mergeAt(n)
+	}
+}
+
+func (ts *timSort[T]) mergeAt(i int) {
+	first, second := ts.runs[i], ts.runs[i+1]
+	ts.runs[i].length += second.length
+	ts.runs = append(ts.runs[:i+1], ts.runs[i+2:]...)
+
+	skip := search(first.length, func(j int) bool { return ts.less(second.start, first.start+j) })
+	start := first.start + skip
+	left := ts.data[start:second.start]
+	if len(left) == 0 {
+		return
+	}
+
+	right := ts.data[second.start : second.start+second.length]
+	right = right[:search(len(right), func(j int) bool { return !ts.less(second.start+j, second.start-1) })]
+	if len(right) == 0 {
+		return
+	}
+
+	t := ts.trace
+	m := merge_sort.MergeTrace{Trace: t, Dst: start, Left: len(ts.data), Right: second.start}
+	if t != nil {
+		for k := range left {
+			t.Copy(m.Left+k, start+k)
+		}
+	}
+	ts.buf = append(ts.buf[:0], left...)
+	merge_sort.MergeFuncTraced(ts.data[start:start+len(left)+len(right)], ts.buf, right, ts.cmp, m)
+}
+
+func HeapSortTraced(arr []int, t Tracer) {
+	heapSort(arr, 0, cmp.Compare[int], t)
+}
+
+func IntroSortTraced(arr []int, t Tracer) {
+	introSort(arr, 0, 2*bits.Len(uint(len(arr))), cmp.Compare[int], t)
+}
+
+func PDQSortTraced(arr []int, t Tracer) {
+	pdqsort(arr, 0, len(arr), bits.Len(uint(len(arr))), cmp.Compare[int], t)
+}
+
+func TimSortTraced(arr []int, t Tracer) {
+	timSortTraced(arr, cmp.Compare[int], t)
+}
+
+func Run() any {
+	type person struct {
+		name string
+		age  int
+	}
+
+	people := []person{
+		{"alice", 31}, {"bob", 25}, {"carol", 31}, {"dave", 25}, {"erin", 40}, {"frank", 25},
+	}
+	byAge := func(a, b person) int { return a.age - b.age }
+
+	stable := make([]person, len(people))
+	copy(stable, people)
+	SortStableFunc(stable, byAge)
+
+	var stableNames []string
+	for _, p := range stable {
+		stableNames = append(stableNames, p.name)
+	}
+
+	numbers := []int{38, 27, 43, 3, 9, 82, 10, 3, 55, 1, 17, 64, 29, 8}
+	intCmp := func(a, b int) int { return a - b }
+
+	results := map[string]any{
+		"stable_by_age": stableNames,
+	}
+	for name, sorter := range map[string]func([]int, func(a, b int) int){
+		"introsort": IntroSort[int],
+		"pdqsort":   PDQSort[int],
+		"timsort":   TimSort[int],
+		"heapsort":  HeapSort[int],
+	} {
+		sorted := make([]int, len(numbers))
+		copy(sorted, numbers)
+		sorter(sorted, intCmp)
+		results[name] = sorted
+	}
+	return results
+}
@@ -0,0 +1,353 @@
+package sort
+
+import (
+	"cmp"
+	"fmt"
+	"math/bits"
+	"math/rand"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type record struct {
+	key   int
+	order int
+}
+
+func byKey(a, b record) int {
+	return cmp.Compare(a.key, b.key)
+}
+
+var sorters = map[string]func([]record, func(a, b record) int){
+	"Sort":           Sort[record],
+	"SortFunc":       SortFunc[[]record, record],
+	"SortStableFunc": SortStableFunc[[]record, record],
+	"IntroSort":      IntroSort[record],
+	"PDQSort":        PDQSort[record],
+	"TimSort":        TimSort[record],
+	"HeapSort":       HeapSort[record],
+	"InsertionSort":  InsertionSort[record],
+}
+
+func patterns(rng *rand.Rand, n int) map[string][]int {
+	random := make([]int, n)
+	sorted := make([]int, n)
+	reversed := make([]int, n)
+	equal := make([]int, n)
+	fewUnique := make([]int, n)
+	organPipe := make([]int, n)
+	sawtooth := make([]int, n)
+	nearlySorted := make([]int, n)
+
+	for i := range n {
+		random[i] = rng.Intn(n + 1)
+		sorted[i] = i
+		reversed[i] = n - i
+		equal[i] = 7
+		fewUnique[i] = rng.Intn(4)
+		organPipe[i] = min(i, n-i)
+		sawtooth[i] = i % 17
+		nearlySorted[i] = i
+	}
+	for range n / 20 {
+		a, b := rng.Intn(max(n, 1)), rng.Intn(max(n, 1))
+		if n > 0 {
+			nearlySorted[a], nearlySorted[b] = nearlySorted[b], nearlySorted[a]
+		}
+	}
+
+	return map[string][]int{
+		"random":        random,
+		"sorted":        sorted,
+		"reversed":      reversed,
+		"equal":         equal,
+		"few_unique":    fewUnique,
+		"organ_pipe":    organPipe,
+		"sawtooth":      sawtooth,
+		"nearly_sorted": nearlySorted,
+	}
+}
+
+func toRecords(keys []int) []record {
+	records := make([]record, len(keys))
+	for i, key := range keys {
+		records[i] = record{key: key, order: i}
+	}
+	return records
+}
+
+func TestSortersMatchStandardLibrary(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for _, n := range []int{0, 1, 2, 3, 11, 12, 13, 31, 32, 33, 64, 100, 1000, 5000} {
+		for pattern, keys := range patterns(rng, n) {
+			expected := toRecords(keys)
+			slices.SortStableFunc(expected, byKey)
+
+			for name, sorter := range sorters {
+				if name == "InsertionSort" && n > 1000 {
+					continue
+				}
+
+				got := toRecords(keys)
+				sorter(got, byKey)
+
+				if !IsSortedFunc(got, byKey) {
+					t.Fatalf("%s on %s/%d is not sorted", name, pattern, n)
+				}
+				gotKeys := make([]int, len(got))
+				expectedKeys := make([]int, len(expected))
+				for i := range got {
+					gotKeys[i], expectedKeys[i] = got[i].key, expected[i].key
+				}
+				if !slices.Equal(gotKeys, expectedKeys) {
+					t.Fatalf("%s on %s/%d is not a permutation of the input", name, pattern, n)
+				}
+			}
+		}
+	}
+}
+
+func TestStableSorts(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+
+	for _, n := range []int{5, 40, 100, 1000, 20000} {
+		for pattern, keys := range patterns(rng, n) {
+			expected := toRecords(keys)
+			slices.SortStableFunc(expected, byKey)
+
+			for _, name := range []string{"SortStableFunc", "TimSort", "InsertionSort"} {
+				if name == "InsertionSort" && n > 1000 {
+					continue
+				}
+				got := toRecords(keys)
+				sorters[name](got, byKey)
+				if !reflect.DeepEqual(got, expected) {
+					t.Fatalf("%s is not stable on %s/%d", name, pattern, n)
+				}
+			}
+		}
+	}
+}
+
+func TestTimSortUsesNaturalRuns(t *testing.T) {
+	n := 1 << 14
+	keys := make([]int, n)
+	for i := range n / 2 {
+		keys[i] = 2 * i
+		keys[n/2+i] = 2*i + 1
+	}
+
+	comparisons := 0
+	counting := func(a, b int) int {
+		comparisons++
+		return a - b
+	}
+
+	TimSort(keys, counting)
+	if !slices.IsSorted(keys) {
+		t.Fatal("TimSort did not sort two interleaved runs")
+	}
+	if comparisons > 5*n/2 {
+		t.Errorf("TimSort used %d comparisons on two runs of %d, expected a single linear merge", comparisons, n/2)
+	}
+
+	comparisons = 0
+	TimSort(keys, counting)
+	if comparisons != n-1 {
+		t.Errorf("TimSort on sorted input used %d comparisons, expected %d", comparisons, n-1)
+	}
+}
+
+func TestIntroSortFallsBackToHeapSort(t *testing.T) {
+	n := 1 << 12
+	keys := make([]int, n)
+
+	comparisons := 0
+	IntroSort(keys, func(a, b int) int {
+		comparisons++
+		return a - b
+	})
+
+	if limit := 4 * n * 2 * 13; comparisons > limit {
+		t.Errorf("IntroSort used %d comparisons on equal keys, expected O(n log n) (< %d)", comparisons, limit)
+	}
+}
+
+func TestSortStructs(t *testing.T) {
+	type employee struct {
+		name string
+		dept string
+		age  int
+	}
+
+	staff := []employee{
+		{"ann", "ops", 41}, {"ben", "dev", 29}, {"cid", "ops", 29},
+		{"dee", "dev", 35}, {"eve", "sec", 29}, {"fay", "dev", 29},
+	}
+
+	SortStableFunc(staff, func(a, b employee) int { return strings.Compare(a.name, b.name) })
+	SortStableFunc(staff, func(a, b employee) int { return cmp.Compare(a.age, b.age) })
+
+	var names []string
+	for _, e := range staff {
+		names = append(names, e.name)
+	}
+	if expected := []string{"ben", "cid", "eve", "fay", "dee", "ann"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("stable multi-key sort = %v, expected %v", names, expected)
+	}
+
+	Sort(staff, func(a, b employee) int {
+		return cmp.Or(strings.Compare(a.dept, b.dept), cmp.Compare(b.age, a.age))
+	})
+	if staff[0].name != "dee" || staff[len(staff)-1].dept != "sec" {
+		t.Errorf("Sort by dept then age descending = %v", staff)
+	}
+}
+
+func TestFloatsWithNaN(t *testing.T) {
+	values := []float64{3, -1, 2.5, 0, -7}
+	for name, sorter := range map[string]func([]float64, func(a, b float64) int){
+		"PDQSort":   PDQSort[float64],
+		"IntroSort": IntroSort[float64],
+		"TimSort":   TimSort[float64],
+	} {
+		got := slices.Clone(values)
+		sorter(got, cmp.Compare[float64])
+		if !slices.Equal(got, []float64{-7, -1, 0, 2.5, 3}) {
+			t.Errorf("%s = %v", name, got)
+		}
+	}
+}
+
+func TestMinRunLength(t *testing.T) {
+	testCases := map[int]int{0: 0, 31: 31, 32: 16, 33: 17, 64: 16, 65: 17, 1 << 20: 16}
+	for n, expected := range testCases {
+		if got := minRunLength(n); got != expected {
+			t.Errorf("minRunLength(%d) = %d, expected %d", n, got, expected)
+		}
+	}
+}
+
+type replayTracer struct {
+	state    []int
+	compared [][2]int
+}
+
+func newReplayTracer(arr []int) *replayTracer {
+	return &replayTracer{state: slices.Clone(arr)}
+}
+
+func (r *replayTracer) at(i int) int {
+	if i >= len(r.state) {
+		r.state = append(r.state, make([]int, i+1-len(r.state))...)
+	}
+	return r.state[i]
+}
+
+func (r *replayTracer) Compare(i, j int) {
+	r.compared = append(r.compared, [2]int{r.at(i), r.at(j)})
+}
+
+func (r *replayTracer) Swap(i, j int) {
+	a, b := r.at(i), r.at(j)
+	r.state[i], r.state[j] = b, a
+}
+
+func (r *replayTracer) Write(i, value int) {
+	r.at(i)
+	r.state[i] = value
+}
+
+func (r *replayTracer) Copy(dst, src int) {
+	r.Write(dst, r.at(src))
+}
+
+func TestTracedSorts(t *testing.T) {
+	rng := rand.New(rand.NewSource(9))
+
+	traced := map[string]struct {
+		sort func([]int, Tracer)
+		run  func([]int, func(a, b int) int, Tracer)
+	}{
+		"HeapSort": {HeapSortTraced, func(s []int, cmp func(a, b int) int, t Tracer) {
+			heapSort(s, 0, cmp, t)
+		}},
+		"IntroSort": {IntroSortTraced, func(s []int, cmp func(a, b int) int, t Tracer) {
+			introSort(s, 0, 2*bits.Len(uint(len(s))), cmp, t)
+		}},
+		"PDQSort": {PDQSortTraced, func(s []int, cmp func(a, b int) int, t Tracer) {
+			pdqsort(s, 0, len(s), bits.Len(uint(len(s))), cmp, t)
+		}},
+		"TimSort": {TimSortTraced, timSortTraced[int]},
+	}
+
+	for _, n := range []int{0, 1, 2, 20, 300} {
+		for pattern, keys := range patterns(rng, n) {
+			for name, sorter := range traced {
+				sorted := slices.Clone(keys)
+				trace := newReplayTracer(keys)
+				sorter.sort(sorted, trace)
+				if !slices.IsSorted(sorted) {
+					t.Fatalf("%s on %s (n=%d): result not sorted", name, pattern, n)
+				}
+				if !slices.Equal(trace.state[:n], sorted) {
+					t.Fatalf("%s on %s (n=%d): replaying the trace does not give the sorted result", name, pattern, n)
+				}
+
+				var compared [][2]int
+				trace = newReplayTracer(keys)
+				sorter.run(slices.Clone(keys), func(a, b int) int {
+					compared = append(compared, [2]int{a, b})
+					return cmp.Compare(a, b)
+				}, trace)
+				if !slices.Equal(trace.compared, compared) {
+					t.Fatalf("%s on %s (n=%d): traced comparisons do not match the values compared", name, pattern, n)
+				}
+			}
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	result := Run()
+	if result == nil {
+		t.Error("Expected non-nil result")
+	}
+
+	resultMap, ok := result.(map[string]any)
+	if !ok {
+		t.Fatal("Expected result to be a map")
+	}
+	if expected := []string{"bob", "dave", "frank", "alice", "carol", "erin"}; !reflect.DeepEqual(resultMap["stable_by_age"], expected) {
+		t.Errorf("stable_by_age = %v, expected %v", resultMap["stable_by_age"], expected)
+	}
+}
+
+func BenchmarkSort(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	n := 100000
+
+	algorithms := map[string]func([]record, func(a, b record) int){
+		"slices.SortFunc":       slices.SortFunc[[]record, record],
+		"slices.SortStableFunc": slices.SortStableFunc[[]record, record],
+		"IntroSort":             IntroSort[record],
+		"PDQSort":               PDQSort[record],
+		"TimSort":               TimSort[record],
+	}
+
+	for _, pattern := range []string{"random", "sorted", "reversed", "few_unique", "nearly_sorted"} {
+		input := toRecords(patterns(rng, n)[pattern])
+		for _, name := range []string{"slices.SortFunc", "slices.SortStableFunc", "IntroSort", "PDQSort", "TimSort"} {
+			b.Run(fmt.Sprintf("%s/%s", pattern, name), func(b *testing.B) {
+				data := make([]record, n)
+				for b.Loop() {
+					copy(data, input)
+					algorithms[name](data, byKey)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,338 @@
+package non_comparison_sort
+
+import (
+	"cmp"
+	"errors"
+	"math"
+	"unsafe"
+
+	"github.com/celj/dsa/0048-sort"
+)
+
+var ErrRangeTooLarge = errors.New("key range too large for counting sort")
+
+const (
+	MaxCountingRange = 1 << 20
+	msdCutoff        = 16
+)
+
+type Unsigned interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+type Signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+type Float interface {
+	~float32 | ~float64
+}
+
+func CountingSort[T any](s []T, key func(T) int) error {
+	if len(s) < 2 {
+		return nil
+	}
+
+	keys := make([]int, len(s))
+	low, high := key(s[0]), key(s[0])
+	for i, item := range s {
+		keys[i] = key(item)
+		low, high = min(low, keys[i]), max(high, keys[i])
+	}
+	if uint64(high-low) >= MaxCountingRange {
+		return ErrRangeTooLarge
+	}
+
+	counts := make([]int, high-low+2)
+	for _, k := range keys {
+		counts[k-low+1]++
+	}
+	for i := 1; i < len(counts); i++ {
+		counts[i] += counts[i-1]
+	}
+
+	sorted := make([]T, len(s))
+	for i, item := range s {
+		bucket := keys[i] - low
+		sorted[counts[bucket]] = item
+		counts[bucket]++
+	}
+	copy(s, sorted)
+	return nil
+}
+
+func SignedKey[K Signed](v K) uint64 {
+	width := 8 * unsafe.Sizeof(v)
+	mask := uint64(math.MaxUint64) >> (64 - width)
+	return (uint64(v) & mask) ^ (1 << (width - 1))
+}
+
+func FloatKey[K Float](v K) uint64 {
+	if unsafe.Sizeof(v) == 4 {
+		bits := uint64(math.Float32bits(float32(v)))
+		if bits&(1<<31) != 0 {
+			return ^bits & math.MaxUint32
+		}
+		return bits | 1<<31
+	}
+
+	bits := math.Float64bits(float64(v))
+	if bits&(1<<63) != 0 {
+		return ^bits
+	}
+	return bits | 1<<63
+}
+
+func RadixSort[T any, K Unsigned](s []T, key func(T) K) {
+	var zero K
+	keys := make([]uint64, len(s))
+	for i, item := range s {
+		keys[i] = uint64(key(item))
+	}
+	lsdRadix(s, keys, int(unsafe.Sizeof(zero)))
+}
+
+func RadixSortSigned[T any, K Signed](s []T, key func(T) K) {
+	var zero K
+	keys := make([]uint64, len(s))
+	for i, item := range s {
+		keys[i] = SignedKey(key(item))
+	}
+	lsdRadix(s, keys, int(unsafe.Sizeof(zero)))
+}
+
+func RadixSortFloat[T any, K Float](s []T, key func(T) K) {
+	var zero K
+	keys := make([]uint64, len(s))
+	for i, item := range s {
+		keys[i] = FloatKey(key(item))
+	}
+	lsdRadix(s, keys, int(unsafe.Sizeof(zero)))
+}
+
+func lsdRadix[T any](s []T, keys []uint64, width int) {
+	if len(s) < 2 {
+		return
+	}
+
+	counts := make([][256]int, width)
+	for _, k := range keys {
+		for pass := range width {
+			counts[pass][(k>>(8*pass))&0xff]++
+		}
+	}
+
+	items, itemBuf := s, make([]T, len(s))
+	keyBuf := make([]uint64, len(s))
+
+	for pass := range width {
+		shift := 8 * pass
+		offsets := &counts[pass]
+		if offsets[(keys[0]>>shift)&0xff] == len(keys) {
+			continue
+		}
+
+		offset := 0
+		for i, count := range offsets {
+			offsets[i] = offset
+			offset += count
+		}
+
+		for i, k := range keys {
+			b := (k >> shift) & 0xff
+			itemBuf[offsets[b]] = items[i]
+			keyBuf[offsets[b]] = k
+			offsets[b]++
+		}
+
+		items, itemBuf = itemBuf, items
+		keys, keyBuf = keyBuf, keys
+	}
+
+	if &items[0] != &s[0] {
+		copy(s, items)
+	}
+}
+
+func byteAt(key string, depth int) int {
+	if depth < len(key) {
+		return int(key[depth]) + 1
+	}
+	return 0
+}
+
+func MSDRadixSort[T any](s []T, key func(T) string) {
+	keys := make([]string, len(s))
+	for i, item := range s {
+		keys[i] = key(item)
+	}
+	msdRadix(s, keys, make([]T, len(s)), make([]string, len(s)), 0)
+}
+
+func msdRadix[T any](s []T, keys []string, itemBuf []T, keyBuf []string, depth int) {
+	if len(s) <= msdCutoff {
+		insertionSortByKey(s, keys, depth)
+		return
+	}
+
+	var counts [258]int
+	for _, k := range keys {
+		counts[byteAt(k, depth)+1]++
+	}
+	for i := 1; i < len(counts); i++ {
+		counts[i] += counts[i-1]
+	}
+	starts := counts
+
+	for i, k := range keys {
+		b := byteAt(k, depth)
+		itemBuf[counts[b]] = s[i]
+		keyBuf[counts[b]] = k
+		counts[b]++
+	}
+	copy(s, itemBuf)
+	copy(keys, keyBuf)
+
+	for b := 1; b < 257; b++ {
+		low, high := starts[b], starts[b+1]
+		if high-low > 1 {
+			msdRadix(s[low:high], keys[low:high], itemBuf[low:high], keyBuf[low:high], depth+1)
+		}
+	}
+}
+
+func insertionSortByKey[T any](s []T, keys []string, depth int) {
+	for i := 1; i < len(s); i++ {
+		for j := i; j > 0 && keys[j][min(depth, len(keys[j])):] < keys[j-1][min(depth, len(keys[j-1])):]; j-- {
+			s[j], s[j-1] = s[j-1], s[j]
+			keys[j], keys[j-1] = keys[j-1], keys[j]
+		}
+	}
+}
+
+func AmericanFlagSort[T any](s []T, key func(T) string) {
+	keys := make([]string, len(s))
+	for i, item := range s {
+		keys[i] = key(item)
+	}
+	americanFlag(s, keys, 0)
+}
+
+func americanFlag[T any](s []T, keys []string, depth int) {
+	if len(s) <= msdCutoff {
+		insertionSortByKey(s, keys, depth)
+		return
+	}
+
+	var counts [257]int
+	for _, k := range keys {
+		counts[byteAt(k, depth)]++
+	}
+
+	var starts, next [257]int
+	offset := 0
+	for b, count := range counts {
+		starts[b] = offset
+		next[b] = offset
+		offset += count
+	}
+
+	for b := range counts {
+		end := starts[b] + counts[b]
+		for next[b] < end {
+			i := next[b]
+			target := byteAt(keys[i], depth)
+			if target == b {
+				next[b]++
+				continue
+			}
+			j := next[target]
+			s[i], s[j] = s[j], s[i]
+			keys[i], keys[j] = keys[j], keys[i]
+			next[target]++
+		}
+	}
+
+	for b := 1; b < 257; b++ {
+		if counts[b] > 1 {
+			low := starts[b]
+			americanFlag(s[low:low+counts[b]], keys[low:low+counts[b]], depth+1)
+		}
+	}
+}
+
+func BucketSort[T any](s []T, key func(T) float64) {
+	if len(s) < 2 {
+		return
+	}
+
+	keys := make([]float64, len(s))
+	low, high := math.Inf(1), math.Inf(-1)
+	for i, item := range s {
+		keys[i] = key(item)
+		if !math.IsNaN(keys[i]) {
+			low, high = min(low, keys[i]), max(high, keys[i])
+		}
+	}
+
+	type entry struct {
+		item T
+		key  float64
+	}
+
+	buckets := make([][]entry, len(s))
+	span := high - low
+	for i, item := range s {
+		b := 0
+		if span > 0 && !math.IsInf(span, 0) && !math.IsNaN(keys[i]) {
+			b = min(int((keys[i]-low)/span*float64(len(s))), len(s)-1)
+		}
+		buckets[b] = append(buckets[b], entry{item: item, key: keys[i]})
+	}
+
+	i := 0
+	for _, bucket := range buckets {
+		sort.TimSort(bucket, func(a, b entry) int { return cmp.Compare(a.key, b.key) })
+		for _, e := range bucket {
+			s[i] = e.item
+			i++
+		}
+	}
+}
+
+func Run() any {
+	timestamps := []uint64{1700000300, 1700000100, 1700000200, 1700000100, 1699999999}
+	RadixSort(timestamps, func(v uint64) uint64 { return v })
+
+	temperatures := []float64{12.5, -3.25, 0, -40, 37.8, 21}
+	RadixSortFloat(temperatures, func(v float64) float64 { return v })
+
+	offsets := []int32{5, -2, 0, -2147483648, 2147483647, -1}
+	RadixSortSigned(offsets, func(v int32) int32 { return v })
+
+	grades := []int{88, 72, 95, 72, 60, 88}
+	CountingSort(grades, func(v int) int { return v })
+
+	words := []string{"she", "sells", "seashells", "by", "the", "sea", "shore", "the", "shells"}
+	msd := make([]string, len(words))
+	copy(msd, words)
+	MSDRadixSort(msd, func(v string) string { return v })
+	flag := make([]string, len(words))
+	copy(flag, words)
+	AmericanFlagSort(flag, func(v string) string { return v })
+
+	uniform := []float64{0.78, 0.17, 0.39, 0.26, 0.72, 0.94, 0.21, 0.12, 0.23, 0.68}
+	BucketSort(uniform, func(v float64) float64 { return v })
+
+	return map[string]any{
+		"radix_uint64":     timestamps,
+		"radix_float64":    temperatures,
+		"radix_int32":      offsets,
+		"counting":         grades,
+		"msd_radix":        msd,
+		"american_flag":    flag,
+		"bucket":           uniform,
+		"max_count_range":  MaxCountingRange,
+		"signed_key_minus": SignedKey(int8(-1)),
+	}
+}
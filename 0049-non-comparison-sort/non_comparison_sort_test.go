@@ -0,0 +1,279 @@
+package non_comparison_sort
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type event struct {
+	timestamp uint64
+	id        int
+}
+
+func TestCountingSort(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	type item struct {
+		key, order int
+	}
+
+	for _, n := range []int{0, 1, 2, 10, 1000} {
+		items := make([]item, n)
+		for i := range items {
+			items[i] = item{key: rng.Intn(21) - 10, order: i}
+		}
+		expected := slices.Clone(items)
+		slices.SortStableFunc(expected, func(a, b item) int { return cmp.Compare(a.key, b.key) })
+
+		if err := CountingSort(items, func(v item) int { return v.key }); err != nil {
+			t.Fatalf("CountingSort returned %v", err)
+		}
+		if !reflect.DeepEqual(items, expected) {
+			t.Fatalf("CountingSort is not a stable sort for n=%d", n)
+		}
+	}
+
+	wide := []int{0, MaxCountingRange}
+	if err := CountingSort(wide, func(v int) int { return v }); !errors.Is(err, ErrRangeTooLarge) {
+		t.Errorf("CountingSort over a wide range returned %v, expected ErrRangeTooLarge", err)
+	}
+	extreme := []int{math.MinInt, math.MaxInt}
+	if err := CountingSort(extreme, func(v int) int { return v }); !errors.Is(err, ErrRangeTooLarge) {
+		t.Errorf("CountingSort over the full int range returned %v, expected ErrRangeTooLarge", err)
+	}
+}
+
+func TestRadixSortUnsigned(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	base := uint64(1_700_000_000_000_000_000)
+
+	events := make([]event, 5000)
+	for i := range events {
+		events[i] = event{timestamp: base + uint64(rng.Intn(1_000_000)), id: i}
+	}
+	expected := slices.Clone(events)
+	slices.SortStableFunc(expected, func(a, b event) int { return cmp.Compare(a.timestamp, b.timestamp) })
+
+	RadixSort(events, func(e event) uint64 { return e.timestamp })
+	if !reflect.DeepEqual(events, expected) {
+		t.Error("RadixSort on uint64 timestamps is not a stable sort")
+	}
+
+	small := []uint8{200, 3, 255, 0, 3, 17}
+	RadixSort(small, func(v uint8) uint8 { return v })
+	if !slices.Equal(small, []uint8{0, 3, 3, 17, 200, 255}) {
+		t.Errorf("RadixSort on uint8 = %v", small)
+	}
+}
+
+func TestRadixSortSigned(t *testing.T) {
+	int8s := []int8{-1, 127, -128, 0, 5, -5}
+	RadixSortSigned(int8s, func(v int8) int8 { return v })
+	if !slices.Equal(int8s, []int8{-128, -5, -1, 0, 5, 127}) {
+		t.Errorf("RadixSortSigned on int8 = %v", int8s)
+	}
+
+	rng := rand.New(rand.NewSource(3))
+	int64s := make([]int64, 2000)
+	for i := range int64s {
+		int64s[i] = rng.Int63() - rng.Int63()
+	}
+	int64s = append(int64s, math.MinInt64, math.MaxInt64, 0, -1)
+	expected := slices.Clone(int64s)
+	slices.Sort(expected)
+
+	RadixSortSigned(int64s, func(v int64) int64 { return v })
+	if !slices.Equal(int64s, expected) {
+		t.Error("RadixSortSigned on int64 does not match slices.Sort")
+	}
+
+	ints := []int{3, -7, 0, math.MinInt, 42}
+	RadixSortSigned(ints, func(v int) int { return v })
+	if !slices.Equal(ints, []int{math.MinInt, -7, 0, 3, 42}) {
+		t.Errorf("RadixSortSigned on int = %v", ints)
+	}
+}
+
+func TestRadixSortFloat(t *testing.T) {
+	float64s := []float64{3.5, -0.25, math.Inf(1), 0, -1e300, math.Inf(-1), 1e-300, -7, 2}
+	RadixSortFloat(float64s, func(v float64) float64 { return v })
+	if expected := []float64{math.Inf(-1), -1e300, -7, -0.25, 0, 1e-300, 2, 3.5, math.Inf(1)}; !slices.Equal(float64s, expected) {
+		t.Errorf("RadixSortFloat on float64 = %v", float64s)
+	}
+
+	float32s := []float32{1.5, -2, 0, -0.5, 100}
+	RadixSortFloat(float32s, func(v float32) float32 { return v })
+	if !slices.Equal(float32s, []float32{-2, -0.5, 0, 1.5, 100}) {
+		t.Errorf("RadixSortFloat on float32 = %v", float32s)
+	}
+
+	if FloatKey(math.Copysign(0, -1)) >= FloatKey(0.0) {
+		t.Error("FloatKey should order -0 before +0")
+	}
+	if FloatKey(math.NaN()) <= FloatKey(math.Inf(1)) {
+		t.Error("FloatKey should order positive NaN after +Inf")
+	}
+
+	rng := rand.New(rand.NewSource(4))
+	random := make([]float64, 3000)
+	for i := range random {
+		random[i] = rng.NormFloat64() * math.Pow(10, float64(rng.Intn(20)-10))
+	}
+	expected := slices.Clone(random)
+	slices.Sort(expected)
+	RadixSortFloat(random, func(v float64) float64 { return v })
+	if !slices.Equal(random, expected) {
+		t.Error("RadixSortFloat does not match slices.Sort on random floats")
+	}
+}
+
+func randomWords(rng *rand.Rand, n int) []string {
+	words := make([]string, n)
+	for i := range words {
+		var b strings.Builder
+		for range rng.Intn(8) {
+			b.WriteByte("abcz\x00\xff"[rng.Intn(6)])
+		}
+		words[i] = b.String()
+	}
+	return words
+}
+
+func TestStringRadixSorts(t *testing.T) {
+	rng := rand.New(rand.NewSource(5))
+	type entry struct {
+		word  string
+		order int
+	}
+
+	for _, n := range []int{0, 1, 5, 16, 17, 200, 5000} {
+		words := randomWords(rng, n)
+		entries := make([]entry, n)
+		for i, word := range words {
+			entries[i] = entry{word: word, order: i}
+		}
+		byWord := func(a, b entry) int { return strings.Compare(a.word, b.word) }
+		expected := slices.Clone(entries)
+		slices.SortStableFunc(expected, byWord)
+
+		msd := slices.Clone(entries)
+		MSDRadixSort(msd, func(e entry) string { return e.word })
+		if !reflect.DeepEqual(msd, expected) {
+			t.Fatalf("MSDRadixSort is not a stable sort for n=%d", n)
+		}
+
+		flag := slices.Clone(entries)
+		AmericanFlagSort(flag, func(e entry) string { return e.word })
+		if !slices.IsSortedFunc(flag, byWord) {
+			t.Fatalf("AmericanFlagSort did not sort n=%d", n)
+		}
+		gotWords, expectedWords := make([]string, n), make([]string, n)
+		for i := range flag {
+			gotWords[i], expectedWords[i] = flag[i].word, expected[i].word
+		}
+		if !slices.Equal(gotWords, expectedWords) {
+			t.Fatalf("AmericanFlagSort is not a permutation of the input for n=%d", n)
+		}
+	}
+}
+
+func TestBucketSort(t *testing.T) {
+	rng := rand.New(rand.NewSource(6))
+	type sample struct {
+		value float64
+		order int
+	}
+
+	for _, n := range []int{0, 1, 2, 100, 5000} {
+		samples := make([]sample, n)
+		for i := range samples {
+			samples[i] = sample{value: math.Round(rng.Float64()*1000) / 1000, order: i}
+		}
+		expected := slices.Clone(samples)
+		slices.SortStableFunc(expected, func(a, b sample) int { return cmp.Compare(a.value, b.value) })
+
+		BucketSort(samples, func(s sample) float64 { return s.value })
+		if !reflect.DeepEqual(samples, expected) {
+			t.Fatalf("BucketSort is not a stable sort for n=%d", n)
+		}
+	}
+
+	special := []float64{math.Inf(1), 2, math.NaN(), -1, math.Inf(-1), 2}
+	BucketSort(special, func(v float64) float64 { return v })
+	if !math.IsNaN(special[0]) || !slices.Equal(special[1:], []float64{math.Inf(-1), -1, 2, 2, math.Inf(1)}) {
+		t.Errorf("BucketSort with NaN and infinities = %v", special)
+	}
+}
+
+func TestRun(t *testing.T) {
+	result := Run()
+	if result == nil {
+		t.Error("Expected non-nil result")
+	}
+
+	resultMap, ok := result.(map[string]any)
+	if !ok {
+		t.Fatal("Expected result to be a map")
+	}
+	if !reflect.DeepEqual(resultMap["msd_radix"], resultMap["american_flag"]) {
+		t.Errorf("MSD radix %v and American flag %v disagree", resultMap["msd_radix"], resultMap["american_flag"])
+	}
+}
+
+func BenchmarkUint64Timestamps(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	base := uint64(1_700_000_000_000_000_000)
+	input := make([]uint64, 1<<20)
+	for i := range input {
+		input[i] = base + uint64(rng.Int63n(86_400_000_000_000))
+	}
+	data := make([]uint64, len(input))
+
+	b.Run("slices.Sort", func(b *testing.B) {
+		for b.Loop() {
+			copy(data, input)
+			slices.Sort(data)
+		}
+	})
+	b.Run("RadixSort", func(b *testing.B) {
+		for b.Loop() {
+			copy(data, input)
+			RadixSort(data, func(v uint64) uint64 { return v })
+		}
+	})
+}
+
+func BenchmarkStrings(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	input := make([]string, 1<<16)
+	for i := range input {
+		input[i] = fmt.Sprintf("user-%08d/session-%04d", rng.Intn(1_000_000), rng.Intn(10_000))
+	}
+	data := make([]string, len(input))
+	identity := func(v string) string { return v }
+
+	b.Run("slices.Sort", func(b *testing.B) {
+		for b.Loop() {
+			copy(data, input)
+			slices.Sort(data)
+		}
+	})
+	b.Run("MSDRadixSort", func(b *testing.B) {
+		for b.Loop() {
+			copy(data, input)
+			MSDRadixSort(data, identity)
+		}
+	})
+	b.Run("AmericanFlagSort", func(b *testing.B) {
+		for b.Loop() {
+			copy(data, input)
+			AmericanFlagSort(data, identity)
+		}
+	})
+}
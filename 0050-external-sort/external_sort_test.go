@@ -0,0 +1,295 @@
+package external_sort
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func randomLines(rng *rand.Rand, n int) []string {
+	lines := make([]string, n)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("%08x-%d", rng.Uint32(), rng.Intn(1000))
+	}
+	return lines
+}
+
+func readLines(t *testing.T, s string) []string {
+	t.Helper()
+	if s == "" {
+		return nil
+	}
+	if !strings.HasSuffix(s, "\n") {
+		t.Fatalf("output does not end with a newline: %q", s)
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+func assertNoRunsLeft(t *testing.T, dir string) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("expected temp dir to be empty, found %d files", len(entries))
+	}
+}
+
+func TestSortLinesInMemory(t *testing.T) {
+	dir := t.TempDir()
+	input := "pear\napple\nfig\nbanana"
+
+	var out strings.Builder
+	stats, err := Sort(strings.NewReader(input), &out, Lines{}, strings.Compare, Options{TempDir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"apple", "banana", "fig", "pear"}
+	if got := readLines(t, out.String()); !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if stats.Records != 4 || stats.Runs != 0 || stats.MergePasses != 0 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+	assertNoRunsLeft(t, dir)
+}
+
+func TestSortLinesSpillsRuns(t *testing.T) {
+	dir := t.TempDir()
+	rng := rand.New(rand.NewSource(1))
+	lines := randomLines(rng, 5000)
+
+	var out strings.Builder
+	stats, err := Sort(strings.NewReader(strings.Join(lines, "\n")+"\n"), &out, Lines{}, strings.Compare, Options{MemoryBudget: 4096, TempDir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := slices.Clone(lines)
+	slices.Sort(want)
+	if got := readLines(t, out.String()); !slices.Equal(got, want) {
+		t.Error("external sort output differs from slices.Sort")
+	}
+	if stats.Records != len(lines) {
+		t.Errorf("expected %d records, got %d", len(lines), stats.Records)
+	}
+	if stats.Runs < 2 {
+		t.Errorf("expected the budget to force several runs, got %d", stats.Runs)
+	}
+	if stats.MergePasses != 1 {
+		t.Errorf("expected a single merge pass, got %d", stats.MergePasses)
+	}
+	assertNoRunsLeft(t, dir)
+}
+
+func TestSortMultiPassMerge(t *testing.T) {
+	dir := t.TempDir()
+	rng := rand.New(rand.NewSource(2))
+	lines := randomLines(rng, 2000)
+
+	var out strings.Builder
+	stats, err := Sort(strings.NewReader(strings.Join(lines, "\n")), &out, Lines{}, strings.Compare, Options{MemoryBudget: 512, MaxFanIn: 3, TempDir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := slices.Clone(lines)
+	slices.Sort(want)
+	if got := readLines(t, out.String()); !slices.Equal(got, want) {
+		t.Error("multi-pass output differs from slices.Sort")
+	}
+
+	passes, runs := 0, stats.Runs
+	for runs > 1 {
+		runs = (runs + 2) / 3
+		passes++
+	}
+	if stats.MergePasses != passes {
+		t.Errorf("expected %d merge passes for %d runs, got %d", passes, stats.Runs, stats.MergePasses)
+	}
+	assertNoRunsLeft(t, dir)
+}
+
+func TestSortIsStable(t *testing.T) {
+	dir := t.TempDir()
+	var input strings.Builder
+	for i := range 600 {
+		fmt.Fprintf(&input, "%d,%d\n", i%5, i)
+	}
+
+	byKey := CSVColumn{Column: 0, Numeric: true}
+	var out strings.Builder
+	stats, err := Sort(strings.NewReader(input.String()), &out, byKey, byKey.Compare, Options{MemoryBudget: 1024, MaxFanIn: 4, TempDir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.MergePasses < 2 {
+		t.Fatalf("expected a multi-pass merge, got %+v", stats)
+	}
+
+	lastKey, lastSeq := -1, -1
+	for _, line := range readLines(t, out.String()) {
+		var key, seq int
+		fmt.Sscanf(line, "%d,%d", &key, &seq)
+		if key < lastKey || (key == lastKey && seq < lastSeq) {
+			t.Fatalf("order broken at %q after key %d seq %d", line, lastKey, lastSeq)
+		}
+		lastKey, lastSeq = key, seq
+	}
+}
+
+func TestLengthPrefixedRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	rng := rand.New(rand.NewSource(3))
+	records := make([][]byte, 1000)
+	for i := range records {
+		records[i] = make([]byte, rng.Intn(40))
+		rng.Read(records[i])
+	}
+	records[0] = []byte{}
+	records[1] = []byte{'\n', 0, '\n'}
+
+	var in bytes.Buffer
+	enc := LengthPrefixed{}.NewEncoder(&in)
+	for _, record := range records {
+		if err := enc.Encode(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := enc.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if _, err := Sort(&in, &out, LengthPrefixed{}, bytes.Compare, Options{MemoryBudget: 2048, TempDir: dir}); err != nil {
+		t.Fatal(err)
+	}
+
+	var got [][]byte
+	dec := LengthPrefixed{}.NewDecoder(&out)
+	for {
+		record, err := dec.Decode()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, record)
+	}
+
+	want := slices.Clone(records)
+	slices.SortFunc(want, bytes.Compare)
+	if !slices.EqualFunc(got, want, bytes.Equal) {
+		t.Error("length-prefixed output differs from slices.SortFunc")
+	}
+	assertNoRunsLeft(t, dir)
+}
+
+func TestLengthPrefixedErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	truncated := []byte{5, 'a', 'b'}
+	_, err := Sort(bytes.NewReader(truncated), io.Discard, LengthPrefixed{}, bytes.Compare, Options{TempDir: dir})
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("expected io.ErrUnexpectedEOF, got %v", err)
+	}
+
+	huge := []byte{0xff, 0xff, 0x03}
+	_, err = Sort(bytes.NewReader(huge), io.Discard, LengthPrefixed{MaxRecordSize: 1024}, bytes.Compare, Options{TempDir: dir})
+	if !errors.Is(err, ErrRecordTooLarge) {
+		t.Errorf("expected ErrRecordTooLarge, got %v", err)
+	}
+	assertNoRunsLeft(t, dir)
+}
+
+func TestCSVColumnCompare(t *testing.T) {
+	byName := CSVColumn{Column: 0}
+	byPrice := CSVColumn{Column: 1, Numeric: true}
+
+	testCases := []struct {
+		column CSVColumn
+		a, b   []string
+		want   int
+	}{
+		{byName, []string{"apple", "9"}, []string{"banana", "10"}, -1},
+		{byPrice, []string{"apple", "9"}, []string{"banana", "10"}, -1},
+		{byPrice, []string{"apple", "9.5"}, []string{"banana", "9.5"}, 0},
+		{byPrice, []string{"apple", "n/a"}, []string{"banana", "1e9"}, 1},
+		{byPrice, []string{"apple"}, []string{"banana", "3"}, 1},
+		{CSVColumn{Column: 5}, []string{"a"}, []string{"b"}, 0},
+	}
+
+	for i, tc := range testCases {
+		if got := tc.column.Compare(tc.a, tc.b); got != tc.want {
+			t.Errorf("test case %d: Compare(%v, %v) = %d, want %d", i+1, tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestCSVColumnSort(t *testing.T) {
+	dir := t.TempDir()
+	input := "name;city\n\"Doe; Jane\";Lima\nRoe;Austin\n\"Quote \"\"Q\"\"\";Berlin\n"
+	byCity := CSVColumn{Column: 1, Comma: ';'}
+
+	var out strings.Builder
+	if _, err := Sort(strings.NewReader(input), &out, byCity, byCity.Compare, Options{MemoryBudget: 80, TempDir: dir}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "Roe;Austin\n\"Quote \"\"Q\"\"\";Berlin\n\"Doe; Jane\";Lima\nname;city\n"
+	if out.String() != want {
+		t.Errorf("expected %q, got %q", want, out.String())
+	}
+	assertNoRunsLeft(t, dir)
+}
+
+func TestSortEmptyInput(t *testing.T) {
+	var out strings.Builder
+	stats, err := Sort(strings.NewReader(""), &out, Lines{}, strings.Compare, Options{TempDir: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Len() != 0 || stats.Records != 0 {
+		t.Errorf("expected empty output, got %q with %+v", out.String(), stats)
+	}
+}
+
+func TestRun(t *testing.T) {
+	result := Run()
+	if result == nil {
+		t.Error("Expected non-nil result")
+	}
+
+	resultMap, ok := result.(map[string]any)
+	if !ok {
+		t.Fatal("Expected result to be a map")
+	}
+
+	lines := resultMap["lines"].(map[string]any)
+	if lines["error"] != "<nil>" || !slices.IsSorted(lines["sorted"].([]string)) {
+		t.Errorf("unexpected lines result %v", lines)
+	}
+}
+
+func BenchmarkSortLines(b *testing.B) {
+	rng := rand.New(rand.NewSource(4))
+	input := strings.Join(randomLines(rng, 1<<17), "\n")
+	dir := b.TempDir()
+
+	for _, budget := range []int{1 << 24, 1 << 20, 1 << 16} {
+		b.Run(fmt.Sprintf("budget=%dKiB", budget>>10), func(b *testing.B) {
+			for b.Loop() {
+				Sort(strings.NewReader(input), io.Discard, Lines{}, strings.Compare, Options{MemoryBudget: budget, TempDir: dir})
+			}
+		})
+	}
+}
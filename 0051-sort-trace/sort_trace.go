@@ -0,0 +1,485 @@
+package sort_trace
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/celj/dsa/0004-bubble-sort"
+	"github.com/celj/dsa/0012-quick-sort"
+	"github.com/celj/dsa/0013-merge-sort"
+	"github.com/celj/dsa/0048-sort"
+)
+
+type Op int
+
+const (
+	Compare Op = iota
+	Swap
+	Write
+)
+
+var opNames = [...]string{"compare", "swap", "write"}
+
+func (o Op) String() string {
+	if o < 0 || int(o) >= len(opNames) {
+		return fmt.Sprintf("Op(%d)", int(o))
+	}
+	return opNames[o]
+}
+
+func (o Op) MarshalText() ([]byte, error) {
+	if o < 0 || int(o) >= len(opNames) {
+		return nil, fmt.Errorf("sort_trace: unknown op %d", int(o))
+	}
+	return []byte(opNames[o]), nil
+}
+
+func (o *Op) UnmarshalText(text []byte) error {
+	i := slices.Index(opNames[:], string(text))
+	if i < 0 {
+		return fmt.Errorf("sort_trace: unknown op %q", text)
+	}
+	*o = Op(i)
+	return nil
+}
+
+type Event struct {
+	Op    Op  `json:"op"`
+	I     int `json:"i"`
+	J     int `json:"j,omitempty"`
+	Value int `json:"value,omitempty"`
+}
+
+func (e Event) String() string {
+	switch e.Op {
+	case Compare:
+		return fmt.Sprintf("compare [%d] [%d]", e.I, e.J)
+	case Swap:
+		return fmt.Sprintf("swap [%d] [%d]", e.I, e.J)
+	default:
+		return fmt.Sprintf("write [%d] = %d", e.I, e.Value)
+	}
+}
+
+func (e Event) apply(state []int) {
+	switch e.Op {
+	case Swap:
+		state[e.I], state[e.J] = state[e.J], state[e.I]
+	case Write:
+		state[e.I] = e.Value
+	}
+}
+
+type Counts struct {
+	Comparisons int `json:"comparisons"`
+	Swaps       int `json:"swaps"`
+	Writes      int `json:"writes"`
+}
+
+type Trace struct {
+	Algorithm string  `json:"algorithm"`
+	Initial   []int   `json:"initial"`
+	Scratch   int     `json:"scratch,omitempty"`
+	Events    []Event `json:"events"`
+	Counts    Counts  `json:"counts"`
+
+	live []int
+}
+
+func NewTrace(algorithm string, initial []int) *Trace {
+	return &Trace{Algorithm: algorithm, Initial: slices.Clone(initial)}
+}
+
+func Record(algorithm string, arr []int, sort func(arr []int, t *Trace)) (*Trace, []int) {
+	t := NewTrace(algorithm, arr)
+	result := slices.Clone(arr)
+	sort(result, t)
+	return t, result
+}
+
+func (t *Trace) Reserve(n int) {
+	if t == nil || n <= t.Scratch {
+		return
+	}
+	t.Scratch = n
+	if t.live != nil {
+		t.live = append(t.live, make([]int, len(t.Initial)+n-len(t.live))...)
+	}
+}
+
+func (t *Trace) cover(i int) {
+	if extra := i - len(t.Initial) + 1; extra > t.Scratch {
+		t.Reserve(extra)
+	}
+}
+
+func (t *Trace) ScratchIndex(k int) int {
+	if t == nil {
+		return 0
+	}
+	return len(t.Initial) + k
+}
+
+func (t *Trace) Compare(i, j int) {
+	if t == nil {
+		return
+	}
+	t.cover(max(i, j))
+	t.Events = append(t.Events, Event{Op: Compare, I: i, J: j})
+	t.Counts.Comparisons++
+}
+
+func (t *Trace) Swap(i, j int) {
+	if t == nil {
+		return
+	}
+	t.cover(max(i, j))
+	t.Events = append(t.Events, Event{Op: Swap, I: i, J: j})
+	t.Counts.Swaps++
+	if t.live != nil {
+		t.live[i], t.live[j] = t.live[j], t.live[i]
+	}
+}
+
+func (t *Trace) Write(i, value int) {
+	if t == nil {
+		return
+	}
+	t.cover(i)
+	t.Events = append(t.Events, Event{Op: Write, I: i, Value: value})
+	t.Counts.Writes++
+	if t.live != nil {
+		t.live[i] = value
+	}
+}
+
+func (t *Trace) Copy(dst, src int) {
+	if t == nil {
+		return
+	}
+	t.cover(src)
+	t.Write(dst, t.current()[src])
+}
+
+func (t *Trace) current() []int {
+	if t.live == nil {
+		t.live = t.initialState()
+		for _, e := range t.Events {
+			e.apply(t.live)
+		}
+	}
+	return t.live
+}
+
+func (t *Trace) initialState() []int {
+	state := make([]int, len(t.Initial)+t.Scratch)
+	copy(state, t.Initial)
+	return state
+}
+
+func (t *Trace) Replay(visit func(step int, e Event, state []int) bool) {
+	state := t.initialState()
+	for step, e := range t.Events {
+		e.apply(state)
+		if !visit(step, e, state) {
+			return
+		}
+	}
+}
+
+func (t *Trace) Final() []int {
+	state := t.initialState()
+	for _, e := range t.Events {
+		e.apply(state)
+	}
+	return state[:len(t.Initial)]
+}
+
+func (t *Trace) Snapshots() [][]int {
+	n := len(t.Initial)
+	snapshots := [][]int{slices.Clone(t.Initial)}
+	t.Replay(func(_ int, e Event, state []int) bool {
+		if e.Op != Compare && (e.I < n || (e.Op == Swap && e.J < n)) {
+			snapshots = append(snapshots, slices.Clone(state[:n]))
+		}
+		return true
+	})
+	return snapshots
+}
+
+func (t *Trace) ComparedValues() [][2]int {
+	var pairs [][2]int
+	t.Replay(func(_ int, e Event, state []int) bool {
+		if e.Op == Compare {
+			pairs = append(pairs, [2]int{state[e.I], state[e.J]})
+		}
+		return true
+	})
+	return pairs
+}
+
+func (t *Trace) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(t)
+}
+
+func ReadJSON(r io.Reader) (*Trace, error) {
+	var t Trace
+	if err := json.NewDecoder(r).Decode(&t); err != nil {
+		return nil, err
+	}
+	size := len(t.Initial) + t.Scratch
+	if t.Scratch < 0 {
+		return nil, fmt.Errorf("sort_trace: negative scratch size %d", t.Scratch)
+	}
+	for _, e := range t.Events {
+		if e.I < 0 || e.I >= size || (e.Op != Write && (e.J < 0 || e.J >= size)) {
+			return nil, fmt.Errorf("sort_trace: event %v out of range for %d elements", e, size)
+		}
+	}
+	return &t, nil
+}
+
+func (t *Trace) valueRange() (int, int) {
+	lo, hi := 0, 0
+	first := true
+	observe := func(v int) {
+		if first || v < lo {
+			lo = v
+		}
+		if first || v > hi {
+			hi = v
+		}
+		first = false
+	}
+	for _, v := range t.Initial {
+		observe(v)
+	}
+	for _, e := range t.Events {
+		if e.Op == Write {
+			observe(e.Value)
+		}
+	}
+	return lo, hi
+}
+
+type SVGOptions struct {
+	Width  int
+	Height int
+	Step   time.Duration
+}
+
+const (
+	barColor     = "#4c72b0"
+	compareColor = "#f4a261"
+	swapColor    = "#e76f51"
+	writeColor   = "#2a9d8f"
+	scratchColor = "#9fb3c8"
+)
+
+func (t *Trace) WriteSVG(w io.Writer, opts SVGOptions) error {
+	if opts.Width <= 0 {
+		opts.Width = 640
+	}
+	if opts.Height <= 0 {
+		opts.Height = 320
+	}
+	if opts.Step <= 0 {
+		opts.Step = 100 * time.Millisecond
+	}
+
+	n := len(t.Initial) + t.Scratch
+	lo, hi := t.valueRange()
+	barWidth := float64(opts.Width) / float64(max(n, 1))
+	height := func(v int) float64 {
+		return float64(opts.Height) * float64(v-lo+1) / float64(hi-lo+1)
+	}
+	step := opts.Step.Seconds()
+
+	animations := make([]strings.Builder, n)
+	setHeight := func(i, v int, begin float64) {
+		h := height(v)
+		fmt.Fprintf(&animations[i], `<set attributeName="height" to="%.2f" begin="%.3fs" fill="freeze"/>`, h, begin)
+		fmt.Fprintf(&animations[i], `<set attributeName="y" to="%.2f" begin="%.3fs" fill="freeze"/>`, float64(opts.Height)-h, begin)
+	}
+	highlight := func(i int, color string, begin float64) {
+		fmt.Fprintf(&animations[i], `<set attributeName="fill" to="%s" begin="%.3fs" dur="%.3fs"/>`, color, begin, step)
+	}
+
+	t.Replay(func(k int, e Event, state []int) bool {
+		begin := float64(k) * step
+		switch e.Op {
+		case Compare:
+			highlight(e.I, compareColor, begin)
+			highlight(e.J, compareColor, begin)
+		case Swap:
+			highlight(e.I, swapColor, begin)
+			highlight(e.J, swapColor, begin)
+			setHeight(e.I, state[e.I], begin)
+			setHeight(e.J, state[e.J], begin)
+		case Write:
+			highlight(e.I, writeColor, begin)
+			setHeight(e.I, e.Value, begin)
+		}
+		return true
+	})
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n",
+		opts.Width, opts.Height+20, opts.Width, opts.Height+20)
+	fmt.Fprintf(bw, `<text x="4" y="%d" font-family="monospace" font-size="12">%s: %d comparisons, %d swaps, %d writes</text>`+"\n",
+		opts.Height+16, t.Algorithm, t.Counts.Comparisons, t.Counts.Swaps, t.Counts.Writes)
+	for i := range n {
+		h, color := 0.0, scratchColor
+		if i < len(t.Initial) {
+			h, color = height(t.Initial[i]), barColor
+		}
+		fmt.Fprintf(bw, `<rect x="%.2f" y="%.2f" width="%.2f" height="%.2f" fill="%s">%s</rect>`+"\n",
+			float64(i)*barWidth+1, float64(opts.Height)-h, max(barWidth-2, 1), h, color, animations[i].String())
+	}
+	fmt.Fprintln(bw, "</svg>")
+	return bw.Flush()
+}
+
+type FrameOptions struct {
+	Width   int
+	Delay   time.Duration
+	Animate bool
+}
+
+func (t *Trace) WriteFrames(w io.Writer, opts FrameOptions) error {
+	if opts.Width <= 0 {
+		opts.Width = 40
+	}
+
+	lo, hi := t.valueRange()
+	n := len(t.Initial)
+	filled := make([]bool, t.Scratch)
+	bw := bufio.NewWriter(w)
+	var err error
+
+	draw := func(title string, state []int, active ...int) {
+		if opts.Animate {
+			bw.WriteString("\x1b[H\x1b[2J")
+		}
+		fmt.Fprintln(bw, title)
+		for i, v := range state {
+			marker := ""
+			if slices.Contains(active, i) {
+				marker = " ◀"
+			}
+			if i >= n {
+				if i == n {
+					fmt.Fprintln(bw, "    ┄ scratch")
+				}
+				if !filled[i-n] {
+					fmt.Fprintf(bw, "%3d │%s\n", i, marker)
+					continue
+				}
+			}
+			bar := strings.Repeat("█", max(1, opts.Width*(v-lo+1)/(hi-lo+1)))
+			fmt.Fprintf(bw, "%3d │%s %d%s\n", i, bar, v, marker)
+		}
+		fmt.Fprintln(bw)
+		if err = bw.Flush(); err == nil && opts.Delay > 0 {
+			time.Sleep(opts.Delay)
+		}
+	}
+
+	draw(fmt.Sprintf("%s: initial", t.Algorithm), t.initialState())
+	t.Replay(func(k int, e Event, state []int) bool {
+		if e.Op == Write && e.I >= n {
+			filled[e.I-n] = true
+		}
+		title := fmt.Sprintf("%s: step %d/%d %v", t.Algorithm, k+1, len(t.Events), e)
+		if e.Op == Write {
+			draw(title, state, e.I)
+		} else {
+			draw(title, state, e.I, e.J)
+		}
+		return err == nil
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(bw, "%d comparisons, %d swaps, %d writes\n", t.Counts.Comparisons, t.Counts.Swaps, t.Counts.Writes)
+	return bw.Flush()
+}
+
+func insertionSort(arr []int, t *Trace) {
+	for i := 1; i < len(arr); i++ {
+		for j := i; j > 0; j-- {
+			t.Compare(j-1, j)
+			if arr[j-1] <= arr[j] {
+				break
+			}
+			arr[j-1], arr[j] = arr[j], arr[j-1]
+			t.Swap(j-1, j)
+		}
+	}
+}
+
+var tracedSorts = []struct {
+	name string
+	sort func(arr []int, t *Trace)
+}{
+	{"bubble", func(arr []int, t *Trace) { bubble_sort.BubbleSortTraced(arr, t) }},
+	{"quick", func(arr []int, t *Trace) { quick_sort.QuickSortTraced(arr, t) }},
+	{"merge", func(arr []int, t *Trace) { merge_sort.MergeSortTraced(arr, t) }},
+	{"heap", func(arr []int, t *Trace) { sort.HeapSortTraced(arr, t) }},
+	{"intro", func(arr []int, t *Trace) { sort.IntroSortTraced(arr, t) }},
+	{"pdq", func(arr []int, t *Trace) { sort.PDQSortTraced(arr, t) }},
+	{"tim", func(arr []int, t *Trace) { sort.TimSortTraced(arr, t) }},
+}
+
+func Run() any {
+	arr := []int{5, 2, 4, 6, 1, 3}
+	t, sorted := Record("insertion sort", arr, insertionSort)
+
+	events := make([]string, len(t.Events))
+	for i, e := range t.Events {
+		events[i] = e.String()
+	}
+
+	var frames strings.Builder
+	t.WriteFrames(&frames, FrameOptions{Width: 12})
+
+	var svg strings.Builder
+	t.WriteSVG(&svg, SVGOptions{})
+
+	var encoded strings.Builder
+	t.WriteJSON(&encoded)
+	decoded, err := ReadJSON(strings.NewReader(encoded.String()))
+
+	counts := make(map[string]Counts, len(tracedSorts))
+	for _, traced := range tracedSorts {
+		trace, _ := Record(traced.name, arr, traced.sort)
+		counts[traced.name] = trace.Counts
+	}
+
+	return map[string]any{
+		"original":            arr,
+		"sorted":              sorted,
+		"counts":              t.Counts,
+		"events":              events,
+		"snapshots":           t.Snapshots(),
+		"last_frame":          lastFrame(frames.String()),
+		"svg_bytes":           svg.Len(),
+		"json_bytes":          encoded.Len(),
+		"json_roundtrip_ok":   err == nil && slices.Equal(decoded.Final(), sorted),
+		"replay_matches_sort": slices.Equal(t.Final(), sorted),
+		"traced_sort_counts":  counts,
+	}
+}
+
+func lastFrame(frames string) []string {
+	blocks := strings.Split(strings.TrimSpace(frames), "\n\n")
+	return strings.Split(blocks[len(blocks)-2], "\n")
+}
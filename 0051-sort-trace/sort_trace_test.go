@@ -0,0 +1,360 @@
+package sort_trace
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"math/rand"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func selectionSort(arr []int, t *Trace) {
+	for i := range arr {
+		best := i
+		for j := i + 1; j < len(arr); j++ {
+			t.Compare(j, best)
+			if arr[j] < arr[best] {
+				best = j
+			}
+		}
+		if best != i {
+			arr[i], arr[best] = arr[best], arr[i]
+			t.Swap(i, best)
+		}
+	}
+}
+
+func writeSort(arr []int, t *Trace) {
+	sorted := slices.Sorted(slices.Values(arr))
+	for i, v := range sorted {
+		arr[i] = v
+		t.Write(i, v)
+	}
+}
+
+func insertionSortWithHole(arr []int, t *Trace) {
+	t.Reserve(1)
+	hole := t.ScratchIndex(0)
+	for i := 1; i < len(arr); i++ {
+		v := arr[i]
+		t.Copy(hole, i)
+		j := i
+		for ; j > 0; j-- {
+			t.Compare(j-1, hole)
+			if arr[j-1] <= v {
+				break
+			}
+			arr[j] = arr[j-1]
+			t.Copy(j, j-1)
+		}
+		arr[j] = v
+		t.Copy(j, hole)
+	}
+}
+
+func TestRecordCountsAndReplay(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	arr := rng.Perm(30)
+
+	trace, sorted := Record("selection sort", arr, selectionSort)
+	if !slices.IsSorted(sorted) {
+		t.Fatalf("sort did not sort: %v", sorted)
+	}
+	if !slices.Equal(trace.Final(), sorted) {
+		t.Errorf("replayed final state %v differs from sorted result %v", trace.Final(), sorted)
+	}
+	if trace.Counts.Comparisons != 30*29/2 {
+		t.Errorf("expected %d comparisons, got %d", 30*29/2, trace.Counts.Comparisons)
+	}
+	if trace.Counts.Swaps > 29 || trace.Counts.Writes != 0 {
+		t.Errorf("unexpected counts %+v", trace.Counts)
+	}
+	if len(trace.Events) != trace.Counts.Comparisons+trace.Counts.Swaps {
+		t.Errorf("expected %d events, got %d", trace.Counts.Comparisons+trace.Counts.Swaps, len(trace.Events))
+	}
+	if !reflect.DeepEqual(trace.Initial, arr) {
+		t.Errorf("initial state %v should equal input %v", trace.Initial, arr)
+	}
+}
+
+func TestNilTraceIsNoOp(t *testing.T) {
+	var trace *Trace
+	trace.Compare(0, 1)
+	trace.Swap(0, 1)
+	trace.Write(0, 5)
+	trace.Copy(0, 1)
+	trace.Reserve(4)
+	if trace.ScratchIndex(2) != 0 {
+		t.Errorf("expected a nil trace to have no scratch positions")
+	}
+
+	arr := []int{3, 1, 2}
+	selectionSort(arr, nil)
+	if !slices.Equal(arr, []int{1, 2, 3}) {
+		t.Errorf("expected sorting without a trace to work, got %v", arr)
+	}
+}
+
+func TestSnapshots(t *testing.T) {
+	trace, _ := Record("write sort", []int{3, 1, 2}, writeSort)
+	want := [][]int{{3, 1, 2}, {1, 1, 2}, {1, 2, 2}, {1, 2, 3}}
+	if got := trace.Snapshots(); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected snapshots %v, got %v", want, got)
+	}
+
+	steps := 0
+	trace.Replay(func(step int, e Event, state []int) bool {
+		steps++
+		return step < 1
+	})
+	if steps != 2 {
+		t.Errorf("expected Replay to stop after 2 events, visited %d", steps)
+	}
+}
+
+func TestScratch(t *testing.T) {
+	arr := []int{4, 1, 3, 2}
+	trace, sorted := Record("insertion sort with a hole", arr, insertionSortWithHole)
+	if !slices.Equal(trace.Final(), sorted) || len(trace.Final()) != len(arr) {
+		t.Errorf("replayed final state %v differs from sorted result %v", trace.Final(), sorted)
+	}
+	if trace.Scratch != 1 || trace.ScratchIndex(0) != len(arr) {
+		t.Errorf("expected one scratch cell after the array, got %d at %d", trace.Scratch, trace.ScratchIndex(0))
+	}
+	want := [][2]int{{4, 1}, {4, 3}, {1, 3}, {4, 2}, {3, 2}, {1, 2}}
+	if got := trace.ComparedValues(); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected compared values %v, got %v", want, got)
+	}
+	for _, snapshot := range trace.Snapshots() {
+		if len(snapshot) != len(arr) {
+			t.Fatalf("expected snapshots of the array only, got %v", snapshot)
+		}
+	}
+
+	var buf bytes.Buffer
+	trace.WriteJSON(&buf)
+	decoded, err := ReadJSON(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Scratch != 1 || !slices.Equal(decoded.Final(), sorted) {
+		t.Errorf("expected the scratch cell to survive a JSON round trip, got %d and %v", decoded.Scratch, decoded.Final())
+	}
+	decoded.Copy(0, 3)
+	if got := decoded.Events[len(decoded.Events)-1]; got.Op != Write || got.Value != 4 {
+		t.Errorf("expected Copy on a decoded trace to write the replayed value 4, got %v", got)
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	trace, sorted := Record("selection sort", []int{4, -2, 7, 0}, selectionSort)
+	trace.Write(0, -2)
+
+	var buf bytes.Buffer
+	if err := trace.WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `"op": "swap"`) {
+		t.Errorf("expected ops to be encoded by name, got %s", buf.String())
+	}
+
+	decoded, err := ReadJSON(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, trace) {
+		t.Errorf("round trip mismatch:\n%+v\n%+v", decoded, trace)
+	}
+	if !slices.Equal(decoded.Final(), sorted) {
+		t.Errorf("decoded trace replays to %v, want %v", decoded.Final(), sorted)
+	}
+}
+
+func TestReadJSONRejectsBadTraces(t *testing.T) {
+	testCases := []string{
+		`{"initial":[1,2],"events":[{"op":"shuffle","i":0}]}`,
+		`{"initial":[1,2],"events":[{"op":"swap","i":0,"j":2}]}`,
+		`{"initial":[1,2],"events":[{"op":"write","i":-1,"value":3}]}`,
+		`{"initial":[1,2],"scratch":1,"events":[{"op":"write","i":3,"value":3}]}`,
+		`{"initial":[1,2],"scratch":-1,"events":[]}`,
+		`{"initial":[1,2]`,
+	}
+
+	for i, tc := range testCases {
+		if _, err := ReadJSON(strings.NewReader(tc)); err == nil {
+			t.Errorf("test case %d: expected an error for %s", i+1, tc)
+		}
+	}
+}
+
+func TestOpText(t *testing.T) {
+	for _, op := range []Op{Compare, Swap, Write} {
+		text, err := op.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var back Op
+		if err := back.UnmarshalText(text); err != nil || back != op {
+			t.Errorf("op %v did not round trip: %v, %v", op, back, err)
+		}
+	}
+	if _, err := json.Marshal(Event{Op: Op(9)}); err == nil {
+		t.Error("expected marshalling an unknown op to fail")
+	}
+	if Op(9).String() != "Op(9)" {
+		t.Errorf("unexpected string %q", Op(9).String())
+	}
+}
+
+func TestWriteSVG(t *testing.T) {
+	trace, _ := Record("selection sort", []int{5, 3, 9, 1}, selectionSort)
+	trace.Write(2, 12)
+
+	var buf bytes.Buffer
+	if err := trace.WriteSVG(&buf, SVGOptions{Width: 400, Height: 100}); err != nil {
+		t.Fatal(err)
+	}
+
+	rects, sets := 0, 0
+	dec := xml.NewDecoder(&buf)
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("SVG is not well-formed XML: %v", err)
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			switch start.Name.Local {
+			case "rect":
+				rects++
+			case "set":
+				sets++
+			}
+		}
+	}
+
+	if rects != 4 {
+		t.Errorf("expected 4 bars, got %d", rects)
+	}
+	wantSets := 2*trace.Counts.Comparisons + 6*trace.Counts.Swaps + 3*trace.Counts.Writes
+	if sets != wantSets {
+		t.Errorf("expected %d animation steps, got %d", wantSets, sets)
+	}
+}
+
+func TestWriteFrames(t *testing.T) {
+	trace, _ := Record("selection sort", []int{2, 1}, selectionSort)
+
+	var buf strings.Builder
+	if err := trace.WriteFrames(&buf, FrameOptions{Width: 4}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "selection sort: initial\n" +
+		"  0 │████ 2\n" +
+		"  1 │██ 1\n" +
+		"\n" +
+		"selection sort: step 1/2 compare [1] [0]\n" +
+		"  0 │████ 2 ◀\n" +
+		"  1 │██ 1 ◀\n" +
+		"\n" +
+		"selection sort: step 2/2 swap [0] [1]\n" +
+		"  0 │██ 1 ◀\n" +
+		"  1 │████ 2 ◀\n" +
+		"\n" +
+		"1 comparisons, 1 swaps, 0 writes\n"
+	if buf.String() != want {
+		t.Errorf("unexpected frames:\n%s\nwant:\n%s", buf.String(), want)
+	}
+
+	buf.Reset()
+	trace.WriteFrames(&buf, FrameOptions{Animate: true})
+	if strings.Count(buf.String(), "\x1b[2J") != 3 {
+		t.Error("expected a screen clear before each of the 3 frames")
+	}
+}
+
+func TestRun(t *testing.T) {
+	result := Run()
+	if result == nil {
+		t.Error("Expected non-nil result")
+	}
+
+	resultMap, ok := result.(map[string]any)
+	if !ok {
+		t.Fatal("Expected result to be a map")
+	}
+	if resultMap["json_roundtrip_ok"] != true || resultMap["replay_matches_sort"] != true {
+		t.Errorf("unexpected result %v", resultMap)
+	}
+}
+
+func BenchmarkRecord(b *testing.B) {
+	arr := rand.New(rand.NewSource(2)).Perm(200)
+	for b.Loop() {
+		Record("selection sort", arr, selectionSort)
+	}
+}
+
+func TestTracedSorts(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	n := 32
+	sorted := make([]int, n)
+	reversed := make([]int, n)
+	for i := range n {
+		sorted[i] = i
+		reversed[i] = n - 1 - i
+	}
+	inputs := map[string][]int{"random": rng.Perm(n), "sorted": sorted, "reversed": reversed}
+
+	for pattern, arr := range inputs {
+		for _, traced := range tracedSorts {
+			trace, result := Record(traced.name, arr, traced.sort)
+			if !slices.IsSorted(result) {
+				t.Fatalf("%s on %s: result not sorted %v", traced.name, pattern, result)
+			}
+			if !slices.Equal(trace.Final(), result) {
+				t.Errorf("%s on %s: replaying the trace gives %v, want %v", traced.name, pattern, trace.Final(), result)
+			}
+			if trace.Scratch > n/2 {
+				t.Errorf("%s on %s: expected at most %d scratch cells, got %d", traced.name, pattern, n/2, trace.Scratch)
+			}
+
+			var encoded bytes.Buffer
+			if err := trace.WriteJSON(&encoded); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := ReadJSON(&encoded); err != nil {
+				t.Errorf("%s on %s: trace does not round-trip through JSON: %v", traced.name, pattern, err)
+			}
+		}
+	}
+
+	for _, traced := range tracedSorts {
+		trace, _ := Record(traced.name, sorted, traced.sort)
+		if traced.name == "bubble" || traced.name == "merge" || traced.name == "tim" {
+			if want := (Counts{Comparisons: n - 1}); trace.Counts != want {
+				t.Errorf("%s on sorted input: expected %+v, got %+v", traced.name, want, trace.Counts)
+			}
+		}
+	}
+}
+
+func TestWritesPastTheArrayGrowScratch(t *testing.T) {
+	trace := NewTrace("scratch", []int{2, 1})
+	trace.Copy(3, 0)
+	trace.Copy(0, 1)
+	trace.Copy(1, 3)
+	if trace.Scratch != 2 || trace.ScratchIndex(1) != 3 {
+		t.Errorf("expected writing position 3 to grow scratch to 2 cells, got %d", trace.Scratch)
+	}
+	if got := trace.Final(); !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("expected [1 2], got %v", got)
+	}
+}
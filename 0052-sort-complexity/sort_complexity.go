@@ -0,0 +1,316 @@
+package sort_complexity
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+
+	"github.com/celj/dsa/0012-quick-sort"
+	"github.com/celj/dsa/0013-merge-sort"
+	"github.com/celj/dsa/0048-sort"
+)
+
+var DefaultSizes = []int{256, 512, 1024, 2048}
+
+type Sorter struct {
+	Name     string
+	Sort     func(s []int, compare func(a, b int) int)
+	Expected Model
+}
+
+type Input struct {
+	Name     string
+	Generate func(n int, sorter Sorter) []int
+}
+
+func Sorted(n int) []int {
+	s := make([]int, n)
+	for i := range s {
+		s[i] = i
+	}
+	return s
+}
+
+func Reversed(n int) []int {
+	s := make([]int, n)
+	for i := range s {
+		s[i] = n - 1 - i
+	}
+	return s
+}
+
+func OrganPipe(n int) []int {
+	s := make([]int, n)
+	for i := range s {
+		s[i] = min(i, n-1-i)
+	}
+	return s
+}
+
+func Sawtooth(n, teeth int) []int {
+	period := max(1, n/max(teeth, 1))
+	s := make([]int, n)
+	for i := range s {
+		s[i] = i % period
+	}
+	return s
+}
+
+func ManyDuplicates(n, distinct int, rng *rand.Rand) []int {
+	s := make([]int, n)
+	for i := range s {
+		s[i] = rng.Intn(max(distinct, 1))
+	}
+	return s
+}
+
+func Random(n int, rng *rand.Rand) []int {
+	return rng.Perm(n)
+}
+
+func KillerAdversary(n int, sorter Sorter) []int {
+	gas := n
+	val := make([]int, n)
+	for i := range val {
+		val[i] = gas
+	}
+	solid, candidate := 0, 0
+
+	freeze := func(x int) {
+		val[x] = solid
+		solid++
+	}
+
+	indices := Sorted(n)
+	sorter.Sort(indices, func(x, y int) int {
+		if val[x] == gas && val[y] == gas {
+			if x == candidate {
+				freeze(x)
+			} else {
+				freeze(y)
+			}
+		}
+		if val[x] == gas {
+			candidate = x
+		} else if val[y] == gas {
+			candidate = y
+		}
+		return val[x] - val[y]
+	})
+
+	return val
+}
+
+func StandardInputs(seed int64) []Input {
+	return []Input{
+		{"random", func(n int, _ Sorter) []int { return Random(n, rand.New(rand.NewSource(seed))) }},
+		{"sorted", func(n int, _ Sorter) []int { return Sorted(n) }},
+		{"reversed", func(n int, _ Sorter) []int { return Reversed(n) }},
+		{"organ_pipe", func(n int, _ Sorter) []int { return OrganPipe(n) }},
+		{"sawtooth", func(n int, _ Sorter) []int { return Sawtooth(n, 8) }},
+		{"many_duplicates", func(n int, _ Sorter) []int { return ManyDuplicates(n, 4, rand.New(rand.NewSource(seed))) }},
+		{"killer_adversary", KillerAdversary},
+	}
+}
+
+func StandardSorters() []Sorter {
+	return []Sorter{
+		{"quick_sort.QuickSortFunc", quick_sort.QuickSortFunc[int], Linearithmic},
+		{"quick_sort.QuickSortMedianOfThreeFunc", quick_sort.QuickSortMedianOfThreeFunc[int], Linearithmic},
+		{"merge_sort.MergeSortFunc", merge_sort.MergeSortFunc[int], Linearithmic},
+		{"sort.InsertionSort", sort.InsertionSort[int], Quadratic},
+		{"sort.HeapSort", sort.HeapSort[int], Linearithmic},
+		{"sort.IntroSort", sort.IntroSort[int], Linearithmic},
+		{"sort.PDQSort", sort.PDQSort[int], Linearithmic},
+		{"sort.TimSort", sort.TimSort[int], Linearithmic},
+	}
+}
+
+func CountComparisons(sorter Sorter, input []int) (int, bool) {
+	s := slices.Clone(input)
+	count := 0
+	sorter.Sort(s, func(a, b int) int {
+		count++
+		return a - b
+	})
+	return count, slices.IsSorted(s) && sameElements(s, input)
+}
+
+func sameElements(sorted, input []int) bool {
+	want := slices.Clone(input)
+	slices.Sort(want)
+	return slices.Equal(sorted, want)
+}
+
+type Model int
+
+const (
+	Linearithmic Model = iota
+	Quadratic
+)
+
+func (m Model) String() string {
+	switch m {
+	case Linearithmic:
+		return "n log n"
+	case Quadratic:
+		return "n²"
+	default:
+		return fmt.Sprintf("Model(%d)", int(m))
+	}
+}
+
+func (m Model) eval(n int) float64 {
+	x := float64(n)
+	if m == Quadratic {
+		return x * x
+	}
+	return x * math.Log2(max(x, 2))
+}
+
+type Measurement struct {
+	N           int
+	Comparisons int
+}
+
+type Fit struct {
+	Model       Model
+	Coefficient float64
+	Error       float64
+}
+
+func FitModel(model Model, ms []Measurement) Fit {
+	var num, den float64
+	for _, m := range ms {
+		f := model.eval(m.N) / float64(max(m.Comparisons, 1))
+		num += f
+		den += f * f
+	}
+
+	fit := Fit{Model: model}
+	if den == 0 {
+		return fit
+	}
+	fit.Coefficient = num / den
+
+	for _, m := range ms {
+		r := 1 - fit.Coefficient*model.eval(m.N)/float64(max(m.Comparisons, 1))
+		fit.Error += r * r
+	}
+	fit.Error = math.Sqrt(fit.Error / float64(len(ms)))
+	return fit
+}
+
+func Exponent(ms []Measurement) float64 {
+	var sx, sy, sxx, sxy float64
+	for _, m := range ms {
+		x := math.Log(float64(m.N))
+		y := math.Log(float64(max(m.Comparisons, 1)))
+		sx += x
+		sy += y
+		sxx += x * x
+		sxy += x * y
+	}
+
+	k := float64(len(ms))
+	den := k*sxx - sx*sx
+	if den == 0 {
+		return 0
+	}
+	return (k*sxy - sx*sy) / den
+}
+
+type Report struct {
+	Sorter       string
+	Input        string
+	Measurements []Measurement
+	Fits         []Fit
+	Best         Model
+	Exponent     float64
+	Expected     Model
+	Correct      bool
+}
+
+func (r Report) Regressed() bool {
+	return !r.Correct || r.Best > r.Expected
+}
+
+func (r Report) String() string {
+	status := "ok"
+	if !r.Correct {
+		status = "WRONG OUTPUT"
+	} else if r.Regressed() {
+		status = fmt.Sprintf("REGRESSION: expected %v", r.Expected)
+	}
+	return fmt.Sprintf("%s on %s: %v (exponent %.2f) %s", r.Sorter, r.Input, r.Best, r.Exponent, status)
+}
+
+func Measure(sorter Sorter, input Input, sizes []int) Report {
+	r := Report{Sorter: sorter.Name, Input: input.Name, Expected: sorter.Expected, Correct: true}
+
+	for _, n := range sizes {
+		count, ok := CountComparisons(sorter, input.Generate(n, sorter))
+		r.Measurements = append(r.Measurements, Measurement{N: n, Comparisons: count})
+		r.Correct = r.Correct && ok
+	}
+
+	r.Fits = []Fit{FitModel(Linearithmic, r.Measurements), FitModel(Quadratic, r.Measurements)}
+	r.Best = slices.MinFunc(r.Fits, func(a, b Fit) int {
+		switch {
+		case a.Error < b.Error:
+			return -1
+		case a.Error > b.Error:
+			return 1
+		}
+		return int(a.Model) - int(b.Model)
+	}).Model
+	r.Exponent = Exponent(r.Measurements)
+	return r
+}
+
+func Verify(sorters []Sorter, inputs []Input, sizes []int) []Report {
+	var reports []Report
+	for _, sorter := range sorters {
+		for _, input := range inputs {
+			reports = append(reports, Measure(sorter, input, sizes))
+		}
+	}
+	return reports
+}
+
+func Regressions(reports []Report) []Report {
+	var regressed []Report
+	for _, r := range reports {
+		if r.Regressed() {
+			regressed = append(regressed, r)
+		}
+	}
+	return regressed
+}
+
+func Run() any {
+	reports := Verify(StandardSorters(), StandardInputs(1), DefaultSizes)
+
+	table := make(map[string]map[string]string)
+	for _, r := range reports {
+		if table[r.Sorter] == nil {
+			table[r.Sorter] = make(map[string]string)
+		}
+		table[r.Sorter][r.Input] = fmt.Sprintf("%v (%.2f)", r.Best, r.Exponent)
+	}
+
+	var regressions []string
+	for _, r := range Regressions(reports) {
+		regressions = append(regressions, r.String())
+	}
+
+	return map[string]any{
+		"sizes":        DefaultSizes,
+		"organ_pipe":   OrganPipe(9),
+		"sawtooth":     Sawtooth(9, 3),
+		"killer_qsort": KillerAdversary(9, StandardSorters()[0]),
+		"growth":       table,
+		"regressions":  regressions,
+	}
+}
@@ -0,0 +1,186 @@
+package sort_complexity
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/celj/dsa/0012-quick-sort"
+	"github.com/celj/dsa/0048-sort"
+)
+
+func TestGenerators(t *testing.T) {
+	testCases := []struct {
+		name string
+		got  []int
+		want []int
+	}{
+		{"sorted", Sorted(5), []int{0, 1, 2, 3, 4}},
+		{"reversed", Reversed(5), []int{4, 3, 2, 1, 0}},
+		{"organ pipe odd", OrganPipe(7), []int{0, 1, 2, 3, 2, 1, 0}},
+		{"organ pipe even", OrganPipe(6), []int{0, 1, 2, 2, 1, 0}},
+		{"sawtooth", Sawtooth(8, 2), []int{0, 1, 2, 3, 0, 1, 2, 3}},
+		{"sawtooth more teeth than elements", Sawtooth(3, 10), []int{0, 0, 0}},
+		{"empty", OrganPipe(0), []int{}},
+	}
+
+	for _, tc := range testCases {
+		if !reflect.DeepEqual(tc.got, tc.want) {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.want, tc.got)
+		}
+	}
+
+	dups := ManyDuplicates(1000, 3, rand.New(rand.NewSource(1)))
+	slices.Sort(dups)
+	if got := slices.Compact(dups); !slices.Equal(got, []int{0, 1, 2}) {
+		t.Errorf("expected values {0, 1, 2}, got %v", got)
+	}
+
+	perm := Random(50, rand.New(rand.NewSource(1)))
+	slices.Sort(perm)
+	if !slices.Equal(perm, Sorted(50)) {
+		t.Error("Random should return a permutation of 0..n-1")
+	}
+}
+
+func TestCountComparisons(t *testing.T) {
+	insertion := Sorter{Name: "insertion", Sort: sort.InsertionSort[int]}
+
+	count, ok := CountComparisons(insertion, Sorted(10))
+	if count != 9 || !ok {
+		t.Errorf("insertion sort on sorted input: expected 9 comparisons, got %d (ok=%v)", count, ok)
+	}
+
+	count, ok = CountComparisons(insertion, Reversed(10))
+	if count != 45 || !ok {
+		t.Errorf("insertion sort on reversed input: expected 45 comparisons, got %d (ok=%v)", count, ok)
+	}
+
+	broken := Sorter{Name: "broken", Sort: func(s []int, _ func(a, b int) int) { clear(s) }}
+	if _, ok := CountComparisons(broken, []int{2, 1}); ok {
+		t.Error("expected a sorter that loses elements to be reported as incorrect")
+	}
+}
+
+func TestKillerAdversary(t *testing.T) {
+	n := 1024
+	median := Sorter{Name: "median", Sort: quick_sort.QuickSortMedianOfThreeFunc[int]}
+	killer := KillerAdversary(n, median)
+
+	if len(killer) != n {
+		t.Fatalf("expected %d values, got %d", n, len(killer))
+	}
+
+	count, ok := CountComparisons(median, killer)
+	if !ok {
+		t.Fatal("median-of-three quick sort failed to sort the killer input")
+	}
+	if count < n*n/8 {
+		t.Errorf("expected the killer input to force Θ(n²) comparisons, got %d", count)
+	}
+
+	random, _ := CountComparisons(median, Random(n, rand.New(rand.NewSource(2))))
+	if count < 10*random {
+		t.Errorf("killer input (%d comparisons) should be far worse than random input (%d)", count, random)
+	}
+
+	pdq := Sorter{Name: "pdq", Sort: sort.PDQSort[int]}
+	count, _ = CountComparisons(pdq, KillerAdversary(n, pdq))
+	if bound := 4 * n * int(math.Log2(float64(n))); count > bound {
+		t.Errorf("pdqsort should stay O(n log n) against the adversary, got %d > %d", count, bound)
+	}
+}
+
+func TestFitModel(t *testing.T) {
+	var quadratic, linearithmic []Measurement
+	for _, n := range []int{100, 200, 400, 800} {
+		quadratic = append(quadratic, Measurement{N: n, Comparisons: n * n / 2})
+		linearithmic = append(linearithmic, Measurement{N: n, Comparisons: int(3 * float64(n) * math.Log2(float64(n)))})
+	}
+
+	fit := FitModel(Quadratic, quadratic)
+	if math.Abs(fit.Coefficient-0.5) > 1e-9 || fit.Error > 1e-9 {
+		t.Errorf("expected an exact n²/2 fit, got %+v", fit)
+	}
+	if FitModel(Linearithmic, quadratic).Error < 0.1 {
+		t.Error("n log n should fit quadratic data badly")
+	}
+
+	fit = FitModel(Linearithmic, linearithmic)
+	if math.Abs(fit.Coefficient-3) > 1e-3 || fit.Error > 1e-3 {
+		t.Errorf("expected a 3·n log n fit, got %+v", fit)
+	}
+
+	if e := Exponent(quadratic); math.Abs(e-2) > 1e-9 {
+		t.Errorf("expected exponent 2, got %v", e)
+	}
+	if e := Exponent(linearithmic); e < 1 || e > 1.2 {
+		t.Errorf("expected exponent just above 1, got %v", e)
+	}
+}
+
+func TestMeasureFlagsQuadraticQuickSort(t *testing.T) {
+	inputs := StandardInputs(1)
+	quick := StandardSorters()[0]
+
+	sortedInput := inputs[slices.IndexFunc(inputs, func(in Input) bool { return in.Name == "sorted" })]
+	r := Measure(quick, sortedInput, DefaultSizes)
+	if r.Best != Quadratic || !r.Regressed() {
+		t.Errorf("expected last-element-pivot quick sort to go quadratic on sorted input, got %v", r)
+	}
+	if !strings.Contains(r.String(), "REGRESSION") {
+		t.Errorf("expected the report to flag a regression, got %q", r.String())
+	}
+
+	r = Measure(quick, inputs[0], DefaultSizes)
+	if r.Best != Linearithmic || r.Regressed() {
+		t.Errorf("expected quick sort to be n log n on random input, got %v", r)
+	}
+}
+
+func TestVerifyStandardSorters(t *testing.T) {
+	reports := Verify(StandardSorters(), StandardInputs(3), DefaultSizes)
+	if len(reports) != len(StandardSorters())*len(StandardInputs(3)) {
+		t.Fatalf("expected a report per sorter and input, got %d", len(reports))
+	}
+
+	for _, r := range reports {
+		if !r.Correct {
+			t.Errorf("%s produced wrong output on %s", r.Sorter, r.Input)
+		}
+		if strings.HasPrefix(r.Sorter, "sort.") && r.Regressed() {
+			t.Errorf("unexpected regression: %v", r)
+		}
+	}
+
+	for _, r := range Regressions(reports) {
+		if !strings.HasPrefix(r.Sorter, "quick_sort.") {
+			t.Errorf("only the quick_sort variants should regress, got %v", r)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	result := Run()
+	if result == nil {
+		t.Error("Expected non-nil result")
+	}
+
+	resultMap, ok := result.(map[string]any)
+	if !ok {
+		t.Fatal("Expected result to be a map")
+	}
+	if len(resultMap["regressions"].([]string)) == 0 {
+		t.Error("expected Run to report the quick sort regressions")
+	}
+}
+
+func BenchmarkKillerAdversary(b *testing.B) {
+	median := Sorter{Name: "median", Sort: quick_sort.QuickSortMedianOfThreeFunc[int]}
+	for b.Loop() {
+		KillerAdversary(1024, median)
+	}
+}
@@ -0,0 +1,248 @@
+package binary_search_on_answer
+
+import (
+	"context"
+	"math"
+	"sync"
+
+	"github.com/celj/dsa/0002-binary-search"
+)
+
+const maxFloatIterations = 4096
+
+func SearchInt(lo, hi int, pred func(int) bool) int {
+	for lo < hi {
+		mid := lo + int(uint(hi-lo)>>1)
+		if pred(mid) {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return lo
+}
+
+func SearchFloat(lo, hi float64, pred func(float64) bool, eps float64) float64 {
+	for range maxFloatIterations {
+		if hi-lo <= eps {
+			break
+		}
+
+		mid := lo + (hi-lo)/2
+		if mid <= lo || mid >= hi {
+			break
+		}
+
+		if pred(mid) {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	return hi
+}
+
+func SearchIntContext(ctx context.Context, lo, hi int, pred func(context.Context, int) (bool, error)) (int, error) {
+	for lo < hi {
+		if err := ctx.Err(); err != nil {
+			return lo, err
+		}
+
+		mid := lo + int(uint(hi-lo)>>1)
+		ok, err := pred(ctx, mid)
+		if err != nil {
+			return lo, err
+		}
+
+		if ok {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return lo, nil
+}
+
+func SearchIntParallel(ctx context.Context, lo, hi, workers int, pred func(context.Context, int) (bool, error)) (int, error) {
+	workers = max(workers, 1)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	for lo < hi {
+		if err := ctx.Err(); err != nil {
+			return lo, err
+		}
+
+		span, parts := uint(hi-lo), uint(workers)+1
+		step, rem := span/parts, span%parts
+		probes := make([]int, 0, workers)
+		for i := 1; i <= workers; i++ {
+			probe := lo + int(step*uint(i)+rem*uint(i)/parts)
+			if probe < hi && (len(probes) == 0 || probe > probes[len(probes)-1]) {
+				probes = append(probes, probe)
+			}
+		}
+		if len(probes) == 0 {
+			probes = append(probes, lo)
+		}
+
+		results := make([]bool, len(probes))
+		errs := make([]error, len(probes))
+		var wg sync.WaitGroup
+		for i, probe := range probes {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				results[i], errs[i] = pred(ctx, probe)
+				if errs[i] != nil {
+					cancel()
+				}
+			}()
+		}
+		wg.Wait()
+
+		for _, err := range errs {
+			if err != nil {
+				return lo, err
+			}
+		}
+
+		first := len(probes)
+		for i, ok := range results {
+			if ok {
+				first = i
+				break
+			}
+		}
+
+		if first > 0 {
+			lo = probes[first-1] + 1
+		}
+		if first < len(probes) {
+			hi = probes[first]
+		}
+	}
+	return lo, nil
+}
+
+type cascadeEntry[T any] struct {
+	value    T
+	sentinel bool
+	own      int
+	next     int
+}
+
+type Cascade[T any] struct {
+	less   func(T, T) bool
+	levels [][]cascadeEntry[T]
+}
+
+func NewCascade[T any](less func(T, T) bool, lists ...[]T) *Cascade[T] {
+	c := &Cascade[T]{less: less, levels: make([][]cascadeEntry[T], len(lists))}
+
+	var below []T
+	for i := len(lists) - 1; i >= 0; i-- {
+		list := lists[i]
+
+		var promoted []T
+		for j := 1; j < len(below); j += 2 {
+			promoted = append(promoted, below[j])
+		}
+
+		merged := make([]T, 0, len(list)+len(promoted))
+		a, b := 0, 0
+		for a < len(list) || b < len(promoted) {
+			if b == len(promoted) || (a < len(list) && !less(promoted[b], list[a])) {
+				merged = append(merged, list[a])
+				a++
+			} else {
+				merged = append(merged, promoted[b])
+				b++
+			}
+		}
+
+		level := make([]cascadeEntry[T], len(merged)+1)
+		for j, value := range merged {
+			level[j] = cascadeEntry[T]{
+				value: value,
+				own:   binary_search.LowerBound(list, value, less),
+				next:  binary_search.LowerBound(below, value, less),
+			}
+		}
+		level[len(merged)] = cascadeEntry[T]{sentinel: true, own: len(list), next: len(below)}
+
+		c.levels[i] = level
+		below = merged
+	}
+
+	return c
+}
+
+func (c *Cascade[T]) entryLess(e cascadeEntry[T], target T) bool {
+	return !e.sentinel && c.less(e.value, target)
+}
+
+func (c *Cascade[T]) Search(target T) []int {
+	result := make([]int, len(c.levels))
+	if len(c.levels) == 0 {
+		return result
+	}
+
+	first := c.levels[0]
+	p := SearchInt(0, len(first)-1, func(i int) bool { return !c.entryLess(first[i], target) })
+
+	for i, level := range c.levels {
+		for p > 0 && !c.entryLess(level[p-1], target) {
+			p--
+		}
+		result[i] = level[p].own
+		p = level[p].next
+	}
+	return result
+}
+
+func (c *Cascade[T]) Size() int {
+	total := 0
+	for _, level := range c.levels {
+		total += len(level) - 1
+	}
+	return total
+}
+
+func Run() any {
+	latency := func(servers int) float64 {
+		return 2000 / float64(servers)
+	}
+	const sla = 45.0
+	minServers := SearchInt(1, 1000, func(servers int) bool { return latency(servers) <= sla })
+
+	budget := 1_000_000
+	cost := func(x int) int { return x * x * x }
+	maxAffordable := SearchInt(0, 1000, func(x int) bool { return cost(x) > budget }) - 1
+
+	sqrt2 := SearchFloat(0, 2, func(x float64) bool { return x*x >= 2 }, 0)
+
+	ctx := context.Background()
+	parallel, err := SearchIntParallel(ctx, 1, 1000, 4, func(_ context.Context, servers int) (bool, error) {
+		return latency(servers) <= sla, nil
+	})
+
+	lists := [][]int{
+		{1, 4, 9, 16, 25, 36},
+		{2, 3, 5, 7, 11, 13, 17, 19, 23},
+		{10, 20, 30, 40},
+	}
+	c := NewCascade(func(a, b int) bool { return a < b }, lists...)
+
+	return map[string]any{
+		"min_servers_for_sla":      minServers,
+		"max_affordable_cube_root": maxAffordable,
+		"sqrt_2":                   sqrt2,
+		"sqrt_2_error":             math.Abs(sqrt2 - math.Sqrt2),
+		"parallel_min_servers":     parallel,
+		"parallel_error":           err,
+		"cascade_lists":            lists,
+		"cascade_lower_bounds_12":  c.Search(12),
+		"cascade_entries":          c.Size(),
+	}
+}
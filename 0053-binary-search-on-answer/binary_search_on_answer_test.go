@@ -0,0 +1,299 @@
+package binary_search_on_answer
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/bits"
+	"math/rand"
+	"slices"
+	"sync/atomic"
+	"testing"
+
+	"github.com/celj/dsa/0002-binary-search"
+)
+
+func TestSearchInt(t *testing.T) {
+	testCases := []struct {
+		name      string
+		lo, hi    int
+		threshold int
+		want      int
+	}{
+		{"middle", 0, 100, 37, 37},
+		{"first", 0, 100, 0, 0},
+		{"none", 0, 100, 500, 100},
+		{"below range", 10, 20, -5, 10},
+		{"empty range", 5, 5, 0, 5},
+		{"negative", -50, 50, -13, -13},
+		{"full int range", math.MinInt, math.MaxInt, 42, 42},
+	}
+
+	for _, tc := range testCases {
+		got := SearchInt(tc.lo, tc.hi, func(x int) bool { return x >= tc.threshold })
+		if got != tc.want {
+			t.Errorf("%s: expected %d, got %d", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestSearchIntLastFalse(t *testing.T) {
+	budget := 1_000_000
+	got := SearchInt(0, 1000, func(x int) bool { return x*x*x > budget }) - 1
+	if got != 100 {
+		t.Errorf("expected the largest x with x³ <= %d to be 100, got %d", budget, got)
+	}
+}
+
+func TestSearchFloat(t *testing.T) {
+	got := SearchFloat(0, 2, func(x float64) bool { return x*x >= 2 }, 0)
+	if got != math.Sqrt2 && math.Nextafter(got, 0) != math.Sqrt2 {
+		t.Errorf("expected √2 to full precision, got %v", got)
+	}
+
+	got = SearchFloat(0, 100, func(x float64) bool { return math.Exp(x) >= 1000 }, 1e-6)
+	if math.Abs(got-math.Log(1000)) > 1e-6 {
+		t.Errorf("expected ln 1000 within 1e-6, got %v", got)
+	}
+
+	got = SearchFloat(-1e300, 1e300, func(x float64) bool { return x >= 1 }, 0)
+	if got != 1 {
+		t.Errorf("expected a huge range to converge to 1, got %v", got)
+	}
+
+	got = SearchFloat(0, 1, func(float64) bool { return false }, 1e-9)
+	if got != 1 {
+		t.Errorf("expected hi when the predicate is never true, got %v", got)
+	}
+}
+
+func TestSearchIntContext(t *testing.T) {
+	ctx := context.Background()
+	calls := 0
+	got, err := SearchIntContext(ctx, 0, 1<<20, func(_ context.Context, x int) (bool, error) {
+		calls++
+		return x >= 12345, nil
+	})
+	if err != nil || got != 12345 {
+		t.Errorf("expected 12345, got %d (%v)", got, err)
+	}
+	if calls > 21 {
+		t.Errorf("expected at most 21 predicate calls, got %d", calls)
+	}
+
+	boom := errors.New("boom")
+	_, err = SearchIntContext(ctx, 0, 100, func(_ context.Context, x int) (bool, error) {
+		return false, boom
+	})
+	if !errors.Is(err, boom) {
+		t.Errorf("expected predicate error, got %v", err)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	calls = 0
+	_, err = SearchIntContext(cancelled, 0, 1<<20, func(_ context.Context, x int) (bool, error) {
+		calls++
+		if calls == 3 {
+			cancel()
+		}
+		return x >= 7, nil
+	})
+	if !errors.Is(err, context.Canceled) || calls != 3 {
+		t.Errorf("expected cancellation after 3 calls, got %v after %d", err, calls)
+	}
+}
+
+func TestSearchIntParallel(t *testing.T) {
+	ctx := context.Background()
+	rng := rand.New(rand.NewSource(1))
+
+	for _, workers := range []int{0, 1, 2, 3, 7, 16} {
+		for range 50 {
+			lo := rng.Intn(1000) - 500
+			hi := lo + rng.Intn(2000)
+			threshold := lo + rng.Intn(hi-lo+3) - 1
+
+			got, err := SearchIntParallel(ctx, lo, hi, workers, func(_ context.Context, x int) (bool, error) {
+				return x >= threshold, nil
+			})
+			want := SearchInt(lo, hi, func(x int) bool { return x >= threshold })
+			if err != nil || got != want {
+				t.Fatalf("workers=%d [%d, %d) threshold %d: expected %d, got %d (%v)", workers, lo, hi, threshold, want, got, err)
+			}
+		}
+	}
+}
+
+func TestSearchIntParallelFullRange(t *testing.T) {
+	for _, workers := range []int{1, 3, 7, 15} {
+		rounds := 64/bits.Len(uint(workers)) + 1
+		for _, threshold := range []int{math.MinInt, math.MinInt + 1, -1, 0, 42, math.MaxInt - 1, math.MaxInt} {
+			var calls atomic.Int64
+			var outside atomic.Bool
+			got, err := SearchIntParallel(context.Background(), math.MinInt, math.MaxInt, workers, func(_ context.Context, x int) (bool, error) {
+				calls.Add(1)
+				if x == math.MaxInt {
+					outside.Store(true)
+				}
+				return x >= threshold, nil
+			})
+			if err != nil || got != threshold {
+				t.Errorf("workers=%d threshold %d: expected %d, got %d (%v)", workers, threshold, threshold, got, err)
+			}
+			if outside.Load() {
+				t.Errorf("workers=%d threshold %d: probed hi, outside [lo, hi)", workers, threshold)
+			}
+			if limit := int64(rounds * workers); calls.Load() > limit {
+				t.Errorf("workers=%d threshold %d: expected evenly spaced probes in at most %d calls, got %d", workers, threshold, limit, calls.Load())
+			}
+		}
+	}
+}
+
+func TestSearchIntParallelRounds(t *testing.T) {
+	var calls atomic.Int64
+	_, err := SearchIntParallel(context.Background(), 0, 1<<20, 7, func(_ context.Context, x int) (bool, error) {
+		calls.Add(1)
+		return x >= 999_999, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rounds := calls.Load() / 7; rounds > 8 {
+		t.Errorf("expected about log₈(2²⁰) ≈ 7 rounds, got %d", rounds)
+	}
+}
+
+func TestSearchIntParallelError(t *testing.T) {
+	boom := errors.New("boom")
+	_, err := SearchIntParallel(context.Background(), 0, 1000, 4, func(ctx context.Context, x int) (bool, error) {
+		if x > 500 {
+			return false, boom
+		}
+		return false, nil
+	})
+	if !errors.Is(err, boom) {
+		t.Errorf("expected predicate error, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := SearchIntParallel(ctx, 0, 1000, 4, func(context.Context, int) (bool, error) { return true, nil }); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func lessInt(a, b int) bool { return a < b }
+
+func randomSortedLists(rng *rand.Rand, k, maxLen, maxValue int) [][]int {
+	lists := make([][]int, k)
+	for i := range lists {
+		list := make([]int, rng.Intn(maxLen+1))
+		for j := range list {
+			list[j] = rng.Intn(maxValue)
+		}
+		slices.Sort(list)
+		lists[i] = list
+	}
+	return lists
+}
+
+func TestCascade(t *testing.T) {
+	lists := [][]int{
+		{1, 4, 9, 16, 25, 36},
+		{2, 3, 5, 7, 11, 13, 17, 19, 23},
+		{10, 20, 30, 40},
+	}
+	c := NewCascade(lessInt, lists...)
+
+	testCases := []struct {
+		target int
+		want   []int
+	}{
+		{12, []int{3, 5, 1}},
+		{0, []int{0, 0, 0}},
+		{1, []int{0, 0, 0}},
+		{100, []int{6, 9, 4}},
+		{20, []int{4, 8, 1}},
+	}
+
+	for _, tc := range testCases {
+		if got := c.Search(tc.target); !slices.Equal(got, tc.want) {
+			t.Errorf("Search(%d): expected %v, got %v", tc.target, tc.want, got)
+		}
+	}
+
+	if got := NewCascade(lessInt).Search(5); len(got) != 0 {
+		t.Errorf("expected no results for no lists, got %v", got)
+	}
+}
+
+func TestCascadeMatchesLowerBound(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+
+	for trial := range 200 {
+		lists := randomSortedLists(rng, 1+rng.Intn(8), 30, 1+rng.Intn(60))
+		c := NewCascade(lessInt, lists...)
+
+		for target := -2; target < 65; target++ {
+			got := c.Search(target)
+			for i, list := range lists {
+				if want := binary_search.LowerBound(list, target, lessInt); got[i] != want {
+					t.Fatalf("trial %d, target %d, list %d %v: expected %d, got %d", trial, target, i, list, want, got[i])
+				}
+			}
+		}
+	}
+}
+
+func TestCascadeSize(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	lists := randomSortedLists(rng, 10, 1000, 1_000_000)
+
+	total := 0
+	for _, list := range lists {
+		total += len(list)
+	}
+
+	if size := NewCascade(lessInt, lists...).Size(); size > 2*total {
+		t.Errorf("expected at most %d cascade entries, got %d", 2*total, size)
+	}
+}
+
+func TestRun(t *testing.T) {
+	result := Run()
+	if result == nil {
+		t.Error("Expected non-nil result")
+	}
+
+	resultMap, ok := result.(map[string]any)
+	if !ok {
+		t.Fatal("Expected result to be a map")
+	}
+	if resultMap["min_servers_for_sla"] != 45 || resultMap["parallel_min_servers"] != 45 {
+		t.Errorf("expected 45 servers, got %v", resultMap)
+	}
+}
+
+func BenchmarkCascadeSearch(b *testing.B) {
+	rng := rand.New(rand.NewSource(4))
+	lists := randomSortedLists(rng, 16, 10_000, 1_000_000)
+	c := NewCascade(lessInt, lists...)
+
+	for b.Loop() {
+		c.Search(rng.Intn(1_000_000))
+	}
+}
+
+func BenchmarkIndependentLowerBounds(b *testing.B) {
+	rng := rand.New(rand.NewSource(4))
+	lists := randomSortedLists(rng, 16, 10_000, 1_000_000)
+	result := make([]int, len(lists))
+
+	for b.Loop() {
+		target := rng.Intn(1_000_000)
+		for i, list := range lists {
+			result[i] = binary_search.LowerBound(list, target, lessInt)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package static_search
+
+import (
+	"cmp"
+	"math/bits"
+	"runtime"
+)
+
+const STreeBlock = 16
+
+type EytzingerIndex[T cmp.Ordered] struct {
+	data []T
+}
+
+func NewEytzingerIndex[T cmp.Ordered](sorted []T) *EytzingerIndex[T] {
+	n := len(sorted)
+	e := &EytzingerIndex[T]{data: make([]T, n+1)}
+
+	i := 0
+	var build func(k int)
+	build = func(k int) {
+		if k > n {
+			return
+		}
+		build(2 * k)
+		e.data[k] = sorted[i]
+		i++
+		build(2*k + 1)
+	}
+	build(1)
+
+	return e
+}
+
+func (e *EytzingerIndex[T]) Len() int {
+	return len(e.data) - 1
+}
+
+func (e *EytzingerIndex[T]) descend(x T) int {
+	n := len(e.data) - 1
+	k := 1
+	var ahead T
+	for k <= n {
+		if p := k * 16; p <= n {
+			ahead = e.data[p]
+		}
+		b := 0
+		if e.data[k] < x {
+			b = 1
+		}
+		k = 2*k + b
+	}
+	runtime.KeepAlive(ahead)
+	return k
+}
+
+func (e *EytzingerIndex[T]) rank(leaf int) int {
+	level := 1 << (bits.Len(uint(leaf)) - 1)
+	return leaf - level + max(0, len(e.data)-level)
+}
+
+func (e *EytzingerIndex[T]) LowerBound(x T) int {
+	return e.rank(e.descend(x))
+}
+
+func (e *EytzingerIndex[T]) Search(x T) int {
+	leaf := e.descend(x)
+	k := leaf >> (bits.TrailingZeros(^uint(leaf)) + 1)
+	if k == 0 || e.data[k] != x {
+		return -1
+	}
+	return e.rank(leaf)
+}
+
+func (e *EytzingerIndex[T]) Layout() []T {
+	return e.data[1:]
+}
+
+type STree[T cmp.Ordered] struct {
+	n      int
+	blocks int
+	keys   []T
+	rank   []int
+}
+
+func NewSTree[T cmp.Ordered](sorted []T) *STree[T] {
+	n := len(sorted)
+	blocks := (n + STreeBlock - 1) / STreeBlock
+	s := &STree[T]{
+		n:      n,
+		blocks: blocks,
+		keys:   make([]T, blocks*STreeBlock),
+		rank:   make([]int, blocks*STreeBlock),
+	}
+
+	i := 0
+	var build func(k int)
+	build = func(k int) {
+		if k >= blocks {
+			return
+		}
+		for j := range STreeBlock {
+			build(streeChild(k, j))
+			slot := k*STreeBlock + j
+			if i < n {
+				s.keys[slot] = sorted[i]
+				s.rank[slot] = i
+				i++
+			} else {
+				s.keys[slot] = sorted[n-1]
+				s.rank[slot] = n
+			}
+		}
+		build(streeChild(k, STreeBlock))
+	}
+	build(0)
+
+	return s
+}
+
+func streeChild(k, i int) int {
+	return k*(STreeBlock+1) + i + 1
+}
+
+func (s *STree[T]) Len() int {
+	return s.n
+}
+
+func (s *STree[T]) lowerBoundSlot(x T) int {
+	slot := -1
+	for k := 0; k < s.blocks; {
+		node := s.keys[k*STreeBlock : (k+1)*STreeBlock]
+		i := 0
+		for _, key := range node {
+			if key < x {
+				i++
+			}
+		}
+		if i < STreeBlock {
+			slot = k*STreeBlock + i
+		}
+		k = streeChild(k, i)
+	}
+	return slot
+}
+
+func (s *STree[T]) LowerBound(x T) int {
+	slot := s.lowerBoundSlot(x)
+	if slot < 0 {
+		return s.n
+	}
+	return s.rank[slot]
+}
+
+func (s *STree[T]) Search(x T) int {
+	slot := s.lowerBoundSlot(x)
+	if slot < 0 || s.rank[slot] == s.n || s.keys[slot] != x {
+		return -1
+	}
+	return s.rank[slot]
+}
+
+func Run() any {
+	sorted := []int{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37, 41, 43, 47}
+	e := NewEytzingerIndex(sorted)
+
+	primes := make([]int, 0, 100)
+	for x := 2; len(primes) < 100; x++ {
+		prime := true
+		for _, p := range primes {
+			if p*p > x {
+				break
+			}
+			if x%p == 0 {
+				prime = false
+				break
+			}
+		}
+		if prime {
+			primes = append(primes, x)
+		}
+	}
+	s := NewSTree(primes)
+
+	return map[string]any{
+		"sorted":                   sorted,
+		"eytzinger_layout":         e.Layout(),
+		"eytzinger_search_19":      e.Search(19),
+		"eytzinger_lower_bound_20": e.LowerBound(20),
+		"stree_blocks":             s.blocks,
+		"stree_search_541":         s.Search(541),
+		"stree_lower_bound_100":    s.LowerBound(100),
+	}
+}
@@ -0,0 +1,147 @@
+package static_search
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/celj/dsa/0002-binary-search"
+)
+
+func lessInt(a, b int) bool { return a < b }
+
+func TestEytzingerLayout(t *testing.T) {
+	e := NewEytzingerIndex([]int{1, 2, 3, 4, 5, 6, 7})
+	if want := []int{4, 2, 6, 1, 3, 5, 7}; !slices.Equal(e.Layout(), want) {
+		t.Errorf("expected BFS layout %v, got %v", want, e.Layout())
+	}
+	if e.Len() != 7 {
+		t.Errorf("expected length 7, got %d", e.Len())
+	}
+}
+
+func TestLowerBoundMatchesBinarySearch(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for n := range 300 {
+		sorted := make([]int, n)
+		for i := range sorted {
+			sorted[i] = rng.Intn(n/2 + 1)
+		}
+		slices.Sort(sorted)
+
+		e := NewEytzingerIndex(sorted)
+		s := NewSTree(sorted)
+
+		for x := -1; x <= n/2+2; x++ {
+			want := binary_search.LowerBound(sorted, x, lessInt)
+			if got := e.LowerBound(x); got != want {
+				t.Fatalf("n=%d x=%d: Eytzinger expected %d, got %d", n, x, want, got)
+			}
+			if got := s.LowerBound(x); got != want {
+				t.Fatalf("n=%d x=%d: S-tree expected %d, got %d", n, x, want, got)
+			}
+
+			if want == n || sorted[want] != x {
+				want = -1
+			}
+			if got := e.Search(x); got != want {
+				t.Fatalf("n=%d x=%d: Eytzinger Search expected %d, got %d", n, x, want, got)
+			}
+			if got := s.Search(x); got != want {
+				t.Fatalf("n=%d x=%d: S-tree Search expected %d, got %d", n, x, want, got)
+			}
+		}
+	}
+}
+
+func TestStrings(t *testing.T) {
+	words := []string{"apple", "banana", "cherry", "date", "elderberry", "fig", "grape"}
+	e := NewEytzingerIndex(words)
+	s := NewSTree(words)
+
+	for i, w := range words {
+		if e.Search(w) != i || s.Search(w) != i {
+			t.Errorf("expected %q at %d, got %d and %d", w, i, e.Search(w), s.Search(w))
+		}
+	}
+	if e.Search("coconut") != -1 || s.Search("coconut") != -1 {
+		t.Error("expected missing word to return -1")
+	}
+	if e.LowerBound("coconut") != 3 || s.LowerBound("coconut") != 3 {
+		t.Error("expected coconut to insert before date")
+	}
+}
+
+func TestEmpty(t *testing.T) {
+	e := NewEytzingerIndex([]int{})
+	s := NewSTree([]int{})
+	if e.LowerBound(5) != 0 || e.Search(5) != -1 || s.LowerBound(5) != 0 || s.Search(5) != -1 {
+		t.Error("expected empty indexes to find nothing")
+	}
+}
+
+func TestRun(t *testing.T) {
+	result := Run()
+	if result == nil {
+		t.Error("Expected non-nil result")
+	}
+
+	resultMap, ok := result.(map[string]any)
+	if !ok {
+		t.Fatal("Expected result to be a map")
+	}
+	if resultMap["eytzinger_search_19"] != 7 || resultMap["stree_search_541"] != 99 {
+		t.Errorf("unexpected result %v", resultMap)
+	}
+}
+
+var benchmarkSizes = []int{1e3, 1e4, 1e5, 1e6, 1e7, 1e8}
+
+func benchmarkLookups(b *testing.B, build func(sorted []int) func(int) int) {
+	for _, n := range benchmarkSizes {
+		b.Run(fmt.Sprintf("n=%.0e", float64(n)), func(b *testing.B) {
+			if n > 1e7 && testing.Short() {
+				b.Skip("skipping 1e8 elements in short mode")
+			}
+
+			sorted := make([]int, n)
+			for i := range sorted {
+				sorted[i] = 2 * i
+			}
+			search := build(sorted)
+
+			rng := rand.New(rand.NewSource(1))
+			queries := make([]int, 1<<16)
+			for i := range queries {
+				queries[i] = rng.Intn(2 * n)
+			}
+
+			i := 0
+			b.ResetTimer()
+			for b.Loop() {
+				search(queries[i&(len(queries)-1)])
+				i++
+			}
+		})
+	}
+}
+
+func BenchmarkSearchInt(b *testing.B) {
+	benchmarkLookups(b, func(sorted []int) func(int) int {
+		return func(x int) int { return binary_search.SearchInt(sorted, x) }
+	})
+}
+
+func BenchmarkEytzinger(b *testing.B) {
+	benchmarkLookups(b, func(sorted []int) func(int) int {
+		return NewEytzingerIndex(sorted).Search
+	})
+}
+
+func BenchmarkSTree(b *testing.B) {
+	benchmarkLookups(b, func(sorted []int) func(int) int {
+		return NewSTree(sorted).Search
+	})
+}
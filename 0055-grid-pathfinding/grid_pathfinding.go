@@ -0,0 +1,713 @@
+package grid_pathfinding
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/celj/dsa/0011-maze-with-recursion"
+	"github.com/celj/dsa/0018-heap"
+	"github.com/celj/dsa/0045-deque"
+)
+
+type Point = maze_with_recursion.Point
+
+const Wall = -1
+
+var (
+	ErrNoPath           = errors.New("no path found from start to end")
+	ErrOutOfBounds      = errors.New("position out of bounds")
+	ErrNoEndpoints      = errors.New("at least one start and one end are required")
+	ErrNotZeroOne       = errors.New("0-1 BFS needs cell weights of 0 or 1 and a diagonal cost of 1")
+	ErrJPSUnsupported   = errors.New("jump point search needs uniform weights and DiagonalIfNoObstacles")
+	ErrInvalidWeights   = errors.New("weights must be a non-empty rectangle")
+	ErrNegativeDiagonal = errors.New("diagonal cost must not be negative")
+)
+
+type Diagonal int
+
+const (
+	DiagonalNever Diagonal = iota
+	DiagonalAlways
+	DiagonalIfOneOpen
+	DiagonalIfNoObstacles
+)
+
+type Options struct {
+	Diagonal     Diagonal
+	DiagonalCost float64
+}
+
+type Grid struct {
+	rows, cols   int
+	weights      []int
+	diagonal     Diagonal
+	diagonalCost float64
+}
+
+type Result struct {
+	Path     []Point
+	Cost     float64
+	Expanded int
+}
+
+var (
+	orthogonal = []Point{{Row: -1, Col: 0}, {Row: 1, Col: 0}, {Row: 0, Col: -1}, {Row: 0, Col: 1}}
+	diagonals  = []Point{{Row: -1, Col: -1}, {Row: -1, Col: 1}, {Row: 1, Col: -1}, {Row: 1, Col: 1}}
+)
+
+func NewGrid(weights [][]int, opts Options) (*Grid, error) {
+	if len(weights) == 0 || len(weights[0]) == 0 {
+		return nil, ErrInvalidWeights
+	}
+	if opts.DiagonalCost < 0 {
+		return nil, ErrNegativeDiagonal
+	}
+	if opts.DiagonalCost == 0 {
+		opts.DiagonalCost = math.Sqrt2
+	}
+
+	g := &Grid{
+		rows:         len(weights),
+		cols:         len(weights[0]),
+		diagonal:     opts.Diagonal,
+		diagonalCost: opts.DiagonalCost,
+	}
+	g.weights = make([]int, 0, g.rows*g.cols)
+	for _, row := range weights {
+		if len(row) != g.cols {
+			return nil, ErrInvalidWeights
+		}
+		for _, w := range row {
+			g.weights = append(g.weights, max(w, Wall))
+		}
+	}
+	return g, nil
+}
+
+func FromMaze(maze []string, wall string, opts Options) (*Grid, error) {
+	if err := maze_with_recursion.ValidateMaze(maze); err != nil {
+		return nil, err
+	}
+
+	weights := make([][]int, len(maze))
+	for r, line := range maze {
+		weights[r] = make([]int, len(line))
+		for c := range len(line) {
+			switch ch := line[c]; {
+			case string(ch) == wall:
+				weights[r][c] = Wall
+			case ch >= '0' && ch <= '9':
+				weights[r][c] = int(ch - '0')
+			default:
+				weights[r][c] = 1
+			}
+		}
+	}
+	return NewGrid(weights, opts)
+}
+
+func (g *Grid) Rows() int {
+	return g.rows
+}
+
+func (g *Grid) Cols() int {
+	return g.cols
+}
+
+func (g *Grid) Weight(p Point) int {
+	if !g.inBounds(p.Row, p.Col) {
+		return Wall
+	}
+	return g.weights[p.Row*g.cols+p.Col]
+}
+
+func (g *Grid) inBounds(r, c int) bool {
+	return r >= 0 && r < g.rows && c >= 0 && c < g.cols
+}
+
+func (g *Grid) open(r, c int) bool {
+	return g.inBounds(r, c) && g.weights[r*g.cols+c] != Wall
+}
+
+func (g *Grid) point(i int) Point {
+	return Point{Row: i / g.cols, Col: i % g.cols}
+}
+
+func (g *Grid) diagonalAllowed(r, c int, d Point) bool {
+	if !g.open(r+d.Row, c+d.Col) {
+		return false
+	}
+
+	a, b := g.open(r+d.Row, c), g.open(r, c+d.Col)
+	switch g.diagonal {
+	case DiagonalAlways:
+		return true
+	case DiagonalIfOneOpen:
+		return a || b
+	case DiagonalIfNoObstacles:
+		return a && b
+	default:
+		return false
+	}
+}
+
+func (g *Grid) neighbors(i int, visit func(j int, diagonal bool)) {
+	r, c := i/g.cols, i%g.cols
+	for _, d := range orthogonal {
+		if g.open(r+d.Row, c+d.Col) {
+			visit(i+d.Row*g.cols+d.Col, false)
+		}
+	}
+	if g.diagonal == DiagonalNever {
+		return
+	}
+	for _, d := range diagonals {
+		if g.diagonalAllowed(r, c, d) {
+			visit(i+d.Row*g.cols+d.Col, true)
+		}
+	}
+}
+
+func (g *Grid) stepCost(j int, diagonal bool) float64 {
+	cost := float64(g.weights[j])
+	if diagonal {
+		cost *= g.diagonalCost
+	}
+	return cost
+}
+
+type search struct {
+	g      *Grid
+	goals  []bool
+	dist   []float64
+	parent []int
+	ends   []Point
+}
+
+func (g *Grid) newSearch(starts, goals []Point) (*search, []int, error) {
+	if len(starts) == 0 || len(goals) == 0 {
+		return nil, nil, ErrNoEndpoints
+	}
+
+	s := &search{
+		g:      g,
+		goals:  make([]bool, g.rows*g.cols),
+		dist:   make([]float64, g.rows*g.cols),
+		parent: make([]int, g.rows*g.cols),
+		ends:   goals,
+	}
+	for i := range s.dist {
+		s.dist[i] = math.Inf(1)
+		s.parent[i] = -1
+	}
+
+	for _, p := range goals {
+		if !g.inBounds(p.Row, p.Col) {
+			return nil, nil, ErrOutOfBounds
+		}
+		s.goals[p.Row*g.cols+p.Col] = true
+	}
+
+	var sources []int
+	for _, p := range starts {
+		if !g.inBounds(p.Row, p.Col) {
+			return nil, nil, ErrOutOfBounds
+		}
+		i := p.Row*g.cols + p.Col
+		if g.weights[i] != Wall && s.dist[i] != 0 {
+			s.dist[i] = 0
+			sources = append(sources, i)
+		}
+	}
+	return s, sources, nil
+}
+
+func (s *search) result(goal, expanded int) (Result, error) {
+	if goal < 0 {
+		return Result{Expanded: expanded}, ErrNoPath
+	}
+
+	var path []Point
+	for i := goal; i >= 0; i = s.parent[i] {
+		path = append(path, s.g.point(i))
+	}
+	slices.Reverse(path)
+	return Result{Path: path, Cost: s.dist[goal], Expanded: expanded}, nil
+}
+
+func (g *Grid) BFS(starts, goals []Point) (Result, error) {
+	s, sources, err := g.newSearch(starts, goals)
+	if err != nil {
+		return Result{}, err
+	}
+
+	queue := deque.NewQueue[int]()
+	for _, i := range sources {
+		queue.Enqueue(i)
+	}
+
+	expanded := 0
+	for !queue.IsEmpty() {
+		i, _ := queue.Dequeue()
+		expanded++
+		if s.goals[i] {
+			return s.result(i, expanded)
+		}
+
+		g.neighbors(i, func(j int, _ bool) {
+			if math.IsInf(s.dist[j], 1) {
+				s.dist[j] = s.dist[i] + 1
+				s.parent[j] = i
+				queue.Enqueue(j)
+			}
+		})
+	}
+	return s.result(-1, expanded)
+}
+
+func (g *Grid) ZeroOneBFS(starts, goals []Point) (Result, error) {
+	for _, w := range g.weights {
+		if w != Wall && w != 0 && w != 1 {
+			return Result{}, ErrNotZeroOne
+		}
+	}
+	if g.diagonal != DiagonalNever && g.diagonalCost != 1 {
+		return Result{}, ErrNotZeroOne
+	}
+
+	s, sources, err := g.newSearch(starts, goals)
+	if err != nil {
+		return Result{}, err
+	}
+
+	d := deque.NewDeque[int]()
+	for _, i := range sources {
+		d.PushBack(i)
+	}
+
+	done := make([]bool, len(g.weights))
+	expanded := 0
+	for !d.IsEmpty() {
+		i, _ := d.PopFront()
+		if done[i] {
+			continue
+		}
+		done[i] = true
+		expanded++
+		if s.goals[i] {
+			return s.result(i, expanded)
+		}
+
+		g.neighbors(i, func(j int, _ bool) {
+			w := float64(g.weights[j])
+			if s.dist[i]+w < s.dist[j] {
+				s.dist[j] = s.dist[i] + w
+				s.parent[j] = i
+				if w == 0 {
+					d.PushFront(j)
+				} else {
+					d.PushBack(j)
+				}
+			}
+		})
+	}
+	return s.result(-1, expanded)
+}
+
+type queued struct {
+	cell     int
+	priority float64
+	dist     float64
+}
+
+func lessQueued(a, b queued) bool {
+	if a.priority != b.priority {
+		return a.priority < b.priority
+	}
+	return a.dist > b.dist
+}
+
+func (g *Grid) Dijkstra(starts, goals []Point) (Result, error) {
+	return g.bestFirst(starts, goals, false)
+}
+
+func (g *Grid) AStar(starts, goals []Point) (Result, error) {
+	return g.bestFirst(starts, goals, true)
+}
+
+func (g *Grid) bestFirst(starts, goals []Point, informed bool) (Result, error) {
+	s, sources, err := g.newSearch(starts, goals)
+	if err != nil {
+		return Result{}, err
+	}
+
+	h := func(int) float64 { return 0 }
+	if informed {
+		h = s.heuristic(g.minWeight())
+	}
+
+	pq := heap.NewHeap(lessQueued)
+	for _, i := range sources {
+		pq.Push(queued{cell: i, priority: h(i)})
+	}
+
+	done := make([]bool, len(g.weights))
+	expanded := 0
+	for !pq.IsEmpty() {
+		item, _ := pq.Pop()
+		i := item.cell
+		if done[i] {
+			continue
+		}
+		done[i] = true
+		expanded++
+		if s.goals[i] {
+			return s.result(i, expanded)
+		}
+
+		g.neighbors(i, func(j int, diagonal bool) {
+			if done[j] {
+				return
+			}
+			nd := s.dist[i] + g.stepCost(j, diagonal)
+			if nd < s.dist[j] {
+				s.dist[j] = nd
+				s.parent[j] = i
+				pq.Push(queued{cell: j, priority: nd + h(j), dist: nd})
+			}
+		})
+	}
+	return s.result(-1, expanded)
+}
+
+func (g *Grid) minWeight() float64 {
+	lowest := math.Inf(1)
+	for _, w := range g.weights {
+		if w != Wall {
+			lowest = min(lowest, float64(w))
+		}
+	}
+	return lowest
+}
+
+func (s *search) heuristic(unit float64) func(int) float64 {
+	g := s.g
+	diagonalStep := min(unit*g.diagonalCost, 2*unit)
+	straightStep := min(unit, diagonalStep)
+	if g.diagonal == DiagonalNever {
+		diagonalStep, straightStep = 2*unit, unit
+	}
+
+	return func(i int) float64 {
+		r, c := i/g.cols, i%g.cols
+		best := math.Inf(1)
+		for _, p := range s.ends {
+			dr, dc := abs(p.Row-r), abs(p.Col-c)
+			lo, hi := min(dr, dc), max(dr, dc)
+			best = min(best, float64(lo)*diagonalStep+float64(hi-lo)*straightStep)
+		}
+		return best
+	}
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func sign(x int) int {
+	switch {
+	case x > 0:
+		return 1
+	case x < 0:
+		return -1
+	}
+	return 0
+}
+
+func (g *Grid) JumpPointSearch(starts, goals []Point) (Result, error) {
+	if g.diagonal != DiagonalIfNoObstacles {
+		return Result{}, ErrJPSUnsupported
+	}
+	unit := -1
+	for _, w := range g.weights {
+		if w != Wall {
+			if unit >= 0 && w != unit {
+				return Result{}, ErrJPSUnsupported
+			}
+			unit = w
+		}
+	}
+
+	s, sources, err := g.newSearch(starts, goals)
+	if err != nil {
+		return Result{}, err
+	}
+	h := s.heuristic(float64(max(unit, 0)))
+
+	pq := heap.NewHeap(lessQueued)
+	for _, i := range sources {
+		pq.Push(queued{cell: i, priority: h(i)})
+	}
+
+	done := make([]bool, len(g.weights))
+	expanded := 0
+	for !pq.IsEmpty() {
+		item, _ := pq.Pop()
+		i := item.cell
+		if done[i] {
+			continue
+		}
+		done[i] = true
+		expanded++
+		if s.goals[i] {
+			return s.expand(i, expanded)
+		}
+
+		for _, d := range s.prunedDirections(i) {
+			j, steps, ok := s.jump(i, d)
+			if !ok || done[j] {
+				continue
+			}
+
+			cost := float64(steps * unit)
+			if d.Row != 0 && d.Col != 0 {
+				cost *= g.diagonalCost
+			}
+			if nd := s.dist[i] + cost; nd < s.dist[j] {
+				s.dist[j] = nd
+				s.parent[j] = i
+				pq.Push(queued{cell: j, priority: nd + h(j), dist: nd})
+			}
+		}
+	}
+	return s.result(-1, expanded)
+}
+
+func (s *search) prunedDirections(i int) []Point {
+	g := s.g
+	r, c := i/g.cols, i%g.cols
+
+	if s.parent[i] < 0 {
+		var dirs []Point
+		g.neighbors(i, func(j int, _ bool) {
+			dirs = append(dirs, Point{Row: j/g.cols - r, Col: j%g.cols - c})
+		})
+		return dirs
+	}
+
+	p := g.point(s.parent[i])
+	dr, dc := sign(r-p.Row), sign(c-p.Col)
+	var dirs []Point
+
+	switch {
+	case dr != 0 && dc != 0:
+		vertical, horizontal := g.open(r+dr, c), g.open(r, c+dc)
+		if vertical {
+			dirs = append(dirs, Point{Row: dr})
+		}
+		if horizontal {
+			dirs = append(dirs, Point{Col: dc})
+		}
+		if vertical && horizontal && g.open(r+dr, c+dc) {
+			dirs = append(dirs, Point{Row: dr, Col: dc})
+		}
+	case dr == 0:
+		next, up, down := g.open(r, c+dc), g.open(r-1, c), g.open(r+1, c)
+		if next {
+			dirs = append(dirs, Point{Col: dc})
+			if up && g.open(r-1, c+dc) {
+				dirs = append(dirs, Point{Row: -1, Col: dc})
+			}
+			if down && g.open(r+1, c+dc) {
+				dirs = append(dirs, Point{Row: 1, Col: dc})
+			}
+		}
+		if up {
+			dirs = append(dirs, Point{Row: -1})
+		}
+		if down {
+			dirs = append(dirs, Point{Row: 1})
+		}
+	default:
+		next, left, right := g.open(r+dr, c), g.open(r, c-1), g.open(r, c+1)
+		if next {
+			dirs = append(dirs, Point{Row: dr})
+			if left && g.open(r+dr, c-1) {
+				dirs = append(dirs, Point{Row: dr, Col: -1})
+			}
+			if right && g.open(r+dr, c+1) {
+				dirs = append(dirs, Point{Row: dr, Col: 1})
+			}
+		}
+		if left {
+			dirs = append(dirs, Point{Col: -1})
+		}
+		if right {
+			dirs = append(dirs, Point{Col: 1})
+		}
+	}
+	return dirs
+}
+
+func (s *search) jump(from int, d Point) (int, int, bool) {
+	g := s.g
+	r, c := from/g.cols+d.Row, from%g.cols+d.Col
+
+	for steps := 1; ; steps++ {
+		if !g.open(r, c) {
+			return 0, 0, false
+		}
+		if s.goals[r*g.cols+c] {
+			return r*g.cols + c, steps, true
+		}
+
+		switch {
+		case d.Row != 0 && d.Col != 0:
+			if s.straightJumpExists(r, c, Point{Col: d.Col}) || s.straightJumpExists(r, c, Point{Row: d.Row}) {
+				return r*g.cols + c, steps, true
+			}
+		case d.Row == 0:
+			if (g.open(r-1, c) && !g.open(r-1, c-d.Col)) || (g.open(r+1, c) && !g.open(r+1, c-d.Col)) {
+				return r*g.cols + c, steps, true
+			}
+		default:
+			if (g.open(r, c-1) && !g.open(r-d.Row, c-1)) || (g.open(r, c+1) && !g.open(r-d.Row, c+1)) {
+				return r*g.cols + c, steps, true
+			}
+		}
+
+		if !g.open(r+d.Row, c) || !g.open(r, c+d.Col) {
+			return 0, 0, false
+		}
+		r, c = r+d.Row, c+d.Col
+	}
+}
+
+func (s *search) straightJumpExists(r, c int, d Point) bool {
+	_, _, ok := s.jump(r*s.g.cols+c, d)
+	return ok
+}
+
+func (s *search) expand(goal, expanded int) (Result, error) {
+	res, err := s.result(goal, expanded)
+	if err != nil {
+		return res, err
+	}
+
+	path := res.Path[:1:1]
+	for _, next := range res.Path[1:] {
+		cur := path[len(path)-1]
+		dr, dc := sign(next.Row-cur.Row), sign(next.Col-cur.Col)
+		for cur != next {
+			cur = Point{Row: cur.Row + dr, Col: cur.Col + dc}
+			path = append(path, cur)
+		}
+	}
+	res.Path = path
+	return res, nil
+}
+
+func PathCost(g *Grid, path []Point) (float64, bool) {
+	cost := 0.0
+	for k := 1; k < len(path); k++ {
+		a, b := path[k-1], path[k]
+		d := Point{Row: b.Row - a.Row, Col: b.Col - a.Col}
+		if !g.open(b.Row, b.Col) || abs(d.Row) > 1 || abs(d.Col) > 1 || d == (Point{}) {
+			return 0, false
+		}
+
+		diagonal := d.Row != 0 && d.Col != 0
+		if diagonal && !g.diagonalAllowed(a.Row, a.Col, d) {
+			return 0, false
+		}
+		cost += g.stepCost(b.Row*g.cols+b.Col, diagonal)
+	}
+	return cost, len(path) > 0 && g.open(path[0].Row, path[0].Col)
+}
+
+func SolveMaze(maze []string, wall string, start Point, end Point) ([]Point, error) {
+	g, err := FromMaze(maze, wall, Options{})
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := g.BFS([]Point{start}, []Point{end})
+	if errors.Is(err, ErrOutOfBounds) {
+		if !g.inBounds(start.Row, start.Col) {
+			return nil, errors.New("start position out of bounds")
+		}
+		return nil, errors.New("end position out of bounds")
+	}
+	return res.Path, err
+}
+
+func Run() any {
+	maze := []string{
+		"xxxxxxxxxxxx",
+		"x    x     x",
+		"x xx x xxx x",
+		"x  x   x   x",
+		"xx xxxxx xxx",
+		"x     9    x",
+		"xxxxxxxxxxxx",
+	}
+
+	start := Point{Row: 1, Col: 1}
+	end := Point{Row: 5, Col: 10}
+	exits := []Point{end, {Row: 3, Col: 10}}
+
+	shortest, err := SolveMaze(maze, "x", start, end)
+	if err != nil {
+		return map[string]any{"error": err.Error()}
+	}
+
+	fourWay, _ := FromMaze(maze, "x", Options{})
+	eightWay, _ := FromMaze(maze, "x", Options{Diagonal: DiagonalIfNoObstacles})
+
+	results := make(map[string]any)
+	for name, search := range map[string]func([]Point, []Point) (Result, error){
+		"bfs":              fourWay.BFS,
+		"dijkstra":         fourWay.Dijkstra,
+		"astar":            fourWay.AStar,
+		"astar_8way":       eightWay.AStar,
+		"dijkstra_8way":    eightWay.Dijkstra,
+		"nearest_exit_bfs": func(s, _ []Point) (Result, error) { return fourWay.BFS(s, exits) },
+	} {
+		res, err := search([]Point{start}, []Point{end})
+		if err != nil {
+			results[name] = err.Error()
+			continue
+		}
+		results[name] = map[string]any{"cost": res.Cost, "length": len(res.Path), "expanded": res.Expanded}
+	}
+
+	open, _ := NewGrid(uniform(40, 40), Options{Diagonal: DiagonalIfNoObstacles})
+	corner := []Point{{Row: 0, Col: 0}}
+	far := []Point{{Row: 39, Col: 25}}
+	astar, _ := open.AStar(corner, far)
+	jps, _ := open.JumpPointSearch(corner, far)
+
+	return map[string]any{
+		"maze":             maze,
+		"shortest_path":    strings.Split(maze_with_recursion.PrintMazeWithPath(maze, shortest, "x"), "\n"),
+		"searches":         results,
+		"open_40x40_astar": map[string]any{"cost": astar.Cost, "expanded": astar.Expanded},
+		"open_40x40_jps":   map[string]any{"cost": jps.Cost, "expanded": jps.Expanded},
+	}
+}
+
+func uniform(rows, cols int) [][]int {
+	weights := make([][]int, rows)
+	for r := range weights {
+		weights[r] = make([]int, cols)
+		for c := range weights[r] {
+			weights[r][c] = 1
+		}
+	}
+	return weights
+}
@@ -0,0 +1,355 @@
+package grid_pathfinding
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/celj/dsa/0011-maze-with-recursion"
+)
+
+func randomWeights(rng *rand.Rand, rows, cols int, wallRate float64, weights []int) [][]int {
+	grid := make([][]int, rows)
+	for r := range grid {
+		grid[r] = make([]int, cols)
+		for c := range grid[r] {
+			if rng.Float64() < wallRate {
+				grid[r][c] = Wall
+			} else {
+				grid[r][c] = weights[rng.Intn(len(weights))]
+			}
+		}
+	}
+	return grid
+}
+
+func checkPath(t *testing.T, g *Grid, name string, res Result, starts, goals []Point) {
+	t.Helper()
+
+	cost, ok := PathCost(g, res.Path)
+	if !ok {
+		t.Fatalf("%s: illegal path %v", name, res.Path)
+	}
+	if math.Abs(cost-res.Cost) > 1e-9 {
+		t.Fatalf("%s: reported cost %v, path costs %v", name, res.Cost, cost)
+	}
+
+	isStart, isGoal := false, false
+	for _, p := range starts {
+		isStart = isStart || p == res.Path[0]
+	}
+	for _, p := range goals {
+		isGoal = isGoal || p == res.Path[len(res.Path)-1]
+	}
+	if !isStart || !isGoal {
+		t.Fatalf("%s: path %v does not run from a start to a goal", name, res.Path)
+	}
+}
+
+func TestShortestPathsAgree(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for trial := range 300 {
+		rows, cols := 1+rng.Intn(12), 1+rng.Intn(12)
+		weights := randomWeights(rng, rows, cols, 0.3, []int{1})
+		diagonal := Diagonal(rng.Intn(4))
+		g, err := NewGrid(weights, Options{Diagonal: diagonal})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		starts := []Point{{Row: rng.Intn(rows), Col: rng.Intn(cols)}}
+		goals := []Point{{Row: rng.Intn(rows), Col: rng.Intn(cols)}}
+		if rng.Intn(2) == 0 {
+			starts = append(starts, Point{Row: rng.Intn(rows), Col: rng.Intn(cols)})
+			goals = append(goals, Point{Row: rng.Intn(rows), Col: rng.Intn(cols)})
+		}
+
+		dijkstra, dErr := g.Dijkstra(starts, goals)
+		astar, aErr := g.AStar(starts, goals)
+		if !errors.Is(aErr, dErr) {
+			t.Fatalf("trial %d: Dijkstra error %v, A* error %v", trial, dErr, aErr)
+		}
+		if dErr != nil {
+			if !errors.Is(dErr, ErrNoPath) {
+				t.Fatalf("trial %d: unexpected error %v", trial, dErr)
+			}
+			continue
+		}
+
+		checkPath(t, g, "dijkstra", dijkstra, starts, goals)
+		checkPath(t, g, "astar", astar, starts, goals)
+		if math.Abs(dijkstra.Cost-astar.Cost) > 1e-9 {
+			t.Fatalf("trial %d: Dijkstra cost %v, A* cost %v", trial, dijkstra.Cost, astar.Cost)
+		}
+
+		if diagonal == DiagonalNever {
+			bfs, err := g.BFS(starts, goals)
+			if err != nil || bfs.Cost != dijkstra.Cost {
+				t.Fatalf("trial %d: BFS cost %v (%v), Dijkstra cost %v", trial, bfs.Cost, err, dijkstra.Cost)
+			}
+			checkPath(t, g, "bfs", bfs, starts, goals)
+		}
+
+		if diagonal == DiagonalIfNoObstacles {
+			jps, err := g.JumpPointSearch(starts, goals)
+			if err != nil || math.Abs(jps.Cost-dijkstra.Cost) > 1e-9 {
+				t.Fatalf("trial %d: JPS cost %v (%v), Dijkstra cost %v\n%v", trial, jps.Cost, err, dijkstra.Cost, weights)
+			}
+			checkPath(t, g, "jps", jps, starts, goals)
+		}
+	}
+}
+
+func TestWeightedSearchesAgree(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+
+	for trial := range 200 {
+		rows, cols := 1+rng.Intn(15), 1+rng.Intn(15)
+		start := []Point{{Row: rng.Intn(rows), Col: rng.Intn(cols)}}
+		goal := []Point{{Row: rng.Intn(rows), Col: rng.Intn(cols)}}
+
+		zeroOne, _ := NewGrid(randomWeights(rng, rows, cols, 0.2, []int{0, 1}), Options{})
+		want, wantErr := zeroOne.Dijkstra(start, goal)
+		got, err := zeroOne.ZeroOneBFS(start, goal)
+		if !errors.Is(err, wantErr) || got.Cost != want.Cost {
+			t.Fatalf("trial %d: 0-1 BFS cost %v (%v), Dijkstra cost %v (%v)", trial, got.Cost, err, want.Cost, wantErr)
+		}
+		if err == nil {
+			checkPath(t, zeroOne, "0-1 bfs", got, start, goal)
+		}
+
+		weighted, _ := NewGrid(randomWeights(rng, rows, cols, 0.2, []int{1, 2, 5, 9}), Options{Diagonal: DiagonalIfOneOpen})
+		want, wantErr = weighted.Dijkstra(start, goal)
+		got, err = weighted.AStar(start, goal)
+		if !errors.Is(err, wantErr) || math.Abs(got.Cost-want.Cost) > 1e-9 {
+			t.Fatalf("trial %d: A* cost %v (%v), Dijkstra cost %v (%v)", trial, got.Cost, err, want.Cost, wantErr)
+		}
+	}
+}
+
+func TestDiagonalRules(t *testing.T) {
+	weights := [][]int{
+		{1, Wall},
+		{Wall, 1},
+	}
+	from, to := []Point{{Row: 0, Col: 0}}, []Point{{Row: 1, Col: 1}}
+
+	testCases := []struct {
+		diagonal Diagonal
+		ok       bool
+	}{
+		{DiagonalNever, false},
+		{DiagonalAlways, true},
+		{DiagonalIfOneOpen, false},
+		{DiagonalIfNoObstacles, false},
+	}
+
+	for _, tc := range testCases {
+		g, _ := NewGrid(weights, Options{Diagonal: tc.diagonal})
+		_, err := g.Dijkstra(from, to)
+		if (err == nil) != tc.ok {
+			t.Errorf("diagonal rule %d: expected ok=%v, got %v", tc.diagonal, tc.ok, err)
+		}
+	}
+
+	weights[0][1] = 1
+	g, _ := NewGrid(weights, Options{Diagonal: DiagonalIfOneOpen})
+	res, err := g.Dijkstra(from, to)
+	if err != nil || len(res.Path) != 2 || math.Abs(res.Cost-math.Sqrt2) > 1e-12 {
+		t.Errorf("expected a single diagonal step past one wall, got %v (%v)", res, err)
+	}
+
+	g, _ = NewGrid(weights, Options{Diagonal: DiagonalIfOneOpen, DiagonalCost: 3})
+	res, _ = g.Dijkstra(from, to)
+	if res.Cost != 2 || len(res.Path) != 3 {
+		t.Errorf("expected two straight steps when diagonals cost 3, got %v", res)
+	}
+}
+
+func TestMultipleStartsAndGoals(t *testing.T) {
+	maze := []string{
+		"xxxxxxxxxx",
+		"x        x",
+		"x xxxxxx x",
+		"x        x",
+		"xxxxxxxxxx",
+	}
+	g, err := FromMaze(maze, "x", Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	starts := []Point{{Row: 1, Col: 1}, {Row: 3, Col: 8}}
+	goals := []Point{{Row: 3, Col: 1}, {Row: 1, Col: 8}}
+	res, err := g.BFS(starts, goals)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Cost != 2 {
+		t.Errorf("expected the nearest start-goal pair 2 steps apart, got %v along %v", res.Cost, res.Path)
+	}
+	checkPath(t, g, "bfs", res, starts, goals)
+}
+
+func TestWeightedMaze(t *testing.T) {
+	maze := []string{
+		"xxxxxxx",
+		"x  9  x",
+		"x xxx x",
+		"x     x",
+		"xxxxxxx",
+	}
+	g, _ := FromMaze(maze, "x", Options{})
+	from, to := []Point{{Row: 1, Col: 1}}, []Point{{Row: 1, Col: 5}}
+
+	bfs, _ := g.BFS(from, to)
+	dijkstra, _ := g.Dijkstra(from, to)
+	if bfs.Cost != 4 || dijkstra.Cost != 8 {
+		t.Errorf("expected BFS to cross the 9 in 4 steps and Dijkstra to go around for 8, got %v and %v", bfs.Cost, dijkstra.Cost)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	g, _ := NewGrid([][]int{{1, Wall, 1}}, Options{})
+	p := func(r, c int) []Point { return []Point{{Row: r, Col: c}} }
+
+	if _, err := g.BFS(p(0, 0), p(0, 2)); !errors.Is(err, ErrNoPath) {
+		t.Errorf("expected ErrNoPath, got %v", err)
+	}
+	if _, err := g.AStar(p(0, 0), p(5, 5)); !errors.Is(err, ErrOutOfBounds) {
+		t.Errorf("expected ErrOutOfBounds, got %v", err)
+	}
+	if _, err := g.Dijkstra(nil, p(0, 2)); !errors.Is(err, ErrNoEndpoints) {
+		t.Errorf("expected ErrNoEndpoints, got %v", err)
+	}
+	if _, err := g.JumpPointSearch(p(0, 0), p(0, 2)); !errors.Is(err, ErrJPSUnsupported) {
+		t.Errorf("expected ErrJPSUnsupported for 4-way movement, got %v", err)
+	}
+
+	heavy, _ := NewGrid([][]int{{1, 2}}, Options{})
+	if _, err := heavy.ZeroOneBFS(p(0, 0), p(0, 1)); !errors.Is(err, ErrNotZeroOne) {
+		t.Errorf("expected ErrNotZeroOne, got %v", err)
+	}
+
+	if _, err := NewGrid([][]int{{1, 1}, {1}}, Options{}); !errors.Is(err, ErrInvalidWeights) {
+		t.Errorf("expected ErrInvalidWeights, got %v", err)
+	}
+	if _, err := FromMaze(nil, "x", Options{}); err == nil {
+		t.Error("expected ValidateMaze to reject an empty maze")
+	}
+}
+
+func TestSolveMazeCompatibility(t *testing.T) {
+	maze := []string{
+		"xxxxxxx x",
+		"x       x",
+		"x xxxxxxx",
+	}
+	start, end := Point{Row: 2, Col: 1}, Point{Row: 0, Col: 7}
+
+	path, err := SolveMaze(maze, "x", start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	old, _ := maze_with_recursion.SolveMaze(maze, "x", start, end)
+	if len(path) > len(old) || path[0] != start || path[len(path)-1] != end {
+		t.Errorf("expected a shortest path from start to end no longer than %d, got %v", len(old), path)
+	}
+
+	want := strings.Join([]string{"xxxxxxx*x", "x*******x", "x*xxxxxxx"}, "\n")
+	if got := maze_with_recursion.PrintMazeWithPath(maze, path, "x"); got != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, got)
+	}
+
+	if _, err := SolveMaze(maze, "x", Point{Row: 9, Col: 0}, end); err == nil || err.Error() != "start position out of bounds" {
+		t.Errorf("expected the original start error, got %v", err)
+	}
+}
+
+func TestSolveMazeFindsShortestPath(t *testing.T) {
+	maze := []string{
+		"xxxxxxx",
+		"x     x",
+		"x x x x",
+		"x     x",
+		"xxxxxxx",
+	}
+	path, err := SolveMaze(maze, "x", Point{Row: 1, Col: 1}, Point{Row: 3, Col: 5})
+	if err != nil || len(path) != 7 {
+		t.Errorf("expected a 7-cell shortest path, got %d cells (%v)", len(path), err)
+	}
+}
+
+func TestLargeGrid(t *testing.T) {
+	n := 1000
+	weights := uniform(n, n)
+	for r := 1; r < n-1; r += 2 {
+		for c := range n {
+			weights[r][c] = Wall
+		}
+		if r%4 == 1 {
+			weights[r][n-1] = 1
+		} else {
+			weights[r][0] = 1
+		}
+	}
+
+	g, _ := NewGrid(weights, Options{})
+	res, err := g.BFS([]Point{{Row: 0, Col: 0}}, []Point{{Row: n - 1, Col: n - 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Path) < n*n/2-n {
+		t.Errorf("expected a serpentine path of at least %d cells, got %d", n*n/2-n, len(res.Path))
+	}
+}
+
+func TestRun(t *testing.T) {
+	result := Run()
+	if result == nil {
+		t.Error("Expected non-nil result")
+	}
+
+	resultMap, ok := result.(map[string]any)
+	if !ok {
+		t.Fatal("Expected result to be a map")
+	}
+	if _, ok := resultMap["error"]; ok {
+		t.Errorf("unexpected error: %v", resultMap["error"])
+	}
+}
+
+func benchmarkSearch(b *testing.B, search func(g *Grid) func([]Point, []Point) (Result, error)) {
+	for _, wallRate := range []float64{0, 0.1} {
+		b.Run(fmt.Sprintf("walls=%.0f%%", wallRate*100), func(b *testing.B) {
+			rng := rand.New(rand.NewSource(3))
+			weights := randomWeights(rng, 256, 256, wallRate, []int{1})
+			weights[0][0], weights[255][255] = 1, 1
+			g, _ := NewGrid(weights, Options{Diagonal: DiagonalIfNoObstacles})
+			from, to := []Point{{Row: 0, Col: 0}}, []Point{{Row: 255, Col: 255}}
+
+			if _, err := search(g)(from, to); err != nil {
+				b.Fatal(err)
+			}
+			for b.Loop() {
+				search(g)(from, to)
+			}
+		})
+	}
+}
+
+func BenchmarkDijkstra(b *testing.B) {
+	benchmarkSearch(b, func(g *Grid) func([]Point, []Point) (Result, error) { return g.Dijkstra })
+}
+
+func BenchmarkAStar(b *testing.B) {
+	benchmarkSearch(b, func(g *Grid) func([]Point, []Point) (Result, error) { return g.AStar })
+}
+
+func BenchmarkJumpPointSearch(b *testing.B) {
+	benchmarkSearch(b, func(g *Grid) func([]Point, []Point) (Result, error) { return g.JumpPointSearch })
+}
@@ -0,0 +1,454 @@
+package maze_generation
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+
+	"github.com/celj/dsa/0011-maze-with-recursion"
+	"github.com/celj/dsa/0031-prim-algorithm"
+	"github.com/celj/dsa/0032-kruskal-algorithm"
+)
+
+const Wall = "x"
+
+var (
+	ErrInvalidSize  = errors.New("maze width and height must be at least 1")
+	ErrInvalidBraid = errors.New("braid factor must be between 0 and 1")
+)
+
+type Algorithm int
+
+const (
+	RecursiveBacktracker Algorithm = iota
+	Prim
+	Kruskal
+	Wilson
+	Eller
+)
+
+func Algorithms() []Algorithm {
+	return []Algorithm{RecursiveBacktracker, Prim, Kruskal, Wilson, Eller}
+}
+
+func (a Algorithm) String() string {
+	switch a {
+	case RecursiveBacktracker:
+		return "recursive_backtracker"
+	case Prim:
+		return "prim"
+	case Kruskal:
+		return "kruskal"
+	case Wilson:
+		return "wilson"
+	case Eller:
+		return "eller"
+	default:
+		return fmt.Sprintf("Algorithm(%d)", int(a))
+	}
+}
+
+type Options struct {
+	Width  int
+	Height int
+	Seed   int64
+	Braid  float64
+}
+
+type Maze struct {
+	Width  int
+	Height int
+	east   []bool
+	south  []bool
+}
+
+func newMaze(width, height int) *Maze {
+	return &Maze{
+		Width:  width,
+		Height: height,
+		east:   make([]bool, width*height),
+		south:  make([]bool, width*height),
+	}
+}
+
+func Generate(algorithm Algorithm, opts Options) (*Maze, error) {
+	if opts.Width < 1 || opts.Height < 1 {
+		return nil, ErrInvalidSize
+	}
+	if opts.Braid < 0 || opts.Braid > 1 {
+		return nil, ErrInvalidBraid
+	}
+
+	m := newMaze(opts.Width, opts.Height)
+	rng := rand.New(rand.NewSource(opts.Seed))
+
+	switch algorithm {
+	case RecursiveBacktracker:
+		m.backtrack(rng)
+	case Prim:
+		if err := m.prim(rng); err != nil {
+			return nil, err
+		}
+	case Kruskal:
+		m.kruskal(rng)
+	case Wilson:
+		m.wilson(rng)
+	case Eller:
+		m.eller(rng)
+	default:
+		return nil, fmt.Errorf("unknown maze algorithm %v", algorithm)
+	}
+
+	m.braid(rng, opts.Braid)
+	return m, nil
+}
+
+func (m *Maze) cell(x, y int) int {
+	return y*m.Width + x
+}
+
+func (m *Maze) neighbors(c int) []int {
+	x, y := c%m.Width, c/m.Width
+	var out []int
+	if y > 0 {
+		out = append(out, c-m.Width)
+	}
+	if y < m.Height-1 {
+		out = append(out, c+m.Width)
+	}
+	if x > 0 {
+		out = append(out, c-1)
+	}
+	if x < m.Width-1 {
+		out = append(out, c+1)
+	}
+	return out
+}
+
+func (m *Maze) carve(a, b int) {
+	if a > b {
+		a, b = b, a
+	}
+	if b == a+1 && b%m.Width != 0 {
+		m.east[a] = true
+	} else {
+		m.south[a] = true
+	}
+}
+
+func (m *Maze) Connected(a, b int) bool {
+	if a > b {
+		a, b = b, a
+	}
+	switch {
+	case b-a == m.Width:
+		return m.south[a]
+	case b-a == 1:
+		return b%m.Width != 0 && m.east[a]
+	}
+	return false
+}
+
+func (m *Maze) degree(c int) int {
+	d := 0
+	for _, n := range m.neighbors(c) {
+		if m.Connected(c, n) {
+			d++
+		}
+	}
+	return d
+}
+
+func (m *Maze) backtrack(rng *rand.Rand) {
+	visited := make([]bool, m.Width*m.Height)
+	start := rng.Intn(len(visited))
+	visited[start] = true
+	stack := []int{start}
+
+	for len(stack) > 0 {
+		c := stack[len(stack)-1]
+
+		var unvisited []int
+		for _, n := range m.neighbors(c) {
+			if !visited[n] {
+				unvisited = append(unvisited, n)
+			}
+		}
+		if len(unvisited) == 0 {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		next := unvisited[rng.Intn(len(unvisited))]
+		m.carve(c, next)
+		visited[next] = true
+		stack = append(stack, next)
+	}
+}
+
+func (m *Maze) prim(rng *rand.Rand) error {
+	g := prim_algorithm.NewGraph(m.Width * m.Height)
+	for c := range m.Width * m.Height {
+		for _, n := range m.neighbors(c) {
+			if n > c {
+				if err := g.AddEdge(c, n, rng.Float64()); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	mst, err := g.PrimMST()
+	if err != nil {
+		return err
+	}
+	for _, e := range mst.GetEdges() {
+		m.carve(e.From, e.To)
+	}
+	return nil
+}
+
+func (m *Maze) kruskal(rng *rand.Rand) {
+	var walls [][2]int
+	for c := range m.Width * m.Height {
+		for _, n := range m.neighbors(c) {
+			if n > c {
+				walls = append(walls, [2]int{c, n})
+			}
+		}
+	}
+	rng.Shuffle(len(walls), func(i, j int) { walls[i], walls[j] = walls[j], walls[i] })
+
+	uf := kruskal_algorithm.NewUnionFind(m.Width * m.Height)
+	for _, w := range walls {
+		if uf.Union(w[0], w[1]) {
+			m.carve(w[0], w[1])
+		}
+	}
+}
+
+func (m *Maze) wilson(rng *rand.Rand) {
+	n := m.Width * m.Height
+	inTree := make([]bool, n)
+	next := make([]int, n)
+	inTree[rng.Intn(n)] = true
+
+	for _, start := range rng.Perm(n) {
+		c := start
+		for !inTree[c] {
+			options := m.neighbors(c)
+			next[c] = options[rng.Intn(len(options))]
+			c = next[c]
+		}
+
+		for c = start; !inTree[c]; c = next[c] {
+			m.carve(c, next[c])
+			inTree[c] = true
+		}
+	}
+}
+
+func (m *Maze) eller(rng *rand.Rand) {
+	sets := make([]int, m.Width)
+	for x := range sets {
+		sets[x] = -1
+	}
+	nextSet := 0
+
+	for y := range m.Height {
+		last := y == m.Height-1
+
+		for x := range sets {
+			if sets[x] < 0 {
+				sets[x] = nextSet
+				nextSet++
+			}
+		}
+
+		for x := 0; x+1 < m.Width; x++ {
+			if sets[x] == sets[x+1] || (!last && rng.Intn(2) == 0) {
+				continue
+			}
+			m.carve(m.cell(x, y), m.cell(x+1, y))
+			from, to := sets[x+1], sets[x]
+			for i := range sets {
+				if sets[i] == from {
+					sets[i] = to
+				}
+			}
+		}
+
+		if last {
+			break
+		}
+
+		members := make(map[int][]int)
+		var order []int
+		for x, s := range sets {
+			if _, ok := members[s]; !ok {
+				order = append(order, s)
+			}
+			members[s] = append(members[s], x)
+		}
+
+		below := make([]int, m.Width)
+		for x := range below {
+			below[x] = -1
+		}
+		for _, s := range order {
+			cols := members[s]
+			rng.Shuffle(len(cols), func(i, j int) { cols[i], cols[j] = cols[j], cols[i] })
+			drops := 1 + rng.Intn(len(cols))
+			for _, x := range cols[:drops] {
+				m.carve(m.cell(x, y), m.cell(x, y+1))
+				below[x] = s
+			}
+		}
+		sets = below
+	}
+}
+
+func (m *Maze) braid(rng *rand.Rand, factor float64) {
+	if factor == 0 {
+		return
+	}
+
+	for _, c := range rng.Perm(m.Width * m.Height) {
+		if m.degree(c) != 1 || rng.Float64() >= factor {
+			continue
+		}
+
+		var walled, deadEnds []int
+		for _, n := range m.neighbors(c) {
+			if m.Connected(c, n) {
+				continue
+			}
+			walled = append(walled, n)
+			if m.degree(n) == 1 {
+				deadEnds = append(deadEnds, n)
+			}
+		}
+		if len(deadEnds) > 0 {
+			walled = deadEnds
+		}
+		if len(walled) > 0 {
+			m.carve(c, walled[rng.Intn(len(walled))])
+		}
+	}
+}
+
+func (m *Maze) Passages() int {
+	count := 0
+	for c := range m.Width * m.Height {
+		if m.east[c] {
+			count++
+		}
+		if m.south[c] {
+			count++
+		}
+	}
+	return count
+}
+
+func (m *Maze) DeadEnds() int {
+	count := 0
+	for c := range m.Width * m.Height {
+		if m.degree(c) == 1 {
+			count++
+		}
+	}
+	return count
+}
+
+func (m *Maze) IsPerfect() bool {
+	n := m.Width * m.Height
+	if m.Passages() != n-1 {
+		return false
+	}
+
+	seen := make([]bool, n)
+	seen[0] = true
+	stack := []int{0}
+	reached := 1
+	for len(stack) > 0 {
+		c := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, nb := range m.neighbors(c) {
+			if !seen[nb] && m.Connected(c, nb) {
+				seen[nb] = true
+				reached++
+				stack = append(stack, nb)
+			}
+		}
+	}
+	return reached == n
+}
+
+func (m *Maze) Lines() []string {
+	rows, cols := 2*m.Height+1, 2*m.Width+1
+	grid := make([][]byte, rows)
+	for r := range grid {
+		grid[r] = make([]byte, cols)
+		for c := range grid[r] {
+			grid[r][c] = Wall[0]
+		}
+	}
+
+	for y := range m.Height {
+		for x := range m.Width {
+			c := m.cell(x, y)
+			grid[2*y+1][2*x+1] = ' '
+			if m.east[c] {
+				grid[2*y+1][2*x+2] = ' '
+			}
+			if m.south[c] {
+				grid[2*y+2][2*x+1] = ' '
+			}
+		}
+	}
+
+	lines := make([]string, rows)
+	for r, row := range grid {
+		lines[r] = string(row)
+	}
+	return lines
+}
+
+func (m *Maze) Start() maze_with_recursion.Point {
+	return maze_with_recursion.Point{Row: 1, Col: 1}
+}
+
+func (m *Maze) End() maze_with_recursion.Point {
+	return maze_with_recursion.Point{Row: 2*m.Height - 1, Col: 2*m.Width - 1}
+}
+
+func Run() any {
+	mazes := make(map[string]any)
+	for _, alg := range Algorithms() {
+		m, err := Generate(alg, Options{Width: 8, Height: 5, Seed: 1})
+		if err != nil {
+			return map[string]any{"error": err.Error()}
+		}
+
+		path, err := maze_with_recursion.SolveMaze(m.Lines(), Wall, m.Start(), m.End())
+		if err != nil {
+			return map[string]any{"error": err.Error()}
+		}
+
+		mazes[alg.String()] = map[string]any{
+			"maze":      m.Lines(),
+			"dead_ends": m.DeadEnds(),
+			"perfect":   m.IsPerfect(),
+			"solution":  len(path),
+		}
+	}
+
+	braided, _ := Generate(RecursiveBacktracker, Options{Width: 8, Height: 5, Seed: 1, Braid: 1})
+
+	return map[string]any{
+		"mazes":             mazes,
+		"braided":           braided.Lines(),
+		"braided_dead_ends": braided.DeadEnds(),
+		"braided_perfect":   braided.IsPerfect(),
+	}
+}
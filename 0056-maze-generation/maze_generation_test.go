@@ -0,0 +1,163 @@
+package maze_generation
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/celj/dsa/0011-maze-with-recursion"
+	"github.com/celj/dsa/0055-grid-pathfinding"
+)
+
+func TestPerfectMazes(t *testing.T) {
+	sizes := [][2]int{{1, 1}, {1, 7}, {7, 1}, {2, 2}, {5, 3}, {16, 9}, {30, 30}}
+
+	for _, alg := range Algorithms() {
+		for _, size := range sizes {
+			for seed := range int64(5) {
+				m, err := Generate(alg, Options{Width: size[0], Height: size[1], Seed: seed})
+				if err != nil {
+					t.Fatalf("%v %v: %v", alg, size, err)
+				}
+				if !m.IsPerfect() {
+					t.Fatalf("%v %v seed %d: expected a perfect maze\n%s", alg, size, seed, m.Lines())
+				}
+			}
+		}
+	}
+}
+
+func TestSeededOutput(t *testing.T) {
+	for _, alg := range Algorithms() {
+		a, _ := Generate(alg, Options{Width: 12, Height: 8, Seed: 42})
+		b, _ := Generate(alg, Options{Width: 12, Height: 8, Seed: 42})
+		c, _ := Generate(alg, Options{Width: 12, Height: 8, Seed: 43})
+
+		if !slices.Equal(a.Lines(), b.Lines()) {
+			t.Errorf("%v: expected the same seed to give the same maze", alg)
+		}
+		if slices.Equal(a.Lines(), c.Lines()) {
+			t.Errorf("%v: expected different seeds to give different mazes", alg)
+		}
+	}
+}
+
+func TestLinesFormat(t *testing.T) {
+	m, _ := Generate(Kruskal, Options{Width: 6, Height: 4, Seed: 1})
+	lines := m.Lines()
+
+	if err := maze_with_recursion.ValidateMaze(lines); err != nil {
+		t.Fatal(err)
+	}
+	if len(lines) != 9 || len(lines[0]) != 13 {
+		t.Errorf("expected a 9×13 maze, got %d×%d", len(lines), len(lines[0]))
+	}
+	for r, line := range lines {
+		for c := range len(line) {
+			border := r == 0 || c == 0 || r == len(lines)-1 || c == len(line)-1
+			if border && string(line[c]) != Wall {
+				t.Fatalf("expected a closed border, found an opening at (%d, %d)", r, c)
+			}
+			if r%2 == 1 && c%2 == 1 && line[c] != ' ' {
+				t.Fatalf("expected cell (%d, %d) to be open", r, c)
+			}
+		}
+	}
+
+	one, _ := Generate(Prim, Options{Width: 1, Height: 1})
+	if want := []string{"xxx", "x x", "xxx"}; !slices.Equal(one.Lines(), want) {
+		t.Errorf("expected %v, got %v", want, one.Lines())
+	}
+}
+
+func TestBraid(t *testing.T) {
+	for _, alg := range Algorithms() {
+		perfect, _ := Generate(alg, Options{Width: 20, Height: 20, Seed: 7})
+		half, _ := Generate(alg, Options{Width: 20, Height: 20, Seed: 7, Braid: 0.5})
+		full, _ := Generate(alg, Options{Width: 20, Height: 20, Seed: 7, Braid: 1})
+
+		if full.DeadEnds() != 0 {
+			t.Errorf("%v: expected braid 1 to remove every dead end, %d left", alg, full.DeadEnds())
+		}
+		if half.DeadEnds() >= perfect.DeadEnds() || half.DeadEnds() == 0 {
+			t.Errorf("%v: expected braid 0.5 to remove some dead ends, %d of %d left", alg, half.DeadEnds(), perfect.DeadEnds())
+		}
+		if full.IsPerfect() || full.Passages() <= perfect.Passages() {
+			t.Errorf("%v: expected a braided maze to contain loops", alg)
+		}
+	}
+}
+
+func TestErrors(t *testing.T) {
+	if _, err := Generate(Wilson, Options{Width: 0, Height: 3}); !errors.Is(err, ErrInvalidSize) {
+		t.Errorf("expected ErrInvalidSize, got %v", err)
+	}
+	if _, err := Generate(Wilson, Options{Width: 3, Height: 3, Braid: 1.5}); !errors.Is(err, ErrInvalidBraid) {
+		t.Errorf("expected ErrInvalidBraid, got %v", err)
+	}
+	if _, err := Generate(Algorithm(99), Options{Width: 3, Height: 3}); err == nil {
+		t.Error("expected an error for an unknown algorithm")
+	}
+}
+
+func TestPathfindingFixtures(t *testing.T) {
+	for _, alg := range Algorithms() {
+		for seed := range int64(10) {
+			braid := float64(seed%3) / 2
+			m, _ := Generate(alg, Options{Width: 15, Height: 10, Seed: seed, Braid: braid})
+			name := fmt.Sprintf("%v seed %d braid %.1f", alg, seed, braid)
+
+			dfs, err := maze_with_recursion.SolveMaze(m.Lines(), Wall, m.Start(), m.End())
+			if err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+
+			g, err := grid_pathfinding.FromMaze(m.Lines(), Wall, grid_pathfinding.Options{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			start, end := []grid_pathfinding.Point{m.Start()}, []grid_pathfinding.Point{m.End()}
+			bfs, err := g.BFS(start, end)
+			if err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+			astar, _ := g.AStar(start, end)
+
+			if astar.Cost != bfs.Cost {
+				t.Fatalf("%s: A* cost %v, BFS cost %v", name, astar.Cost, bfs.Cost)
+			}
+			if len(bfs.Path) > len(dfs) {
+				t.Fatalf("%s: BFS path of %d cells is longer than the DFS path of %d", name, len(bfs.Path), len(dfs))
+			}
+			if braid == 0 && len(bfs.Path) != len(dfs) {
+				t.Fatalf("%s: a perfect maze has one path, got %d and %d cells", name, len(bfs.Path), len(dfs))
+			}
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	result := Run()
+	if result == nil {
+		t.Error("Expected non-nil result")
+	}
+
+	resultMap, ok := result.(map[string]any)
+	if !ok {
+		t.Fatal("Expected result to be a map")
+	}
+	if _, ok := resultMap["error"]; ok {
+		t.Errorf("unexpected error: %v", resultMap["error"])
+	}
+}
+
+func BenchmarkGenerate(b *testing.B) {
+	for _, alg := range Algorithms() {
+		b.Run(alg.String(), func(b *testing.B) {
+			for b.Loop() {
+				Generate(alg, Options{Width: 100, Height: 100, Seed: 1})
+			}
+		})
+	}
+}
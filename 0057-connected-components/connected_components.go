@@ -0,0 +1,367 @@
+package connected_components
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/celj/dsa/0032-kruskal-algorithm"
+)
+
+var (
+	ErrRaggedGrid      = errors.New("grid rows must all have the same length")
+	ErrRowWidth        = errors.New("row width does not match the labeler width")
+	ErrClosed          = errors.New("labeler is closed")
+	ErrBadConnectivity = errors.New("connectivity must be Four or Eight")
+)
+
+type Connectivity int
+
+const (
+	Four  Connectivity = 4
+	Eight Connectivity = 8
+)
+
+type Component struct {
+	Label       int
+	Area        int
+	TopLeft     [2]int
+	BottomRight [2]int
+	Perimeter   int
+	Centroid    [2]float64
+}
+
+type stats struct {
+	area           int
+	minRow, minCol int
+	maxRow, maxCol int
+	perimeter      int
+	sumRow, sumCol float64
+}
+
+func newStats(r, c int) *stats {
+	return &stats{minRow: r, minCol: c, maxRow: r, maxCol: c}
+}
+
+func (s *stats) add(r, c, perimeter int) {
+	s.area++
+	s.minRow, s.maxRow = min(s.minRow, r), max(s.maxRow, r)
+	s.minCol, s.maxCol = min(s.minCol, c), max(s.maxCol, c)
+	s.perimeter += perimeter
+	s.sumRow += float64(r)
+	s.sumCol += float64(c)
+}
+
+func (s *stats) merge(o *stats) {
+	s.area += o.area
+	s.minRow, s.maxRow = min(s.minRow, o.minRow), max(s.maxRow, o.maxRow)
+	s.minCol, s.maxCol = min(s.minCol, o.minCol), max(s.maxCol, o.maxCol)
+	s.perimeter += o.perimeter
+	s.sumRow += o.sumRow
+	s.sumCol += o.sumCol
+}
+
+func (s *stats) component(label int) Component {
+	return Component{
+		Label:       label,
+		Area:        s.area,
+		TopLeft:     [2]int{s.minRow, s.minCol},
+		BottomRight: [2]int{s.maxRow, s.maxCol},
+		Perimeter:   s.perimeter,
+		Centroid:    [2]float64{s.sumRow / float64(s.area), s.sumCol / float64(s.area)},
+	}
+}
+
+func Equal[T comparable](value T) func(T) bool {
+	return func(v T) bool { return v == value }
+}
+
+func edgePerimeter(left, up bool) int {
+	p := 4
+	if left {
+		p -= 2
+	}
+	if up {
+		p -= 2
+	}
+	return p
+}
+
+func priorOffsets(conn Connectivity) [][2]int {
+	if conn == Eight {
+		return [][2]int{{0, -1}, {-1, -1}, {-1, 0}, {-1, 1}}
+	}
+	return [][2]int{{0, -1}, {-1, 0}}
+}
+
+func Label[T any](grid [][]T, conn Connectivity, foreground func(T) bool) ([][]int, []Component, error) {
+	if conn != Four && conn != Eight {
+		return nil, nil, ErrBadConnectivity
+	}
+	rows := len(grid)
+	cols := 0
+	if rows > 0 {
+		cols = len(grid[0])
+	}
+
+	fg := make([][]bool, rows)
+	for r, row := range grid {
+		if len(row) != cols {
+			return nil, nil, ErrRaggedGrid
+		}
+		fg[r] = make([]bool, cols)
+		for c, v := range row {
+			fg[r][c] = foreground(v)
+		}
+	}
+
+	labels := make([][]int, rows)
+	for r := range labels {
+		labels[r] = make([]int, cols)
+	}
+
+	uf := kruskal_algorithm.NewUnionFind(rows*cols + 1)
+	next := 1
+	offsets := priorOffsets(conn)
+
+	for r := range rows {
+		for c := range cols {
+			if !fg[r][c] {
+				continue
+			}
+
+			label := 0
+			for _, d := range offsets {
+				nr, nc := r+d[0], c+d[1]
+				if nr < 0 || nc < 0 || nc >= cols || labels[nr][nc] == 0 {
+					continue
+				}
+				if label == 0 {
+					label = labels[nr][nc]
+				} else {
+					uf.Union(label, labels[nr][nc])
+				}
+			}
+			if label == 0 {
+				label = next
+				next++
+			}
+			labels[r][c] = label
+		}
+	}
+
+	final := make([]int, next)
+	var acc []*stats
+	for r := range rows {
+		for c := range cols {
+			if labels[r][c] == 0 {
+				continue
+			}
+
+			root := uf.Find(labels[r][c])
+			if final[root] == 0 {
+				acc = append(acc, newStats(r, c))
+				final[root] = len(acc)
+			}
+			labels[r][c] = final[root]
+
+			left := c > 0 && fg[r][c-1]
+			up := r > 0 && fg[r-1][c]
+			acc[final[root]-1].add(r, c, edgePerimeter(left, up))
+		}
+	}
+
+	components := make([]Component, len(acc))
+	for i, s := range acc {
+		components[i] = s.component(i + 1)
+	}
+	return labels, components, nil
+}
+
+type Labeler[T any] struct {
+	width      int
+	offsets    [][2]int
+	foreground func(T) bool
+	emit       func(Component)
+
+	row     int
+	prevFG  []bool
+	prev    []int
+	parent  map[int]int
+	stats   map[int]*stats
+	nextID  int
+	emitted int
+	closed  bool
+}
+
+func NewLabeler[T any](width int, conn Connectivity, foreground func(T) bool, emit func(Component)) (*Labeler[T], error) {
+	if conn != Four && conn != Eight {
+		return nil, ErrBadConnectivity
+	}
+	return &Labeler[T]{
+		width:      width,
+		offsets:    priorOffsets(conn),
+		foreground: foreground,
+		emit:       emit,
+		prevFG:     make([]bool, width),
+		prev:       make([]int, width),
+		parent:     make(map[int]int),
+		stats:      make(map[int]*stats),
+		nextID:     1,
+	}, nil
+}
+
+func (l *Labeler[T]) find(id int) int {
+	for l.parent[id] != id {
+		l.parent[id] = l.parent[l.parent[id]]
+		id = l.parent[id]
+	}
+	return id
+}
+
+func (l *Labeler[T]) union(a, b int) int {
+	a, b = l.find(a), l.find(b)
+	if a == b {
+		return a
+	}
+	if b < a {
+		a, b = b, a
+	}
+	l.parent[b] = a
+	l.stats[a].merge(l.stats[b])
+	delete(l.stats, b)
+	return a
+}
+
+func (l *Labeler[T]) Push(row []T) error {
+	if l.closed {
+		return ErrClosed
+	}
+	if len(row) != l.width {
+		return ErrRowWidth
+	}
+
+	fg := make([]bool, l.width)
+	cur := make([]int, l.width)
+	for c, v := range row {
+		fg[c] = l.foreground(v)
+	}
+
+	for c := range l.width {
+		if !fg[c] {
+			continue
+		}
+
+		id := 0
+		for _, d := range l.offsets {
+			nc := c + d[1]
+			if nc < 0 || nc >= l.width {
+				continue
+			}
+			neighbor := cur[nc]
+			if d[0] < 0 {
+				neighbor = l.prev[nc]
+			}
+			if neighbor == 0 {
+				continue
+			}
+			if id == 0 {
+				id = l.find(neighbor)
+			} else {
+				id = l.union(id, neighbor)
+			}
+		}
+		if id == 0 {
+			id = l.nextID
+			l.nextID++
+			l.parent[id] = id
+			l.stats[id] = newStats(l.row, c)
+		}
+		cur[c] = id
+
+		left := c > 0 && fg[c-1]
+		l.stats[id].add(l.row, c, edgePerimeter(left, l.prevFG[c]))
+	}
+
+	alive := make(map[int]bool)
+	for c, id := range cur {
+		if id != 0 {
+			cur[c] = l.find(id)
+			alive[cur[c]] = true
+		}
+	}
+	l.finish(alive)
+
+	l.prev, l.prevFG = cur, fg
+	l.row++
+	return nil
+}
+
+func (l *Labeler[T]) finish(alive map[int]bool) {
+	var done []int
+	for _, id := range l.prev {
+		if id == 0 {
+			continue
+		}
+		root := l.find(id)
+		if !alive[root] {
+			done = append(done, root)
+		}
+	}
+	slices.Sort(done)
+	done = slices.Compact(done)
+
+	for _, root := range done {
+		l.emitted++
+		l.emit(l.stats[root].component(l.emitted))
+		delete(l.stats, root)
+	}
+
+	clear(l.parent)
+	for root := range alive {
+		l.parent[root] = root
+	}
+}
+
+func (l *Labeler[T]) Close() error {
+	if l.closed {
+		return ErrClosed
+	}
+	l.finish(nil)
+	l.closed = true
+	return nil
+}
+
+func (l *Labeler[T]) Rows() int {
+	return l.row
+}
+
+func Run() any {
+	land := [][]int{
+		{1, 1, 0, 0, 1},
+		{1, 0, 0, 1, 1},
+		{0, 0, 1, 0, 0},
+		{1, 0, 1, 1, 0},
+		{1, 1, 0, 1, 0},
+	}
+
+	four, fourComponents, _ := Label(land, Four, Equal(1))
+	eight, eightComponents, _ := Label(land, Eight, Equal(1))
+
+	var streamed []Component
+	labeler, _ := NewLabeler(len(land[0]), Eight, Equal(1), func(c Component) {
+		streamed = append(streamed, c)
+	})
+	for _, row := range land {
+		labeler.Push(row)
+	}
+	labeler.Close()
+
+	return map[string]any{
+		"land":         land,
+		"labels_4":     four,
+		"components_4": fourComponents,
+		"labels_8":     eight,
+		"components_8": eightComponents,
+		"streamed_8":   streamed,
+	}
+}
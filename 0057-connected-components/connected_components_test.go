@@ -0,0 +1,272 @@
+package connected_components
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func floodFill(grid [][]bool, conn Connectivity) [][]int {
+	labels := make([][]int, len(grid))
+	for r := range labels {
+		labels[r] = make([]int, len(grid[r]))
+	}
+
+	next := 0
+	for r := range grid {
+		for c := range grid[r] {
+			if !grid[r][c] || labels[r][c] != 0 {
+				continue
+			}
+			next++
+			labels[r][c] = next
+			stack := [][2]int{{r, c}}
+			for len(stack) > 0 {
+				p := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				for dr := -1; dr <= 1; dr++ {
+					for dc := -1; dc <= 1; dc++ {
+						if (dr == 0 && dc == 0) || (conn == Four && dr != 0 && dc != 0) {
+							continue
+						}
+						nr, nc := p[0]+dr, p[1]+dc
+						if nr >= 0 && nr < len(grid) && nc >= 0 && nc < len(grid[nr]) && grid[nr][nc] && labels[nr][nc] == 0 {
+							labels[nr][nc] = next
+							stack = append(stack, [2]int{nr, nc})
+						}
+					}
+				}
+			}
+		}
+	}
+	return labels
+}
+
+func randomGrid(rng *rand.Rand, rows, cols int, density float64) [][]bool {
+	grid := make([][]bool, rows)
+	for r := range grid {
+		grid[r] = make([]bool, cols)
+		for c := range grid[r] {
+			grid[r][c] = rng.Float64() < density
+		}
+	}
+	return grid
+}
+
+func isTrue(b bool) bool { return b }
+
+func streamAll(t *testing.T, grid [][]bool, conn Connectivity) []Component {
+	t.Helper()
+
+	var got []Component
+	l, err := NewLabeler(len(grid[0]), conn, isTrue, func(c Component) { got = append(got, c) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, row := range grid {
+		if err := l.Push(row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return got
+}
+
+func withoutLabels(components []Component) []Component {
+	out := append([]Component{}, components...)
+	for i := range out {
+		out[i].Label = 0
+	}
+	slices.SortFunc(out, func(a, b Component) int {
+		if a.TopLeft[0] != b.TopLeft[0] {
+			return a.TopLeft[0] - b.TopLeft[0]
+		}
+		if a.TopLeft[1] != b.TopLeft[1] {
+			return a.TopLeft[1] - b.TopLeft[1]
+		}
+		return a.Area - b.Area
+	})
+	return out
+}
+
+func TestLabelMatchesFloodFill(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for trial := range 300 {
+		grid := randomGrid(rng, 1+rng.Intn(20), 1+rng.Intn(20), rng.Float64())
+		for _, conn := range []Connectivity{Four, Eight} {
+			labels, components, err := Label(grid, conn, isTrue)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := floodFill(grid, conn); !reflect.DeepEqual(labels, want) {
+				t.Fatalf("trial %d, %d-connectivity: expected labels %v, got %v", trial, conn, want, labels)
+			}
+
+			area := 0
+			for i, c := range components {
+				if c.Label != i+1 {
+					t.Fatalf("expected labels in raster order, got %d at %d", c.Label, i)
+				}
+				area += c.Area
+			}
+			cells := 0
+			for _, row := range grid {
+				for _, v := range row {
+					if v {
+						cells++
+					}
+				}
+			}
+			if area != cells {
+				t.Fatalf("expected component areas to sum to %d, got %d", cells, area)
+			}
+
+			if streamed := streamAll(t, grid, conn); !reflect.DeepEqual(withoutLabels(streamed), withoutLabels(components)) {
+				t.Fatalf("trial %d, %d-connectivity: streaming gave %v, batch gave %v", trial, conn, streamed, components)
+			}
+		}
+	}
+}
+
+func TestComponentStats(t *testing.T) {
+	grid := [][]int{
+		{1, 1, 1, 0, 0},
+		{1, 0, 1, 0, 2},
+		{1, 1, 1, 0, 0},
+		{0, 0, 0, 1, 0},
+	}
+
+	_, components, err := Label(grid, Four, Equal(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Component{
+		{Label: 1, Area: 8, TopLeft: [2]int{0, 0}, BottomRight: [2]int{2, 2}, Perimeter: 16, Centroid: [2]float64{1, 1}},
+		{Label: 2, Area: 1, TopLeft: [2]int{3, 3}, BottomRight: [2]int{3, 3}, Perimeter: 4, Centroid: [2]float64{3, 3}},
+	}
+	if !reflect.DeepEqual(components, want) {
+		t.Errorf("expected %v, got %v", want, components)
+	}
+
+	_, components, _ = Label(grid, Eight, Equal(1))
+	if len(components) != 1 || components[0].Area != 9 || components[0].Perimeter != 20 {
+		t.Errorf("expected the diagonal cell to join the ring under 8-connectivity, got %v", components)
+	}
+
+	_, components, _ = Label(grid, Four, func(v int) bool { return v == 2 })
+	if len(components) != 1 || components[0].TopLeft != [2]int{1, 4} {
+		t.Errorf("expected the predicate to select only the 2, got %v", components)
+	}
+}
+
+func TestFarmlandCompatibility(t *testing.T) {
+	land := [][]int{
+		{1, 0, 0, 0, 0, 0, 0, 0},
+		{0, 1, 1, 0, 0, 1, 1, 0},
+		{0, 1, 1, 0, 0, 1, 1, 0},
+		{0, 0, 0, 1, 0, 0, 0, 0},
+		{0, 0, 0, 1, 0, 0, 0, 0},
+	}
+
+	_, components, err := Label(land, Four, Equal(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var groups [][]int
+	for _, c := range components {
+		groups = append(groups, []int{c.TopLeft[0], c.TopLeft[1], c.BottomRight[0], c.BottomRight[1]})
+	}
+	want := [][]int{{0, 0, 0, 0}, {1, 1, 2, 2}, {1, 5, 2, 6}, {3, 3, 4, 3}}
+	if !reflect.DeepEqual(groups, want) {
+		t.Errorf("expected farmland groups %v, got %v", want, groups)
+	}
+}
+
+func TestStreamingBoundedState(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	width := 500
+
+	total := 0
+	l, _ := NewLabeler(width, Eight, isTrue, func(c Component) { total += c.Area })
+	cells := 0
+	for range 2000 {
+		row := randomGrid(rng, 1, width, 0.4)[0]
+		for _, v := range row {
+			if v {
+				cells++
+			}
+		}
+		l.Push(row)
+		if len(l.parent) > width || len(l.stats) > width {
+			t.Fatalf("expected state bounded by the row width, got %d parents and %d stats", len(l.parent), len(l.stats))
+		}
+	}
+	l.Close()
+
+	if total != cells || l.Rows() != 2000 {
+		t.Errorf("expected %d cells over 2000 rows, got %d over %d", cells, total, l.Rows())
+	}
+}
+
+func TestErrors(t *testing.T) {
+	if _, _, err := Label([][]int{{1, 0}, {1}}, Four, Equal(1)); !errors.Is(err, ErrRaggedGrid) {
+		t.Errorf("expected ErrRaggedGrid, got %v", err)
+	}
+	if _, _, err := Label([][]int{{1}}, Connectivity(6), Equal(1)); !errors.Is(err, ErrBadConnectivity) {
+		t.Errorf("expected ErrBadConnectivity, got %v", err)
+	}
+
+	l, _ := NewLabeler(3, Four, Equal(1), func(Component) {})
+	if err := l.Push([]int{1, 0}); !errors.Is(err, ErrRowWidth) {
+		t.Errorf("expected ErrRowWidth, got %v", err)
+	}
+	l.Close()
+	if err := l.Push([]int{1, 0, 1}); !errors.Is(err, ErrClosed) {
+		t.Errorf("expected ErrClosed, got %v", err)
+	}
+
+	labels, components, err := Label([][]int{}, Four, Equal(1))
+	if err != nil || len(labels) != 0 || len(components) != 0 {
+		t.Errorf("expected an empty grid to have no components, got %v %v %v", labels, components, err)
+	}
+}
+
+func TestRun(t *testing.T) {
+	result := Run()
+	if result == nil {
+		t.Error("Expected non-nil result")
+	}
+
+	resultMap, ok := result.(map[string]any)
+	if !ok {
+		t.Fatal("Expected result to be a map")
+	}
+	if len(resultMap["components_4"].([]Component)) != 4 || len(resultMap["components_8"].([]Component)) != 2 {
+		t.Errorf("unexpected components %v", resultMap)
+	}
+}
+
+func BenchmarkLabel(b *testing.B) {
+	grid := randomGrid(rand.New(rand.NewSource(3)), 1000, 1000, 0.5)
+	for b.Loop() {
+		Label(grid, Eight, isTrue)
+	}
+}
+
+func BenchmarkLabeler(b *testing.B) {
+	grid := randomGrid(rand.New(rand.NewSource(3)), 1000, 1000, 0.5)
+	for b.Loop() {
+		l, _ := NewLabeler(1000, Eight, isTrue, func(Component) {})
+		for _, row := range grid {
+			l.Push(row)
+		}
+		l.Close()
+	}
+}
@@ -0,0 +1,297 @@
+package dynamic_programming
+
+import (
+	"cmp"
+	"errors"
+)
+
+var (
+	ErrInfeasible   = errors.New("no sequence of choices reaches a base state")
+	ErrCycle        = errors.New("state depends on itself")
+	ErrNotTabulated = errors.New("choice refers to a state outside the tabulated window")
+	ErrBadWindow    = errors.New("window must be zero or positive")
+)
+
+type Goal int
+
+const (
+	Minimize Goal = iota
+	Maximize
+	Sum
+)
+
+type Choice[S comparable, V cmp.Ordered] struct {
+	Next  S
+	Value func(V) V
+}
+
+type Problem[S comparable, V cmp.Ordered] struct {
+	Goal    Goal
+	Base    func(S) (V, bool)
+	Choices func(S) []Choice[S, V]
+}
+
+type Table[S comparable] struct {
+	Stages   int
+	States   func(stage int) []S
+	Window   int
+	SkipPath bool
+}
+
+type Solution[S comparable, V cmp.Ordered] struct {
+	Value    V
+	Path     []S
+	States   int
+	Retained int
+}
+
+type cell[V cmp.Ordered] struct {
+	value    V
+	choice   int
+	feasible bool
+}
+
+func (c Choice[S, V]) apply(v V) V {
+	if c.Value == nil {
+		return v
+	}
+	return c.Value(v)
+}
+
+func (p Problem[S, V]) better(a, b V) bool {
+	if p.Goal == Maximize {
+		return a > b
+	}
+	return a < b
+}
+
+func (p Problem[S, V]) evaluate(s S, lookup func(S) (cell[V], error)) (cell[V], error) {
+	if v, ok := p.Base(s); ok {
+		return cell[V]{value: v, choice: -1, feasible: true}, nil
+	}
+
+	best := cell[V]{choice: -1}
+	for i, c := range p.Choices(s) {
+		next, err := lookup(c.Next)
+		if err != nil {
+			return cell[V]{}, err
+		}
+		if !next.feasible {
+			continue
+		}
+		v := c.apply(next.value)
+		switch {
+		case !best.feasible:
+			best = cell[V]{value: v, choice: i, feasible: true}
+		case p.Goal == Sum:
+			best.value += v
+		case p.better(v, best.value):
+			best.value, best.choice = v, i
+		}
+	}
+	return best, nil
+}
+
+func (p Problem[S, V]) path(root S, choice func(S) int) []S {
+	if p.Goal == Sum {
+		return nil
+	}
+	path := []S{root}
+	for s := root; ; {
+		i := choice(s)
+		if i < 0 {
+			return path
+		}
+		s = p.Choices(s)[i].Next
+		path = append(path, s)
+	}
+}
+
+func Memoize[S comparable, V cmp.Ordered](p Problem[S, V], root S) (Solution[S, V], error) {
+	memo := make(map[S]cell[V])
+	visiting := make(map[S]bool)
+
+	var solve func(S) (cell[V], error)
+	solve = func(s S) (cell[V], error) {
+		if c, ok := memo[s]; ok {
+			return c, nil
+		}
+		if visiting[s] {
+			return cell[V]{}, ErrCycle
+		}
+		visiting[s] = true
+		c, err := p.evaluate(s, solve)
+		delete(visiting, s)
+		if err != nil {
+			return cell[V]{}, err
+		}
+		memo[s] = c
+		return c, nil
+	}
+
+	c, err := solve(root)
+	if err != nil {
+		return Solution[S, V]{}, err
+	}
+	if !c.feasible {
+		return Solution[S, V]{}, ErrInfeasible
+	}
+
+	return Solution[S, V]{
+		Value:    c.value,
+		Path:     p.path(root, func(s S) int { return memo[s].choice }),
+		States:   len(memo),
+		Retained: len(memo),
+	}, nil
+}
+
+func Tabulate[S comparable, V cmp.Ordered](p Problem[S, V], t Table[S], root S) (Solution[S, V], error) {
+	if t.Window < 0 {
+		return Solution[S, V]{}, ErrBadWindow
+	}
+
+	var window []map[S]cell[V]
+	if t.Window == 0 {
+		window = append(window, make(map[S]cell[V]))
+	}
+	choices := make(map[S]int)
+
+	lookup := func(s S) (cell[V], error) {
+		if v, ok := p.Base(s); ok {
+			return cell[V]{value: v, choice: -1, feasible: true}, nil
+		}
+		for i := len(window) - 1; i >= 0; i-- {
+			if c, ok := window[i][s]; ok {
+				return c, nil
+			}
+		}
+		return cell[V]{}, ErrNotTabulated
+	}
+
+	states, retained := 0, 0
+	for stage := range t.Stages {
+		if t.Window > 0 {
+			if len(window) == t.Window {
+				oldest := window[0]
+				clear(oldest)
+				window = append(window[1:], oldest)
+			} else {
+				window = append(window, make(map[S]cell[V]))
+			}
+		}
+		current := window[len(window)-1]
+
+		for _, s := range t.States(stage) {
+			c, err := p.evaluate(s, lookup)
+			if err != nil {
+				return Solution[S, V]{}, err
+			}
+			current[s] = c
+			if c.choice >= 0 && p.Goal != Sum && !t.SkipPath {
+				choices[s] = c.choice
+			}
+			states++
+		}
+
+		held := len(choices)
+		for _, w := range window {
+			held += len(w)
+		}
+		retained = max(retained, held)
+	}
+
+	c, err := lookup(root)
+	if err != nil {
+		return Solution[S, V]{}, err
+	}
+	if !c.feasible {
+		return Solution[S, V]{}, ErrInfeasible
+	}
+
+	var path []S
+	if !t.SkipPath {
+		path = p.path(root, func(s S) int {
+			if i, ok := choices[s]; ok {
+				return i
+			}
+			return -1
+		})
+	}
+
+	return Solution[S, V]{
+		Value:    c.value,
+		Path:     path,
+		States:   states,
+		Retained: retained,
+	}, nil
+}
+
+type Pair struct {
+	I, J int
+}
+
+func LCS(a, b string) (Problem[Pair, int], Table[Pair]) {
+	p := Problem[Pair, int]{
+		Goal: Maximize,
+		Base: func(s Pair) (int, bool) {
+			return 0, s.I == len(a) || s.J == len(b)
+		},
+		Choices: func(s Pair) []Choice[Pair, int] {
+			if a[s.I] == b[s.J] {
+				return []Choice[Pair, int]{{Next: Pair{I: s.I + 1, J: s.J + 1}, Value: func(v int) int { return v + 1 }}}
+			}
+			return []Choice[Pair, int]{{Next: Pair{I: s.I + 1, J: s.J}}, {Next: Pair{I: s.I, J: s.J + 1}}}
+		},
+	}
+
+	t := Table[Pair]{
+		Stages: len(a),
+		States: func(stage int) []Pair {
+			row := make([]Pair, 0, len(b))
+			for col := len(b) - 1; col >= 0; col-- {
+				row = append(row, Pair{I: len(a) - 1 - stage, J: col})
+			}
+			return row
+		},
+		Window: 2,
+	}
+	return p, t
+}
+
+func LongestCommonSubsequence(a, b string) string {
+	p, t := LCS(a, b)
+	sol, err := Tabulate(p, t, Pair{})
+	if err != nil {
+		return ""
+	}
+
+	var common []byte
+	for k := 1; k < len(sol.Path); k++ {
+		prev, cur := sol.Path[k-1], sol.Path[k]
+		if cur.I == prev.I+1 && cur.J == prev.J+1 {
+			common = append(common, a[prev.I])
+		}
+	}
+	return string(common)
+}
+
+func Run() any {
+	a, b := "AGGTABQX", "GXTXAYBQ"
+	p, t := LCS(a, b)
+
+	memo, _ := Memoize(p, Pair{})
+	full, _ := Tabulate(p, Table[Pair]{Stages: t.Stages, States: t.States}, Pair{})
+	rolling, _ := Tabulate(p, t, Pair{})
+	valueOnly, _ := Tabulate(p, Table[Pair]{Stages: t.Stages, States: t.States, Window: t.Window, SkipPath: true}, Pair{})
+
+	return map[string]any{
+		"a":                a,
+		"b":                b,
+		"lcs":              LongestCommonSubsequence(a, b),
+		"length":           rolling.Value,
+		"memo_states":      memo.States,
+		"table_retained":   full.Retained,
+		"rolling_retained": rolling.Retained,
+		"value_retained":   valueOnly.Retained,
+	}
+}
@@ -0,0 +1,203 @@
+package dynamic_programming
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func bruteLCS(a, b string) int {
+	if a == "" || b == "" {
+		return 0
+	}
+	if a[0] == b[0] {
+		return 1 + bruteLCS(a[1:], b[1:])
+	}
+	return max(bruteLCS(a[1:], b), bruteLCS(a, b[1:]))
+}
+
+func isSubsequence(sub, s string) bool {
+	i := 0
+	for j := 0; i < len(sub) && j < len(s); j++ {
+		if sub[i] == s[j] {
+			i++
+		}
+	}
+	return i == len(sub)
+}
+
+func randomString(rng *rand.Rand, n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = "ABC"[rng.Intn(3)]
+	}
+	return string(b)
+}
+
+func TestLCSMemoAndTablesAgree(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for trial := range 300 {
+		a, b := randomString(rng, rng.Intn(9)), randomString(rng, rng.Intn(9))
+		want := bruteLCS(a, b)
+		p, table := LCS(a, b)
+
+		memo, err := Memoize(p, Pair{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		full, err := Tabulate(p, Table[Pair]{Stages: table.Stages, States: table.States}, Pair{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		rolling, err := Tabulate(p, table, Pair{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		valueOnly, err := Tabulate(p, Table[Pair]{Stages: table.Stages, States: table.States, Window: 2, SkipPath: true}, Pair{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for name, sol := range map[string]Solution[Pair, int]{"memo": memo, "full": full, "rolling": rolling, "value_only": valueOnly} {
+			if sol.Value != want {
+				t.Fatalf("trial %d %q %q: %s expected %d, got %d", trial, a, b, name, want, sol.Value)
+			}
+		}
+		if valueOnly.Retained > 2*len(b) || valueOnly.Path != nil {
+			t.Fatalf("expected a value-only rolling table to hold at most two rows and no path, held %d", valueOnly.Retained)
+		}
+		if rolling.Retained > 2*len(b)+len(a)*len(b) {
+			t.Fatalf("expected the rolling table to hold two rows plus one choice per state, held %d", rolling.Retained)
+		}
+		if full.Retained != 2*len(a)*len(b) {
+			t.Fatalf("expected the full table to hold %d values and choices, held %d", 2*len(a)*len(b), full.Retained)
+		}
+
+		common := LongestCommonSubsequence(a, b)
+		if len(common) != want || !isSubsequence(common, a) || !isSubsequence(common, b) {
+			t.Fatalf("trial %d: %q is not a longest common subsequence of %q and %q", trial, common, a, b)
+		}
+	}
+}
+
+func TestPathFollowsBestChoices(t *testing.T) {
+	costs := []int{0, 5, 1, 1, 9, 1}
+	p := Problem[int, int]{
+		Goal: Minimize,
+		Base: func(i int) (int, bool) { return 0, i == len(costs)-1 },
+		Choices: func(i int) []Choice[int, int] {
+			var out []Choice[int, int]
+			for step := 1; step <= 2 && i+step < len(costs); step++ {
+				cost := costs[i+step]
+				out = append(out, Choice[int, int]{Next: i + step, Value: func(v int) int { return v + cost }})
+			}
+			return out
+		},
+	}
+
+	sol, err := Memoize(p, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sol.Value != 3 || len(sol.Path) != 4 || sol.Path[1] != 2 || sol.Path[2] != 3 || sol.Path[3] != 5 {
+		t.Errorf("expected cost 3 along [0 2 3 5], got %d along %v", sol.Value, sol.Path)
+	}
+
+	p.Goal = Maximize
+	sol, _ = Memoize(p, 0)
+	if sol.Value != 17 || len(sol.Path) != 6 {
+		t.Errorf("expected the most expensive route to cost 17, got %d along %v", sol.Value, sol.Path)
+	}
+}
+
+func TestSumCountsWithoutPath(t *testing.T) {
+	fib := Problem[int, int]{
+		Goal: Sum,
+		Base: func(n int) (int, bool) { return n, n <= 1 },
+		Choices: func(n int) []Choice[int, int] {
+			return []Choice[int, int]{{Next: n - 1}, {Next: n - 2}}
+		},
+	}
+	table := Table[int]{Stages: 89, States: func(stage int) []int { return []int{stage + 2} }, Window: 3}
+
+	memo, err := Memoize(fib, 90)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rolling, err := Tabulate(fib, table, 90)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if memo.Value != 2880067194370816120 || rolling.Value != memo.Value {
+		t.Errorf("expected F(90) = 2880067194370816120, got %d and %d", memo.Value, rolling.Value)
+	}
+	if memo.Path != nil || rolling.Path != nil || rolling.Retained != 3 {
+		t.Errorf("expected no path and three retained values, got %v, %v and %d", memo.Path, rolling.Path, rolling.Retained)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	odd := Problem[int, int]{
+		Goal: Minimize,
+		Base: func(n int) (int, bool) { return 0, n == 0 },
+		Choices: func(n int) []Choice[int, int] {
+			if n < 2 {
+				return nil
+			}
+			return []Choice[int, int]{{Next: n - 2}}
+		},
+	}
+	if _, err := Memoize(odd, 7); !errors.Is(err, ErrInfeasible) {
+		t.Errorf("expected ErrInfeasible, got %v", err)
+	}
+
+	loop := Problem[int, int]{
+		Base:    func(int) (int, bool) { return 0, false },
+		Choices: func(n int) []Choice[int, int] { return []Choice[int, int]{{Next: (n + 1) % 3}} },
+	}
+	if _, err := Memoize(loop, 0); !errors.Is(err, ErrCycle) {
+		t.Errorf("expected ErrCycle, got %v", err)
+	}
+
+	p, table := LCS("ABCD", "ABCD")
+	table.Window = 1
+	if _, err := Tabulate(p, table, Pair{}); !errors.Is(err, ErrNotTabulated) {
+		t.Errorf("expected ErrNotTabulated, got %v", err)
+	}
+	table.Window = -1
+	if _, err := Tabulate(p, table, Pair{}); !errors.Is(err, ErrBadWindow) {
+		t.Errorf("expected ErrBadWindow, got %v", err)
+	}
+}
+
+func TestRun(t *testing.T) {
+	result := Run()
+	if result == nil {
+		t.Error("Expected non-nil result")
+	}
+
+	resultMap, ok := result.(map[string]any)
+	if !ok {
+		t.Fatal("Expected result to be a map")
+	}
+	if resultMap["length"] != 5 || len(resultMap["lcs"].(string)) != 5 {
+		t.Errorf("unexpected result %v", resultMap)
+	}
+}
+
+func BenchmarkMemoizeLCS(b *testing.B) {
+	rng := rand.New(rand.NewSource(2))
+	p, _ := LCS(randomString(rng, 300), randomString(rng, 300))
+	for b.Loop() {
+		Memoize(p, Pair{})
+	}
+}
+
+func BenchmarkTabulateLCS(b *testing.B) {
+	rng := rand.New(rand.NewSource(2))
+	p, table := LCS(randomString(rng, 300), randomString(rng, 300))
+	for b.Loop() {
+		Tabulate(p, table, Pair{})
+	}
+}